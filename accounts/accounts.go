@@ -107,6 +107,13 @@ func (aS *AccountS) matchingAccountsForEvent(tnt string, cgrEv *utils.CGREvent,
 			unlockAccountProfiles(acnts) // in case of errors will not have unlocks in upper layers
 			return
 		}
+		if lked && releaseExpiredReservations(qAcnt, time.Now()) {
+			if err = aS.dm.SetAccountProfile(qAcnt, false); err != nil {
+				guardian.Guardian.UnguardIDs(refID)
+				unlockAccountProfiles(acnts)
+				return
+			}
+		}
 		if _, isDisabled := qAcnt.Opts[utils.Disabled]; isDisabled ||
 			(qAcnt.ActivationInterval != nil && cgrEv.Time != nil &&
 				!qAcnt.ActivationInterval.IsActiveAtTime(*cgrEv.Time)) { // not active
@@ -197,8 +204,18 @@ func (aS *AccountS) accountDebit(acnt *utils.AccountProfile, usage *decimal.Big,
 	cgrEv *utils.CGREvent, concretes bool) (ec *utils.EventCharges, err error) {
 
 	// Find balances matching event
+	evTime := time.Now()
+	if cgrEv.Time != nil {
+		evTime = *cgrEv.Time
+	}
 	blcsWithWeight := make(utils.BalancesWithWeight, 0, len(acnt.Balances))
 	for _, blnCfg := range acnt.Balances {
+		var expired bool
+		if expired, err = balanceExpired(blnCfg, evTime); err != nil {
+			return
+		} else if expired {
+			continue
+		}
 		var weight float64
 		if weight, err = engine.WeightFromDynamics(blnCfg.Weights,
 			aS.fltrS, cgrEv.Tenant, cgrEv.AsDataProvider()); err != nil {
@@ -407,3 +424,53 @@ func (aS *AccountS) V1ActionRemoveBalance(args *utils.ArgsActRemoveBalances, rpl
 	*rply = utils.OK
 	return
 }
+
+// V1ActionResetAccount restores the balances of the account to their initial Units
+func (aS *AccountS) V1ActionResetAccount(args *utils.ArgsActResetAccount, rply *string) (err error) {
+	if args.AccountID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.AccountID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = aS.cfg.GeneralCfg().DefaultTenant
+	}
+	if _, err = guardian.Guardian.Guard(func() (interface{}, error) {
+		return nil, actResetAccount(aS.dm, tnt, args.AccountID, args.BalanceIDs)
+	}, aS.cfg.GeneralCfg().LockingTimeout,
+		utils.ConcatenatedKey(utils.CacheAccountProfiles, tnt, args.AccountID)); err != nil {
+		return
+	}
+	*rply = utils.OK
+	return
+}
+
+// V1ActionRolloverBalances renews the balances of the account applying their rollover options
+func (aS *AccountS) V1ActionRolloverBalances(args *utils.ArgsActRolloverBalances, rply *string) (err error) {
+	if args.AccountID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.AccountID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = aS.cfg.GeneralCfg().DefaultTenant
+	}
+	tm := time.Now()
+	if args.Time != nil {
+		tm = *args.Time
+	}
+	if _, err = guardian.Guardian.Guard(func() (_ interface{}, err error) {
+		var qAcnt *utils.AccountProfile
+		if qAcnt, err = aS.dm.GetAccountProfile(tnt, args.AccountID); err != nil {
+			return
+		}
+		if err = RolloverBalances(qAcnt, args.BalanceIDs, tm, args.Prorate,
+			aS.cfg.GeneralCfg().RoundingDecimals); err != nil {
+			return
+		}
+		return nil, aS.dm.SetAccountProfile(qAcnt, false)
+	}, aS.cfg.GeneralCfg().LockingTimeout,
+		utils.ConcatenatedKey(utils.CacheAccountProfiles, tnt, args.AccountID)); err != nil {
+		return
+	}
+	*rply = utils.OK
+	return
+}
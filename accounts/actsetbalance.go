@@ -40,6 +40,14 @@ func actSetAccount(dm *engine.DataManager, tnt, acntID string, diktats []*utils.
 			ID:     acntID,
 		}
 	}
+	if err = SetAccountDiktats(qAcnt, diktats, reset); err != nil {
+		return
+	}
+	return dm.SetAccountProfile(qAcnt, false)
+}
+
+// SetAccountDiktats updates the account based on the diktats without storing it
+func SetAccountDiktats(qAcnt *utils.AccountProfile, diktats []*utils.BalDiktat, reset bool) (err error) {
 	for _, dk := range diktats {
 		// check if we have a valid path(e.g. *balance.Test.ID)
 		path := strings.Split(dk.Path, utils.NestingSep)
@@ -75,16 +83,24 @@ func actSetAccount(dm *engine.DataManager, tnt, acntID string, diktats []*utils.
 			return utils.ErrWrongPath
 		}
 	}
-	return dm.SetAccountProfile(qAcnt, false)
+	return
 }
 
 // actSetAccountFields sets the fields inside the account
+// an empty value clears the field
 func actSetAccountFields(ac *utils.AccountProfile, path []string, value string) (err error) {
 	switch path[0] {
 	// the tenant and ID should come from user and should not change
 	case utils.FilterIDs:
-		ac.FilterIDs = utils.NewStringSet(strings.Split(value, utils.InfieldSep)).AsSlice()
+		ac.FilterIDs = nil
+		if value != utils.EmptyString {
+			ac.FilterIDs = utils.NewStringSet(strings.Split(value, utils.InfieldSep)).AsSlice()
+		}
 	case utils.ActivationIntervalString:
+		if value == utils.EmptyString {
+			ac.ActivationInterval = nil
+			return
+		}
 		// similar how the TP are loaded split the value based on ;
 		// the first element is ActivationTime and the second if any ExpiryTime
 		ac.ActivationInterval = &utils.ActivationInterval{}
@@ -96,20 +112,71 @@ func actSetAccountFields(ac *utils.AccountProfile, path []string, value string)
 			ac.ActivationInterval.ExpiryTime, err = utils.ParseTimeDetectLayout(valSpl[1], utils.EmptyString)
 		}
 	case utils.Weights:
-		ac.Weights, err = utils.NewDynamicWeightsFromString(value, utils.InfieldSep, utils.ANDSep)
+		ac.Weights = nil
+		if value != utils.EmptyString {
+			ac.Weights, err = utils.NewDynamicWeightsFromString(value, utils.InfieldSep, utils.ANDSep)
+		}
 	case utils.Opts:
+		if value == utils.EmptyString {
+			if len(path) == 1 { // no option specified so clear all of them
+				ac.Opts = nil
+				return
+			}
+			return utils.MapStorage(ac.Opts).Remove(path[1:])
+		}
 		if ac.Opts == nil { // if the options are not initialized already init them here
 			ac.Opts = make(map[string]interface{})
 		}
 		err = utils.MapStorage(ac.Opts).Set(path[1:], value)
 	case utils.ThresholdIDs:
-		ac.ThresholdIDs = utils.NewStringSet(strings.Split(value, utils.InfieldSep)).AsSlice()
+		ac.ThresholdIDs = nil
+		if value != utils.EmptyString {
+			ac.ThresholdIDs = utils.NewStringSet(strings.Split(value, utils.InfieldSep)).AsSlice()
+		}
 	default:
 		err = utils.ErrWrongPath
 	}
 	return
 }
 
+// actResetAccount restores the Units of the balances to the value configured in *initialUnits option
+// the balances without this option are reset to 0
+// if no balanceIDs are provided all the balances of the account are reset
+func actResetAccount(dm *engine.DataManager, tnt, acntID string, balIDs []string) (err error) {
+	var qAcnt *utils.AccountProfile
+	if qAcnt, err = dm.GetAccountProfile(tnt, acntID); err != nil {
+		return
+	}
+	if err = ResetAccountBalances(qAcnt, balIDs); err != nil {
+		return
+	}
+	return dm.SetAccountProfile(qAcnt, false)
+}
+
+// ResetAccountBalances restores the Units of the balances without storing the account
+func ResetAccountBalances(qAcnt *utils.AccountProfile, balIDs []string) (err error) {
+	if len(balIDs) == 0 {
+		balIDs = make([]string, 0, len(qAcnt.Balances))
+		for balID := range qAcnt.Balances {
+			balIDs = append(balIDs, balID)
+		}
+	}
+	for _, balID := range balIDs {
+		bal, has := qAcnt.Balances[balID]
+		if !has {
+			return utils.ErrPrefixNotFound(balID)
+		}
+		units := utils.NewDecimal(0, 0)
+		if initUnits, has := bal.Opts[utils.MetaInitialUnits]; has {
+			if units, err = utils.NewDecimalFromString(utils.IfaceAsString(initUnits)); err != nil {
+				return
+			}
+		}
+		bal.Units = units
+	}
+	return
+}
+
 // actSetBalance will set the field at path from balance with value
 // value is string as the value received from action is string
 // the balance must not be nil
@@ -454,3 +454,99 @@ func TestActSetCostIncrementErrors(t *testing.T) {
 		t.Errorf("Expected %+v, received %+v", expected, err)
 	}
 }
+
+func TestActSetAccountFieldsClear(t *testing.T) {
+	accPrf := &utils.AccountProfile{
+		Tenant:    "cgrates.org",
+		ID:        "1001",
+		FilterIDs: []string{"*string:~*req.ToR:*sms"},
+		ActivationInterval: &utils.ActivationInterval{
+			ActivationTime: time.Date(2014, 7, 29, 15, 0, 0, 0, time.UTC),
+		},
+		Weights: []*utils.DynamicWeight{{Weight: 10}},
+		Opts: map[string]interface{}{
+			utils.Disabled:     "true",
+			utils.AccountField: "1004",
+		},
+		ThresholdIDs: []string{"TH_ID1"},
+	}
+	for _, path := range [][]string{
+		{utils.FilterIDs},
+		{utils.ActivationIntervalString},
+		{utils.Weights},
+		{utils.Opts, utils.Disabled},
+		{utils.ThresholdIDs},
+	} {
+		if err := actSetAccountFields(accPrf, path, utils.EmptyString); err != nil {
+			t.Error(err)
+		}
+	}
+	expected := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Opts: map[string]interface{}{
+			utils.AccountField: "1004",
+		},
+	}
+	if !reflect.DeepEqual(expected, accPrf) {
+		t.Errorf("Expected %+v, received %+v", utils.ToJSON(expected), utils.ToJSON(accPrf))
+	}
+	if err := actSetAccountFields(accPrf, []string{utils.Opts}, utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if accPrf.Opts != nil {
+		t.Errorf("Expected nil Opts, received %+v", accPrf.Opts)
+	}
+}
+
+func TestActResetAccount(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	if err := dm.SetAccountProfile(&utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"MONTHLY_SMS": {
+				ID:    "MONTHLY_SMS",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(3, 0),
+				Opts: map[string]interface{}{
+					utils.MetaInitialUnits: 100.,
+				},
+			},
+			"MONETARY": {
+				ID:    "MONETARY",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(5, 0),
+			},
+		},
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := actResetAccount(dm, "cgrates.org", "1001", []string{"MONTHLY_SMS"}); err != nil {
+		t.Fatal(err)
+	}
+	acnt, err := dm.GetAccountProfile("cgrates.org", "1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := acnt.Balances["MONTHLY_SMS"].Units; rcv.Compare(utils.NewDecimal(100, 0)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 100, rcv)
+	}
+	if rcv := acnt.Balances["MONETARY"].Units; rcv.Compare(utils.NewDecimal(5, 0)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 5, rcv)
+	}
+	if err := actResetAccount(dm, "cgrates.org", "1001", nil); err != nil {
+		t.Fatal(err)
+	}
+	if acnt, err = dm.GetAccountProfile("cgrates.org", "1001"); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := acnt.Balances["MONETARY"].Units; rcv.Compare(utils.NewDecimal(0, 0)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 0, rcv)
+	}
+	expected := "NOT_FOUND:UNKNOWN"
+	if err := actResetAccount(dm, "cgrates.org", "1001", []string{"UNKNOWN"}); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+}
@@ -0,0 +1,276 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package accounts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/ericlagergren/decimal"
+)
+
+// reservedUnits returns the units debited out of each balance compared with the backup
+func reservedUnits(acnt *utils.AccountProfile, bkp utils.AccountBalancesBackup) (units map[string]*utils.Decimal) {
+	units = make(map[string]*utils.Decimal)
+	for blncID, bkpVal := range bkp {
+		blnc, has := acnt.Balances[blncID]
+		if !has {
+			continue
+		}
+		if dbted := utils.SubstractBig(bkpVal, blnc.Units.Big); dbted.Cmp(decimal.New(0, 0)) > 0 {
+			units[blncID] = &utils.Decimal{dbted}
+		}
+	}
+	return
+}
+
+// refundReservation gives back the reserved units to the balances and removes the reservation
+func refundReservation(acnt *utils.AccountProfile, rsvID string) {
+	rsv, has := acnt.Reservations[rsvID]
+	if !has {
+		return
+	}
+	for blncID, units := range rsv.Units {
+		if blnc, has := acnt.Balances[blncID]; has { // the balance could have been removed in the meantime
+			blnc.Units.Big = utils.SumBig(blnc.Units.Big, units.Big)
+		}
+	}
+	delete(acnt.Reservations, rsvID)
+	if len(acnt.Reservations) == 0 {
+		acnt.Reservations = nil
+	}
+}
+
+// releaseExpiredReservations refunds the reservations expired at tm
+func releaseExpiredReservations(acnt *utils.AccountProfile, tm time.Time) (released bool) {
+	for rsvID, rsv := range acnt.Reservations {
+		if rsv.Expired(tm) {
+			refundReservation(acnt, rsvID)
+			released = true
+		}
+	}
+	return
+}
+
+// reservationAccounts locks and returns the accounts holding the reservation
+func (aS *AccountS) reservationAccounts(tnt string, acntIDs []string,
+	rsvID string) (acnts utils.AccountProfilesWithWeight, err error) {
+	for _, acntID := range acntIDs {
+		refID := guardian.Guardian.GuardIDs("", aS.cfg.GeneralCfg().LockingTimeout,
+			utils.ConcatenatedKey(utils.CacheAccountProfiles, tnt, acntID))
+		var qAcnt *utils.AccountProfile
+		if qAcnt, err = aS.dm.GetAccountProfile(tnt, acntID); err != nil {
+			guardian.Guardian.UnguardIDs(refID)
+			if err == utils.ErrNotFound {
+				err = nil
+				continue
+			}
+			unlockAccountProfiles(acnts)
+			return
+		}
+		if _, has := qAcnt.Reservations[rsvID]; !has {
+			guardian.Guardian.UnguardIDs(refID)
+			continue
+		}
+		acnts = append(acnts, &utils.AccountProfileWithWeight{qAcnt, 0, refID})
+	}
+	if len(acnts) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// storeAccounts saves the accounts, restoring the ones already saved in case of errors
+func (aS *AccountS) storeAccounts(acnts utils.AccountProfilesWithWeight,
+	bkps []*utils.AccountProfile) (err error) {
+	for i, acnt := range acnts {
+		if err = aS.dm.SetAccountProfile(acnt.AccountProfile, false); err != nil {
+			for _, bkp := range bkps[:i] {
+				if errRst := aS.dm.SetAccountProfile(bkp, false); errRst != nil {
+					utils.Logger.Warning(fmt.Sprintf("<%s> error <%s> restoring account <%s>",
+						utils.AccountS, errRst, bkp.TenantID()))
+				}
+			}
+			return
+		}
+	}
+	return
+}
+
+// V1ReserveAbstracts debits the abstract units for the event and holds them until commit or release
+func (aS *AccountS) V1ReserveAbstracts(args *utils.ArgsAccountsForEvent, rsv *utils.AccountsReservation) (err error) {
+	var acnts utils.AccountProfilesWithWeight
+	if acnts, err = aS.matchingAccountsForEvent(args.CGREvent.Tenant,
+		args.CGREvent, args.AccountIDs, true); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	defer unlockAccountProfiles(acnts)
+
+	rsvID := utils.IfaceAsString(args.Opts[utils.OptsAccountsReservationID])
+	if rsvID == utils.EmptyString {
+		rsvID = utils.GenUUID()
+	}
+	// the reservation_ttl limits the units held, it is also used when the reservation has no TTL
+	ttl := aS.cfg.AccountSCfg().ReservationTTL
+	if ttlIface, has := args.Opts[utils.OptsAccountsReservationTTL]; has {
+		var rsvTTL time.Duration
+		if rsvTTL, err = utils.IfaceAsDuration(ttlIface); err != nil {
+			return
+		}
+		if ttl == 0 || rsvTTL < ttl {
+			ttl = rsvTTL
+		}
+	}
+	var expTime *time.Time
+	if ttl != 0 {
+		expTime = utils.TimePointer(time.Now().Add(ttl))
+	}
+	bkps := make([]*utils.AccountProfile, len(acnts))
+	blncBkps := make([]utils.AccountBalancesBackup, len(acnts))
+	for i, acnt := range acnts {
+		if _, has := acnt.AccountProfile.Reservations[rsvID]; has {
+			return fmt.Errorf("reservation <%s> already exists on account <%s>",
+				rsvID, acnt.AccountProfile.TenantID())
+		}
+		bkps[i] = acnt.AccountProfile.Clone()
+		blncBkps[i] = acnt.AccountProfile.AccountBalancesBackup()
+	}
+	// debit without storing, the accounts are stored together with the reservation
+	var procEC *utils.EventCharges
+	if procEC, err = aS.accountsDebit(acnts, args.CGREvent, false, false); err != nil {
+		return
+	}
+	var acntIDs []string
+	altered := make(utils.AccountProfilesWithWeight, 0, len(acnts))
+	altBkps := make([]*utils.AccountProfile, 0, len(acnts))
+	for i, acnt := range acnts {
+		units := reservedUnits(acnt.AccountProfile, blncBkps[i])
+		if len(units) == 0 {
+			continue
+		}
+		if acnt.AccountProfile.Reservations == nil {
+			acnt.AccountProfile.Reservations = make(map[string]*utils.AccountReservation)
+		}
+		acnt.AccountProfile.Reservations[rsvID] = &utils.AccountReservation{
+			ID:         rsvID,
+			Event:      args.CGREvent.Clone(),
+			Units:      units,
+			ExpiryTime: expTime,
+		}
+		acntIDs = append(acntIDs, acnt.AccountProfile.ID)
+		altered = append(altered, acnt)
+		altBkps = append(altBkps, bkps[i])
+	}
+	if err = aS.storeAccounts(altered, altBkps); err != nil {
+		return
+	}
+	var rcvEec *utils.ExtEventCharges
+	if rcvEec, err = procEC.AsExtEventCharges(); err != nil {
+		return
+	}
+	*rsv = utils.AccountsReservation{
+		ReservationID: rsvID,
+		AccountIDs:    acntIDs,
+		ExpiryTime:    expTime,
+		EventCharges:  rcvEec,
+	}
+	return
+}
+
+// V1CommitReservation debits the used units out of the reservation and releases the rest
+func (aS *AccountS) V1CommitReservation(args *utils.ArgsAccountsReservation, eEc *utils.ExtEventCharges) (err error) {
+	if args.ReservationID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ReservationID)
+	}
+	if len(args.AccountIDs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.AccountIDs)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = aS.cfg.GeneralCfg().DefaultTenant
+	}
+	var acnts utils.AccountProfilesWithWeight
+	if acnts, err = aS.reservationAccounts(tnt, args.AccountIDs, args.ReservationID); err != nil {
+		return
+	}
+	defer unlockAccountProfiles(acnts)
+
+	bkps := make([]*utils.AccountProfile, len(acnts))
+	for i, acnt := range acnts {
+		bkps[i] = acnt.AccountProfile.Clone()
+	}
+	cgrEv := acnts[0].AccountProfile.Reservations[args.ReservationID].Event.Clone()
+	// give back the reserved units and debit the committed usage
+	for _, acnt := range acnts {
+		refundReservation(acnt.AccountProfile, args.ReservationID)
+	}
+	if args.Usage != nil {
+		cgrEv.Event[utils.Usage] = *args.Usage
+	}
+	procEC := utils.NewEventCharges()
+	if args.Usage == nil || *args.Usage != 0 {
+		if procEC, err = aS.accountsDebit(acnts, cgrEv, false, false); err != nil {
+			return
+		}
+	}
+	if err = aS.storeAccounts(acnts, bkps); err != nil {
+		return
+	}
+	var rcvEec *utils.ExtEventCharges
+	if rcvEec, err = procEC.AsExtEventCharges(); err != nil {
+		return
+	}
+	*eEc = *rcvEec
+	return
+}
+
+// V1ReleaseReservation gives back all the units held by the reservation
+func (aS *AccountS) V1ReleaseReservation(args *utils.ArgsAccountsReservation, rply *string) (err error) {
+	if args.ReservationID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ReservationID)
+	}
+	if len(args.AccountIDs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.AccountIDs)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = aS.cfg.GeneralCfg().DefaultTenant
+	}
+	var acnts utils.AccountProfilesWithWeight
+	if acnts, err = aS.reservationAccounts(tnt, args.AccountIDs, args.ReservationID); err != nil {
+		return
+	}
+	defer unlockAccountProfiles(acnts)
+
+	bkps := make([]*utils.AccountProfile, len(acnts))
+	for i, acnt := range acnts {
+		bkps[i] = acnt.AccountProfile.Clone()
+		refundReservation(acnt.AccountProfile, args.ReservationID)
+	}
+	if err = aS.storeAccounts(acnts, bkps); err != nil {
+		return
+	}
+	*rply = utils.OK
+	return
+}
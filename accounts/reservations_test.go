@@ -0,0 +1,313 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package accounts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/ericlagergren/decimal"
+)
+
+func testReservationAccountS(t *testing.T) (accnts *AccountS, accPrf *utils.AccountProfile) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltr := engine.NewFilterS(cfg, nil, dm)
+	accnts = NewAccountS(cfg, fltr, nil, dm)
+	accPrf = &utils.AccountProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TestReservations",
+		FilterIDs: []string{"*string:~*req.Account:1004"},
+		Balances: map[string]*utils.Balance{
+			"ConcreteBalance1": &utils.Balance{
+				ID:    "ConcreteBalance1",
+				Type:  utils.MetaConcrete,
+				Units: &utils.Decimal{decimal.New(100, 0)},
+				CostIncrements: []*utils.CostIncrement{
+					&utils.CostIncrement{
+						Increment:    &utils.Decimal{decimal.New(int64(time.Second), 0)},
+						FixedFee:     &utils.Decimal{decimal.New(0, 0)},
+						RecurrentFee: &utils.Decimal{decimal.New(1, 0)},
+					},
+				},
+			},
+		},
+	}
+	if err := accnts.dm.SetAccountProfile(accPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func testReservationUnits(t *testing.T, accnts *AccountS, expUnits float64, expRsvs int) {
+	t.Helper()
+	acnt, err := accnts.dm.GetAccountProfile("cgrates.org", "TestReservations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if units, _ := acnt.Balances["ConcreteBalance1"].Units.Float64(); units != expUnits {
+		t.Errorf("Expected %v units, received %v", expUnits, units)
+	}
+	if len(acnt.Reservations) != expRsvs {
+		t.Errorf("Expected %d reservations, received %s", expRsvs, utils.ToJSON(acnt.Reservations))
+	}
+}
+
+func TestV1ReserveAbstractsCommit(t *testing.T) {
+	accnts, _ := testReservationAccountS(t)
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			ID:     "TestV1ReserveAbstracts",
+			Tenant: "cgrates.org",
+			Event: map[string]interface{}{
+				utils.AccountField: "1004",
+				utils.Usage:        "27s",
+			},
+			Opts: map[string]interface{}{
+				utils.OptsAccountsReservationID: "RSV1",
+			},
+		},
+	}
+	var rsv utils.AccountsReservation
+	if err := accnts.V1ReserveAbstracts(args, &rsv); err != nil {
+		t.Fatal(err)
+	}
+	exp := utils.AccountsReservation{
+		ReservationID: "RSV1",
+		AccountIDs:    []string{"TestReservations"},
+		EventCharges: &utils.ExtEventCharges{
+			Abstracts: utils.Float64Pointer(float64(27 * time.Second)),
+		},
+	}
+	// without TTL the units are held for the reservation_ttl
+	if rsv.ExpiryTime == nil || rsv.ExpiryTime.After(time.Now().Add(time.Hour)) {
+		t.Errorf("Unexpected expiry time: %v", rsv.ExpiryTime)
+	}
+	rsv.ExpiryTime = nil
+	if !reflect.DeepEqual(exp, rsv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rsv))
+	}
+	testReservationUnits(t, accnts, 73, 1)
+
+	expErr := "reservation <RSV1> already exists on account <cgrates.org:TestReservations>"
+	if err := accnts.V1ReserveAbstracts(args, &rsv); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+
+	// commit only a part of the reserved usage
+	var eEc utils.ExtEventCharges
+	cmtArgs := &utils.ArgsAccountsReservation{
+		ReservationID: "RSV1",
+		AccountIDs:    []string{"TestReservations"},
+		Usage:         utils.DurationPointer(10 * time.Second),
+	}
+	if err := accnts.V1CommitReservation(cmtArgs, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	expEc := utils.ExtEventCharges{
+		Abstracts: utils.Float64Pointer(float64(10 * time.Second)),
+	}
+	if !reflect.DeepEqual(expEc, eEc) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expEc), utils.ToJSON(eEc))
+	}
+	testReservationUnits(t, accnts, 90, 0)
+
+	if err := accnts.V1CommitReservation(cmtArgs, &eEc); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
+func TestV1ReserveAbstractsCommitReservedUsage(t *testing.T) {
+	accnts, _ := testReservationAccountS(t)
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			ID:     "TestV1ReserveAbstracts",
+			Tenant: "cgrates.org",
+			Event: map[string]interface{}{
+				utils.AccountField: "1004",
+				utils.Usage:        "30s",
+			},
+		},
+	}
+	var rsv utils.AccountsReservation
+	if err := accnts.V1ReserveAbstracts(args, &rsv); err != nil {
+		t.Fatal(err)
+	} else if rsv.ReservationID == utils.EmptyString {
+		t.Error("Expected generated reservation ID")
+	}
+	var eEc utils.ExtEventCharges
+	if err := accnts.V1CommitReservation(&utils.ArgsAccountsReservation{
+		ReservationID: rsv.ReservationID,
+		AccountIDs:    rsv.AccountIDs,
+	}, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	testReservationUnits(t, accnts, 70, 0)
+}
+
+func TestV1ReleaseReservation(t *testing.T) {
+	accnts, _ := testReservationAccountS(t)
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			ID:     "TestV1ReleaseReservation",
+			Tenant: "cgrates.org",
+			Event: map[string]interface{}{
+				utils.AccountField: "1004",
+				utils.Usage:        "40s",
+			},
+			Opts: map[string]interface{}{
+				utils.OptsAccountsReservationID: "RSV1",
+			},
+		},
+	}
+	var rsv utils.AccountsReservation
+	if err := accnts.V1ReserveAbstracts(args, &rsv); err != nil {
+		t.Fatal(err)
+	}
+	testReservationUnits(t, accnts, 60, 1)
+
+	var reply string
+	if err := accnts.V1ReleaseReservation(&utils.ArgsAccountsReservation{}, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.ReservationID).Error() {
+		t.Errorf("Expected mandatory error, received %+v", err)
+	}
+	if err := accnts.V1ReleaseReservation(&utils.ArgsAccountsReservation{
+		ReservationID: "RSV1",
+		AccountIDs:    []string{"TestReservations"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %s", reply)
+	}
+	testReservationUnits(t, accnts, 100, 0)
+}
+
+func TestV1ReserveAbstractsExpired(t *testing.T) {
+	accnts, _ := testReservationAccountS(t)
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			ID:     "TestV1ReserveAbstractsExpired",
+			Tenant: "cgrates.org",
+			Event: map[string]interface{}{
+				utils.AccountField: "1004",
+				utils.Usage:        "50s",
+			},
+			Opts: map[string]interface{}{
+				utils.OptsAccountsReservationID:  "RSV1",
+				utils.OptsAccountsReservationTTL: "1ns",
+			},
+		},
+	}
+	var rsv utils.AccountsReservation
+	if err := accnts.V1ReserveAbstracts(args, &rsv); err != nil {
+		t.Fatal(err)
+	} else if rsv.ExpiryTime == nil {
+		t.Error("Expected expiry time for the reservation")
+	}
+	testReservationUnits(t, accnts, 50, 1)
+	time.Sleep(time.Millisecond)
+
+	// the expired reservation is given back before the new debit
+	args.Opts[utils.OptsAccountsReservationID] = "RSV2"
+	delete(args.Opts, utils.OptsAccountsReservationTTL)
+	if err := accnts.V1ReserveAbstracts(args, &rsv); err != nil {
+		t.Fatal(err)
+	}
+	testReservationUnits(t, accnts, 50, 1)
+	var eEc utils.ExtEventCharges
+	if err := accnts.V1CommitReservation(&utils.ArgsAccountsReservation{
+		ReservationID: "RSV1",
+		AccountIDs:    []string{"TestReservations"},
+	}, &eEc); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
+func TestV1ReserveAbstractsMaxTTL(t *testing.T) {
+	accnts, _ := testReservationAccountS(t)
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			ID:     "TestV1ReserveAbstractsMaxTTL",
+			Tenant: "cgrates.org",
+			Event: map[string]interface{}{
+				utils.AccountField: "1004",
+				utils.Usage:        "10s",
+			},
+			Opts: map[string]interface{}{
+				utils.OptsAccountsReservationTTL: "48h",
+			},
+		},
+	}
+	var rsv utils.AccountsReservation
+	if err := accnts.V1ReserveAbstracts(args, &rsv); err != nil {
+		t.Fatal(err)
+	} else if rsv.ExpiryTime == nil || rsv.ExpiryTime.After(time.Now().Add(time.Hour)) {
+		t.Errorf("Expected the TTL limited to the reservation_ttl, received: %v", rsv.ExpiryTime)
+	}
+	accnts.cfg.AccountSCfg().ReservationTTL = 0
+	if err := accnts.V1ReserveAbstracts(args, &rsv); err != nil {
+		t.Fatal(err)
+	} else if rsv.ExpiryTime == nil || rsv.ExpiryTime.Before(time.Now().Add(47*time.Hour)) {
+		t.Errorf("Expected the TTL of the reservation, received: %v", rsv.ExpiryTime)
+	}
+}
+
+func TestV1ReserveAbstractsProvisioned(t *testing.T) {
+	accnts, accPrf := testReservationAccountS(t)
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			ID:     "TestV1ReserveAbstractsProvisioned",
+			Tenant: "cgrates.org",
+			Event: map[string]interface{}{
+				utils.AccountField: "1004",
+				utils.Usage:        "30s",
+			},
+			Opts: map[string]interface{}{
+				utils.OptsAccountsReservationID: "RSV1",
+			},
+		},
+	}
+	var rsv utils.AccountsReservation
+	if err := accnts.V1ReserveAbstracts(args, &rsv); err != nil {
+		t.Fatal(err)
+	}
+	testReservationUnits(t, accnts, 70, 1)
+
+	// provisioning the account again keeps the units held by the reservation
+	accPrf.Balances["ConcreteBalance1"].Units = utils.NewDecimal(200, 0)
+	accPrf.Reservations = nil
+	if err := accnts.dm.SetProvisionedAccountProfile(accPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	testReservationUnits(t, accnts, 170, 1)
+
+	var reply string
+	if err := accnts.V1ReleaseReservation(&utils.ArgsAccountsReservation{
+		ReservationID: "RSV1",
+		AccountIDs:    []string{"TestReservations"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	testReservationUnits(t, accnts, 200, 0)
+}
@@ -0,0 +1,196 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package accounts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/ericlagergren/decimal"
+)
+
+// RolloverBalances renews the balances at the cycle boundary without storing the account
+// the unused units allowed by the rollover options are moved in a separate balance
+// that lives until the next rollover and the balance Units are restored to *initialUnits
+// with prorate only the units for the remaining part of the cycle are allocated
+// if no balanceIDs are provided all the balances with *initialUnits option are renewed
+func RolloverBalances(acnt *utils.AccountProfile, balIDs []string,
+	tm time.Time, prorate bool, rndDec int) (err error) {
+	if len(balIDs) == 0 {
+		for balID, bal := range acnt.Balances {
+			if _, has := bal.Opts[utils.MetaInitialUnits]; has {
+				balIDs = append(balIDs, balID)
+			}
+		}
+	}
+	for _, balID := range balIDs {
+		bal, has := acnt.Balances[balID]
+		if !has {
+			return utils.ErrPrefixNotFound(balID)
+		}
+		// the units carried at the previous rollover expire now
+		for rlvID, rlvBal := range acnt.Balances {
+			if utils.IfaceAsString(rlvBal.Opts[utils.MetaRolloverOf]) == balID {
+				delete(acnt.Balances, rlvID)
+			}
+		}
+		units := utils.NewDecimal(0, 0)
+		if initUnits, has := bal.Opts[utils.MetaInitialUnits]; has {
+			if units, err = utils.NewDecimalFromString(utils.IfaceAsString(initUnits)); err != nil {
+				return
+			}
+		}
+		if prorate {
+			var fctr *decimal.Big
+			if fctr, err = prorateFactor(bal, tm); err != nil {
+				return
+			}
+			units = &utils.Decimal{utils.MultiplyBig(units.Big, fctr).Quantize(rndDec)}
+		} else {
+			var carry *decimal.Big
+			if carry, err = rolloverUnits(bal); err != nil {
+				return
+			}
+			if carry.Cmp(decimal.New(0, 0)) > 0 {
+				rlvBal := bal.Clone()
+				rlvBal.ID = utils.ConcatenatedKey(balID, utils.MetaRollover)
+				rlvBal.Units = &utils.Decimal{carry}
+				for _, opt := range []string{utils.MetaInitialUnits, utils.MetaRolloverMaxUnits,
+					utils.MetaRolloverMaxPrcnt, utils.MetaRolloverCycle} {
+					delete(rlvBal.Opts, opt)
+				}
+				if rlvBal.Opts == nil {
+					rlvBal.Opts = make(map[string]interface{})
+				}
+				rlvBal.Opts[utils.MetaRolloverOf] = balID
+				rlvBal.Weights = rolloverWeights(bal.Weights)
+				acnt.Balances[rlvBal.ID] = rlvBal
+			}
+		}
+		bal.Units = units
+	}
+	return
+}
+
+// rolloverUnits returns the unused units of the balance that can be carried to the next cycle
+// without rollover options nothing is carried
+func rolloverUnits(bal *utils.Balance) (carry *decimal.Big, err error) {
+	carry = decimal.New(0, 0)
+	maxUnitsIface, hasMaxUnits := bal.Opts[utils.MetaRolloverMaxUnits]
+	maxPrcntIface, hasMaxPrcnt := bal.Opts[utils.MetaRolloverMaxPrcnt]
+	if bal.Units == nil ||
+		(!hasMaxUnits && !hasMaxPrcnt) ||
+		bal.Units.Cmp(carry) <= 0 {
+		return
+	}
+	carry = new(decimal.Big).Copy(bal.Units.Big)
+	if hasMaxPrcnt {
+		var maxPrcnt *utils.Decimal
+		if maxPrcnt, err = utils.NewDecimalFromString(utils.IfaceAsString(maxPrcntIface)); err != nil {
+			return
+		}
+		carry = utils.DivideBig(utils.MultiplyBig(carry, maxPrcnt.Big), decimal.New(100, 0))
+	}
+	if hasMaxUnits {
+		var maxUnits *utils.Decimal
+		if maxUnits, err = utils.NewDecimalFromString(utils.IfaceAsString(maxUnitsIface)); err != nil {
+			return
+		}
+		if carry.Cmp(maxUnits.Big) > 0 {
+			carry = maxUnits.Big
+		}
+	}
+	return
+}
+
+// rolloverWeights returns the weights of the carried units, higher than the ones of the source balance
+// so the carried units are consumed first
+func rolloverWeights(dWs utils.DynamicWeights) (rlvDWs utils.DynamicWeights) {
+	rlvDWs = make(utils.DynamicWeights, 0, len(dWs)+1)
+	for _, dW := range dWs {
+		rlvDW := dW.Clone()
+		rlvDW.Weight++
+		rlvDWs = append(rlvDWs, rlvDW)
+	}
+	if len(dWs) == 0 || len(dWs[len(dWs)-1].FilterIDs) != 0 {
+		// the source balance falls back on 0 when no weight matches
+		rlvDWs = append(rlvDWs, &utils.DynamicWeight{Weight: 1})
+	}
+	return
+}
+
+// prorateFactor returns the part of the cycle remaining after tm
+func prorateFactor(bal *utils.Balance, tm time.Time) (fctr *decimal.Big, err error) {
+	cycle, has := bal.Opts[utils.MetaRolloverCycle]
+	if !has {
+		return decimal.New(1, 0), nil // not a cyclic balance so allocate everything
+	}
+	var start, end time.Time
+	if start, end, err = cycleBounds(utils.IfaceAsString(cycle), tm); err != nil {
+		return
+	}
+	return utils.DivideBig(decimal.New(int64(end.Sub(tm)), 0),
+		decimal.New(int64(end.Sub(start)), 0)), nil
+}
+
+// cycleBounds returns the start and the end of the cycle containing tm
+// the cycle can be one of *daily, *weekly, *monthly, *yearly or a duration
+func cycleBounds(cycle string, tm time.Time) (start, end time.Time, err error) {
+	dayStart := time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, tm.Location())
+	switch cycle {
+	case utils.MetaDaily:
+		start = dayStart
+		end = start.AddDate(0, 0, 1)
+	case utils.MetaWeekly: // the week starts on Monday
+		start = dayStart.AddDate(0, 0, -(int(tm.Weekday())+6)%7)
+		end = start.AddDate(0, 0, 7)
+	case utils.MetaMonthly:
+		start = time.Date(tm.Year(), tm.Month(), 1, 0, 0, 0, 0, tm.Location())
+		end = start.AddDate(0, 1, 0)
+	case utils.MetaYearly:
+		start = time.Date(tm.Year(), 1, 1, 0, 0, 0, 0, tm.Location())
+		end = start.AddDate(1, 0, 0)
+	default:
+		var dur time.Duration
+		if dur, err = utils.ParseDurationWithNanosecs(cycle); err != nil {
+			return
+		}
+		if dur <= 0 {
+			err = fmt.Errorf("invalid %s: <%s>", utils.MetaRolloverCycle, cycle)
+			return
+		}
+		start = tm.Truncate(dur)
+		end = start.Add(dur)
+	}
+	return
+}
+
+// balanceExpired checks the *expiryTime option of the balance
+func balanceExpired(bal *utils.Balance, tm time.Time) (expired bool, err error) {
+	expIface, has := bal.Opts[utils.MetaExpiryTime]
+	if !has {
+		return
+	}
+	var expTime time.Time
+	if expTime, err = utils.IfaceAsTime(expIface, utils.EmptyString); err != nil {
+		return
+	}
+	return !tm.Before(expTime), nil
+}
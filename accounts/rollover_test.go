@@ -0,0 +1,306 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package accounts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestRolloverBalances(t *testing.T) {
+	acnt := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"MONTHLY_SMS": {
+				ID:    "MONTHLY_SMS",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(60, 0),
+				Opts: map[string]interface{}{
+					utils.MetaInitialUnits:     100.,
+					utils.MetaRolloverMaxPrcnt: 50.,
+					utils.MetaRolloverMaxUnits: "20",
+				},
+			},
+			"MONETARY": {
+				ID:    "MONETARY",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(5, 0),
+			},
+		},
+	}
+	tm := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := RolloverBalances(acnt, nil, tm, false, 5); err != nil {
+		t.Fatal(err)
+	}
+	if len(acnt.Balances) != 3 {
+		t.Fatalf("Unexpected balances: %s", utils.ToJSON(acnt.Balances))
+	}
+	if rcv := acnt.Balances["MONTHLY_SMS"].Units; rcv.Compare(utils.NewDecimal(100, 0)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 100, rcv)
+	}
+	if rcv := acnt.Balances["MONETARY"].Units; rcv.Compare(utils.NewDecimal(5, 0)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 5, rcv)
+	}
+	rlvID := "MONTHLY_SMS:*rollover"
+	expRlv := &utils.Balance{
+		ID:    rlvID,
+		Type:  utils.MetaAbstract,
+		Units: utils.NewDecimal(20, 0), // 50% of 60 limited to 20
+		Opts: map[string]interface{}{
+			utils.MetaRolloverOf: "MONTHLY_SMS",
+		},
+	}
+	if rcv := acnt.Balances[rlvID]; rcv == nil ||
+		rcv.Units.Compare(expRlv.Units) != 0 ||
+		!reflect.DeepEqual(expRlv.Opts, rcv.Opts) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expRlv), utils.ToJSON(rcv))
+	}
+
+	// the rolled units are not rolled again and expire after one cycle
+	acnt.Balances["MONTHLY_SMS"].Units = utils.NewDecimal(10, 0)
+	if err := RolloverBalances(acnt, []string{"MONTHLY_SMS"}, tm.AddDate(0, 1, 0), false, 5); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := acnt.Balances[rlvID]; rcv == nil || rcv.Units.Compare(utils.NewDecimal(5, 0)) != 0 {
+		t.Errorf("Expected %+v, received %s", 5, utils.ToJSON(rcv))
+	}
+	acnt.Balances["MONTHLY_SMS"].Units = utils.NewDecimal(0, 0)
+	if err := RolloverBalances(acnt, []string{"MONTHLY_SMS"}, tm.AddDate(0, 2, 0), false, 5); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := acnt.Balances[rlvID]; has {
+		t.Errorf("Expected the rollover balance to expire, received %s", utils.ToJSON(acnt.Balances))
+	}
+
+	expErr := "NOT_FOUND:UNKNOWN"
+	if err := RolloverBalances(acnt, []string{"UNKNOWN"}, tm, false, 5); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestRolloverBalancesProrate(t *testing.T) {
+	acnt := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"MONTHLY_SMS": {
+				ID:   "MONTHLY_SMS",
+				Type: utils.MetaAbstract,
+				Opts: map[string]interface{}{
+					utils.MetaInitialUnits:  100.,
+					utils.MetaRolloverCycle: utils.MetaMonthly,
+				},
+			},
+			"DAILY_DATA": {
+				ID:   "DAILY_DATA",
+				Type: utils.MetaAbstract,
+				Opts: map[string]interface{}{
+					utils.MetaInitialUnits:  1000.,
+					utils.MetaRolloverCycle: "6h",
+				},
+			},
+		},
+	}
+	// 477h remaining out of the 720h of April
+	tm := time.Date(2021, 4, 11, 3, 0, 0, 0, time.UTC)
+	if err := RolloverBalances(acnt, nil, tm, true, 2); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := acnt.Balances["MONTHLY_SMS"].Units; rcv.Compare(utils.NewDecimal(6625, 2)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 66.25, rcv)
+	}
+	if rcv := acnt.Balances["DAILY_DATA"].Units; rcv.Compare(utils.NewDecimal(500, 0)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 500, rcv)
+	}
+
+	acnt.Balances["DAILY_DATA"].Opts[utils.MetaRolloverCycle] = "-1h"
+	expErr := "invalid *rolloverCycle: <-1h>"
+	if err := RolloverBalances(acnt, []string{"DAILY_DATA"}, tm, true, 2); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestCycleBounds(t *testing.T) {
+	tm := time.Date(2021, 2, 14, 15, 30, 0, 0, time.UTC) // Sunday
+	for cycle, exp := range map[string][2]time.Time{
+		utils.MetaDaily: {
+			time.Date(2021, 2, 14, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+		utils.MetaWeekly: {
+			time.Date(2021, 2, 8, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+		utils.MetaMonthly: {
+			time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		utils.MetaYearly: {
+			time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"1h": {
+			time.Date(2021, 2, 14, 15, 0, 0, 0, time.UTC),
+			time.Date(2021, 2, 14, 16, 0, 0, 0, time.UTC),
+		},
+	} {
+		if start, end, err := cycleBounds(cycle, tm); err != nil {
+			t.Error(err)
+		} else if !start.Equal(exp[0]) || !end.Equal(exp[1]) {
+			t.Errorf("For %s expected %+v, received %+v", cycle, exp, [2]time.Time{start, end})
+		}
+	}
+	if _, _, err := cycleBounds("*unknown", tm); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestV1ActionRolloverBalances(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	accnts := NewAccountS(cfg, engine.NewFilterS(cfg, nil, dm), nil, dm)
+	tm := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := dm.SetAccountProfile(&utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"MONTHLY_SMS": {
+				ID:    "MONTHLY_SMS",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(30, 0),
+				Opts: map[string]interface{}{
+					utils.MetaInitialUnits:     100.,
+					utils.MetaRolloverMaxUnits: 50.,
+				},
+			},
+		},
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	var reply string
+	expErr := "MANDATORY_IE_MISSING: [AccountID]"
+	if err := accnts.V1ActionRolloverBalances(&utils.ArgsActRolloverBalances{}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+	if err := accnts.V1ActionRolloverBalances(&utils.ArgsActRolloverBalances{
+		AccountID: "1001",
+		Time:      &tm,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %s", reply)
+	}
+	acnt, err := dm.GetAccountProfile("cgrates.org", "1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := acnt.Balances["MONTHLY_SMS"].Units; rcv.Compare(utils.NewDecimal(100, 0)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 100, rcv)
+	}
+	if rcv := acnt.Balances["MONTHLY_SMS:*rollover"]; rcv == nil || rcv.Units.Compare(utils.NewDecimal(30, 0)) != 0 {
+		t.Errorf("Expected %+v, received %s", 30, utils.ToJSON(rcv))
+	}
+}
+
+func TestRolloverBalancesConsumedFirst(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	accnts := NewAccountS(cfg, engine.NewFilterS(cfg, nil, dm), nil, dm)
+	acnt := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"MONTHLY_BUNDLE": {
+				ID:      "MONTHLY_BUNDLE",
+				Type:    utils.MetaConcrete,
+				Weights: utils.DynamicWeights{{Weight: 10}},
+				Units:   utils.NewDecimal(6, 0),
+				Opts: map[string]interface{}{
+					utils.MetaInitialUnits:     10.,
+					utils.MetaRolloverMaxUnits: 4.,
+				},
+			},
+		},
+	}
+	if err := RolloverBalances(acnt, nil, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), false, 5); err != nil {
+		t.Fatal(err)
+	}
+	rlvID := "MONTHLY_BUNDLE:*rollover"
+	if rcv := acnt.Balances[rlvID]; rcv == nil ||
+		!reflect.DeepEqual(utils.DynamicWeights{{Weight: 11}}, rcv.Weights) {
+		t.Fatalf("Unexpected rollover balance: %s", utils.ToJSON(rcv))
+	}
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TestRolloverBalancesConsumedFirst",
+		Event:  map[string]interface{}{},
+	}
+	if _, err := accnts.accountDebit(acnt, utils.NewDecimal(5, 0).Big, cgrEv, true); err != nil {
+		t.Fatal(err)
+	}
+	// the carried units are consumed before the ones of the new cycle
+	if rcv := acnt.Balances[rlvID].Units; rcv.Compare(utils.NewDecimal(0, 0)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 0, rcv)
+	}
+	if rcv := acnt.Balances["MONTHLY_BUNDLE"].Units; rcv.Compare(utils.NewDecimal(9, 0)) != 0 {
+		t.Errorf("Expected %+v, received %+v", 9, rcv)
+	}
+}
+
+func TestRolloverWeights(t *testing.T) {
+	if rcv := rolloverWeights(nil); !reflect.DeepEqual(utils.DynamicWeights{{Weight: 1}}, rcv) {
+		t.Errorf("Received %s", utils.ToJSON(rcv))
+	}
+	exp := utils.DynamicWeights{
+		{FilterIDs: []string{"*string:~*req.Account:1001"}, Weight: 21},
+		{Weight: 1},
+	}
+	if rcv := rolloverWeights(utils.DynamicWeights{
+		{FilterIDs: []string{"*string:~*req.Account:1001"}, Weight: 20},
+	}); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestBalanceExpired(t *testing.T) {
+	tm := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	bal := &utils.Balance{ID: "B1"}
+	if expired, err := balanceExpired(bal, tm); err != nil || expired {
+		t.Errorf("Expected not expired, received %+v, %+v", expired, err)
+	}
+	bal.Opts = map[string]interface{}{utils.MetaExpiryTime: "2021-03-01T00:00:00Z"}
+	if expired, err := balanceExpired(bal, tm); err != nil || !expired {
+		t.Errorf("Expected expired, received %+v, %+v", expired, err)
+	}
+	if expired, err := balanceExpired(bal, tm.Add(-time.Second)); err != nil || expired {
+		t.Errorf("Expected not expired, received %+v, %+v", expired, err)
+	}
+	bal.Opts[utils.MetaExpiryTime] = "not_a_time"
+	if _, err := balanceExpired(bal, tm); err == nil {
+		t.Error("Expected error")
+	}
+}
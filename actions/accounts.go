@@ -21,7 +21,10 @@ package actions
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/cgrates/cgrates/accounts"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
@@ -54,27 +57,50 @@ func (aL *actSetBalance) execute(ctx context.Context, data utils.MapStorage, trg
 		Tenant:    aL.tnt,
 		AccountID: trgID,
 		Reset:     aL.reset,
-		Diktats:   make([]*utils.BalDiktat, len(aL.cfg().Diktats)),
 		Opts:      aL.cfg().Opts,
 	}
-	for i, actD := range aL.cfg().Diktats {
+	if args.Diktats, err = balDiktats(aL.config, aL.cfg(), data, utils.EmptyString); err != nil {
+		return
+	}
+	var rply string
+	return aL.connMgr.Call(aL.config.ActionSCfg().AccountSConns, nil,
+		utils.AccountSv1ActionSetBalance, args, &rply)
+}
+
+// dryRun implements actioner interface
+func (aL *actSetBalance) dryRun(_ context.Context, data utils.MapStorage, trgID string, state *dryRunState) (diff *utils.ActionDiff, err error) {
+	var diktats []*utils.BalDiktat
+	if diktats, err = balDiktats(aL.config, aL.cfg(), data, utils.EmptyString); err != nil {
+		return
+	}
+	return state.updateAccount(aL.tnt, trgID, true, func(acnt *utils.AccountProfile) error {
+		return accounts.SetAccountDiktats(acnt, diktats, aL.reset)
+	})
+}
+
+// balDiktats parses the values of the action diktats using the data
+// the pathPrefix is added to the paths not already starting with it
+func balDiktats(cfg *config.CGRConfig, aCfg *engine.APAction, data utils.MapStorage, pathPrefix string) (diktats []*utils.BalDiktat, err error) {
+	diktats = make([]*utils.BalDiktat, len(aCfg.Diktats))
+	for i, actD := range aCfg.Diktats {
 		var val string
 		var rsr config.RSRParsers
-		if rsr, err = actD.RSRValues(aL.config.GeneralCfg().RSRSep); err != nil {
+		if rsr, err = actD.RSRValues(cfg.GeneralCfg().RSRSep); err != nil {
 			return
 		}
 		if val, err = rsr.ParseDataProvider(data); err != nil {
 			return
 		}
-
-		args.Diktats[i] = &utils.BalDiktat{
-			Path:  actD.Path,
+		path := actD.Path
+		if !strings.HasPrefix(path, pathPrefix) {
+			path = pathPrefix + path
+		}
+		diktats[i] = &utils.BalDiktat{
+			Path:  path,
 			Value: val,
 		}
 	}
-	var rply string
-	return aL.connMgr.Call(aL.config.ActionSCfg().AccountSConns, nil,
-		utils.AccountSv1ActionSetBalance, args, &rply)
+	return
 }
 
 // actRemBalance will remove multiple balances from account
@@ -102,13 +128,218 @@ func (aL *actRemBalance) execute(ctx context.Context, data utils.MapStorage, trg
 	args := &utils.ArgsActRemoveBalances{
 		Tenant:     aL.tnt,
 		AccountID:  trgID,
-		BalanceIDs: make([]string, len(aL.cfg().Diktats)),
+		BalanceIDs: balanceIDs(aL.cfg()),
 		Opts:       aL.cfg().Opts,
 	}
-	for i, actD := range aL.cfg().Diktats {
-		args.BalanceIDs[i] = actD.Path
-	}
 	var rply string
 	return aL.connMgr.Call(aL.config.ActionSCfg().AccountSConns, nil,
 		utils.AccountSv1ActionRemoveBalance, args, &rply)
 }
+
+// dryRun implements actioner interface
+func (aL *actRemBalance) dryRun(_ context.Context, _ utils.MapStorage, trgID string, state *dryRunState) (diff *utils.ActionDiff, err error) {
+	return state.updateAccount(aL.tnt, trgID, false, func(acnt *utils.AccountProfile) error {
+		for _, balID := range balanceIDs(aL.cfg()) {
+			delete(acnt.Balances, balID)
+		}
+		return nil
+	})
+}
+
+// balanceIDs returns the balance IDs configured as diktat paths
+func balanceIDs(aCfg *engine.APAction) (balIDs []string) {
+	if len(aCfg.Diktats) == 0 {
+		return
+	}
+	balIDs = make([]string, len(aCfg.Diktats))
+	for i, actD := range aCfg.Diktats {
+		balIDs[i] = actD.Path
+	}
+	return
+}
+
+// actResetAccount will restore the balances of the account to their initial units
+type actResetAccount struct {
+	config  *config.CGRConfig
+	connMgr *engine.ConnManager
+	aCfg    *engine.APAction
+	tnt     string
+}
+
+func (aL *actResetAccount) id() string {
+	return aL.aCfg.ID
+}
+
+func (aL *actResetAccount) cfg() *engine.APAction {
+	return aL.aCfg
+}
+
+// execute implements actioner interface
+func (aL *actResetAccount) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	if len(aL.config.ActionSCfg().AccountSConns) == 0 {
+		return fmt.Errorf("no connection with AccountS")
+	}
+	args := &utils.ArgsActResetAccount{
+		Tenant:     aL.tnt,
+		AccountID:  trgID,
+		BalanceIDs: balanceIDs(aL.cfg()), // without diktats all the balances are reset
+		Opts:       aL.cfg().Opts,
+	}
+	var rply string
+	return aL.connMgr.Call(aL.config.ActionSCfg().AccountSConns, nil,
+		utils.AccountSv1ActionResetAccount, args, &rply)
+}
+
+// dryRun implements actioner interface
+func (aL *actResetAccount) dryRun(_ context.Context, _ utils.MapStorage, trgID string, state *dryRunState) (diff *utils.ActionDiff, err error) {
+	return state.updateAccount(aL.tnt, trgID, false, func(acnt *utils.AccountProfile) error {
+		return accounts.ResetAccountBalances(acnt, balanceIDs(aL.cfg()))
+	})
+}
+
+// actSetAccountField will update the fields of the account profile
+type actSetAccountField struct {
+	config  *config.CGRConfig
+	connMgr *engine.ConnManager
+	aCfg    *engine.APAction
+	tnt     string
+}
+
+func (aL *actSetAccountField) id() string {
+	return aL.aCfg.ID
+}
+
+func (aL *actSetAccountField) cfg() *engine.APAction {
+	return aL.aCfg
+}
+
+// execute implements actioner interface
+// the diktat paths are relative to the account(e.g. Opts.Key) and an empty value clears the field
+func (aL *actSetAccountField) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	if len(aL.config.ActionSCfg().AccountSConns) == 0 {
+		return fmt.Errorf("no connection with AccountS")
+	}
+	args := &utils.ArgsActSetBalance{
+		Tenant:    aL.tnt,
+		AccountID: trgID,
+		Reset:     true,
+		Opts:      aL.cfg().Opts,
+	}
+	if args.Diktats, err = balDiktats(aL.config, aL.cfg(), data,
+		utils.MetaAccount+utils.NestingSep); err != nil {
+		return
+	}
+	var rply string
+	return aL.connMgr.Call(aL.config.ActionSCfg().AccountSConns, nil,
+		utils.AccountSv1ActionSetBalance, args, &rply)
+}
+
+// dryRun implements actioner interface
+func (aL *actSetAccountField) dryRun(_ context.Context, data utils.MapStorage, trgID string, state *dryRunState) (diff *utils.ActionDiff, err error) {
+	var diktats []*utils.BalDiktat
+	if diktats, err = balDiktats(aL.config, aL.cfg(), data,
+		utils.MetaAccount+utils.NestingSep); err != nil {
+		return
+	}
+	return state.updateAccount(aL.tnt, trgID, true, func(acnt *utils.AccountProfile) error {
+		return accounts.SetAccountDiktats(acnt, diktats, true)
+	})
+}
+
+// actSetAccountStatus will enable or disable the account
+type actSetAccountStatus struct {
+	config  *config.CGRConfig
+	connMgr *engine.ConnManager
+	aCfg    *engine.APAction
+	tnt     string
+	disable bool
+}
+
+func (aL *actSetAccountStatus) id() string {
+	return aL.aCfg.ID
+}
+
+func (aL *actSetAccountStatus) cfg() *engine.APAction {
+	return aL.aCfg
+}
+
+// execute implements actioner interface
+func (aL *actSetAccountStatus) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	if len(aL.config.ActionSCfg().AccountSConns) == 0 {
+		return fmt.Errorf("no connection with AccountS")
+	}
+	args := &utils.ArgsActSetBalance{
+		Tenant:    aL.tnt,
+		AccountID: trgID,
+		Reset:     true,
+		Diktats:   aL.diktats(),
+		Opts:      aL.cfg().Opts,
+	}
+	var rply string
+	return aL.connMgr.Call(aL.config.ActionSCfg().AccountSConns, nil,
+		utils.AccountSv1ActionSetBalance, args, &rply)
+}
+
+// dryRun implements actioner interface
+func (aL *actSetAccountStatus) dryRun(_ context.Context, _ utils.MapStorage, trgID string, state *dryRunState) (diff *utils.ActionDiff, err error) {
+	return state.updateAccount(aL.tnt, trgID, true, func(acnt *utils.AccountProfile) error {
+		return accounts.SetAccountDiktats(acnt, aL.diktats(), true)
+	})
+}
+
+// diktats returns the diktat updating the status of the account
+// the account is disabled by the presence of the Disabled option
+// so enabling it means removing the option
+func (aL *actSetAccountStatus) diktats() []*utils.BalDiktat {
+	var val string
+	if aL.disable {
+		val = utils.TrueStr
+	}
+	return []*utils.BalDiktat{{
+		Path:  utils.MetaAccount + utils.NestingSep + utils.Opts + utils.NestingSep + utils.Disabled,
+		Value: val,
+	}}
+}
+
+// actRolloverBalances will renew the balances of the account at the cycle boundary
+type actRolloverBalances struct {
+	config  *config.CGRConfig
+	connMgr *engine.ConnManager
+	aCfg    *engine.APAction
+	tnt     string
+	prorate bool
+}
+
+func (aL *actRolloverBalances) id() string {
+	return aL.aCfg.ID
+}
+
+func (aL *actRolloverBalances) cfg() *engine.APAction {
+	return aL.aCfg
+}
+
+// execute implements actioner interface
+func (aL *actRolloverBalances) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	if len(aL.config.ActionSCfg().AccountSConns) == 0 {
+		return fmt.Errorf("no connection with AccountS")
+	}
+	args := &utils.ArgsActRolloverBalances{
+		Tenant:     aL.tnt,
+		AccountID:  trgID,
+		BalanceIDs: balanceIDs(aL.cfg()), // without diktats all the balances with *initialUnits are renewed
+		Prorate:    aL.prorate,
+		Time:       utils.TimePointer(time.Now()),
+		Opts:       aL.cfg().Opts,
+	}
+	var rply string
+	return aL.connMgr.Call(aL.config.ActionSCfg().AccountSConns, nil,
+		utils.AccountSv1ActionRolloverBalances, args, &rply)
+}
+
+// dryRun implements actioner interface
+func (aL *actRolloverBalances) dryRun(_ context.Context, _ utils.MapStorage, trgID string, state *dryRunState) (diff *utils.ActionDiff, err error) {
+	return state.updateAccount(aL.tnt, trgID, false, func(acnt *utils.AccountProfile) error {
+		return accounts.RolloverBalances(acnt, balanceIDs(aL.cfg()), time.Now(),
+			aL.prorate, aL.config.GeneralCfg().RoundingDecimals)
+	})
+}
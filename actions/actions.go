@@ -210,6 +210,8 @@ func (aS *ActionS) scheduledActions(tnt string, cgrEv *utils.CGREvent, aPrflIDs
 		return
 	}
 
+	// the threshold triggering the execution replaces the *thresholds targets of the profiles
+	evTrgID := utils.IfaceAsString(cgrEv.Opts[utils.OptsTrgID])
 	for _, aPf := range aPfs {
 		ctx := context.Background()
 		trgActs := map[string][]actioner{} // build here the list of actioners based on the trgKey
@@ -236,6 +238,11 @@ func (aS *ActionS) scheduledActions(tnt string, cgrEv *utils.CGREvent, aPrflIDs
 					evNm, acts))
 				continue
 			}
+			if trg == utils.MetaThresholds && evTrgID != utils.EmptyString {
+				schedActs = append(schedActs, newScheduledActs(ctx, aPf.Tenant, aPf.ID, trg, evTrgID, aPf.Schedule,
+					evNm, acts))
+				continue
+			}
 			for trgID := range aPf.Targets[trg] {
 				schedActs = append(schedActs, newScheduledActs(ctx, aPf.Tenant, aPf.ID, trg, trgID, aPf.Schedule,
 					evNm, acts))
@@ -272,6 +279,17 @@ func (aS *ActionS) asapExecuteActions(sActs *scheduledActs) (err error) {
 	return
 }
 
+// dryRunActions simulates the execution of the scheduledActs on cloned data
+// the ActionProfiles are not updated and nothing is sent to the other subsystems
+func (aS *ActionS) dryRunActions(schedActSet []*scheduledActs) (diffs []*utils.ActionDiff) {
+	state := newDryRunState(aS.dm)
+	diffs = make([]*utils.ActionDiff, 0, len(schedActSet))
+	for _, sActs := range schedActSet {
+		diffs = append(diffs, sActs.dryRun(state)...)
+	}
+	return
+}
+
 // V1ScheduleActions will be called to schedule actions matching the arguments
 func (aS *ActionS) V1ScheduleActions(args *utils.ArgActionSv1ScheduleActions, rpl *string) (err error) {
 	if err = aS.scheduleActions([]*utils.CGREvent{args.CGREvent},
@@ -283,12 +301,23 @@ func (aS *ActionS) V1ScheduleActions(args *utils.ArgActionSv1ScheduleActions, rp
 }
 
 // V1ExecuteActions will be called to execute ASAP action profiles, ignoring their Schedule field
+// with the *dryrun option nothing is modified and the reply contains the JSON encoded list of utils.ActionDiff
 func (aS *ActionS) V1ExecuteActions(args *utils.ArgActionSv1ScheduleActions, rpl *string) (err error) {
 	var schedActSet []*scheduledActs
 	if schedActSet, err = aS.scheduledActions(args.CGREvent.Tenant,
 		args.CGREvent, args.ActionProfileIDs, true); err != nil {
 		return
 	}
+	if dryRunIface, has := args.CGREvent.Opts[utils.MetaDryRun]; has {
+		var dryRun bool
+		if dryRun, err = utils.IfaceAsBool(dryRunIface); err != nil {
+			return
+		}
+		if dryRun {
+			*rpl = utils.ToJSON(aS.dryRunActions(schedActSet))
+			return
+		}
+	}
 	var partExec bool
 	// execute the actions
 	for _, sActs := range schedActSet {
@@ -303,3 +332,15 @@ func (aS *ActionS) V1ExecuteActions(args *utils.ArgActionSv1ScheduleActions, rpl
 	*rpl = utils.OK
 	return
 }
+
+// V1DryRunActions simulates the execution of the action profiles matching the arguments
+// nothing is modified and the reply contains the changes each action would make
+func (aS *ActionS) V1DryRunActions(args *utils.ArgActionSv1ScheduleActions, rpl *[]*utils.ActionDiff) (err error) {
+	var schedActSet []*scheduledActs
+	if schedActSet, err = aS.scheduledActions(args.CGREvent.Tenant,
+		args.CGREvent, args.ActionProfileIDs, true); err != nil {
+		return
+	}
+	*rpl = aS.dryRunActions(schedActSet)
+	return
+}
@@ -205,6 +205,64 @@ func TestScheduledActions(t *testing.T) {
 	}
 }
 
+func TestScheduledActionsTriggerID(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	acts := NewActionS(defaultCfg, filters, dm, nil)
+
+	actPrf := &engine.ActionProfile{
+		Tenant: "cgrates.org",
+		ID:     "AP_RESET_TH",
+		Targets: map[string]utils.StringSet{
+			utils.MetaThresholds: utils.NewStringSet([]string{"cgrates.org:TH_OTHER"}),
+			utils.MetaAccounts:   utils.NewStringSet([]string{"1001"}),
+		},
+		Actions: []*engine.APAction{
+			{
+				ID:   "RESET_TH",
+				Type: utils.MetaResetThreshold,
+			},
+			{
+				ID:   "RESET_ACC",
+				Type: utils.MetaResetAccount,
+			},
+		},
+	}
+	if err := acts.dm.SetActionProfile(actPrf, true); err != nil {
+		t.Fatal(err)
+	}
+
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TEST_TRGID",
+		Event:  map[string]interface{}{},
+		Opts: map[string]interface{}{
+			utils.OptsTrgID: "cgrates.org:TH1",
+		},
+	}
+	rcv, err := acts.scheduledActions(cgrEv.Tenant, cgrEv, []string{"AP_RESET_TH"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]string{
+		utils.MetaThresholds: "cgrates.org:TH1",
+		utils.MetaAccounts:   "1001",
+	}
+	if len(rcv) != len(exp) {
+		t.Fatalf("Expected %d scheduled actions, received %d", len(exp), len(rcv))
+	}
+	for _, sActs := range rcv {
+		if trgID, has := exp[sActs.trgTyp]; !has || trgID != sActs.trgID {
+			t.Errorf("Unexpected target <%s:%s>", sActs.trgTyp, sActs.trgID)
+		}
+	}
+	if err := acts.dm.RemoveActionProfile(actPrf.Tenant, actPrf.ID, utils.NonTransactional, true); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestScheduleAction(t *testing.T) {
 	defaultCfg := config.NewDefaultCGRConfig()
 	data := engine.NewInternalDB(nil, nil, true)
@@ -1022,3 +1080,468 @@ func TestExportActionResetStatStaticID(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestActionSetAccountFieldAndStatus(t *testing.T) {
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	var rcvDiktats []*utils.BalDiktat
+	sMock := &testMockCDRsConn{
+		calls: map[string]func(arg interface{}, rply interface{}) error{
+			utils.AccountSv1ActionSetBalance: func(arg interface{}, rply interface{}) error {
+				argConv, can := arg.(*utils.ArgsActSetBalance)
+				if !can {
+					return fmt.Errorf("Wrong argument type: %T", arg)
+				}
+				if argConv.AccountID != "1001" {
+					return fmt.Errorf("Expected %+v, received %+v", "1001", argConv.AccountID)
+				}
+				rcvDiktats = argConv.Diktats
+				return nil
+			},
+		},
+	}
+	internalChann := make(chan rpcclient.ClientConnector, 1)
+	internalChann <- sMock
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ActionSCfg().AccountSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)}
+	connMgr := engine.NewConnManager(config.CgrConfig(), map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts): internalChann,
+	})
+	evNM := utils.MapStorage{
+		utils.MetaReq: map[string]interface{}{
+			"Plan": "PREMIUM",
+		},
+		utils.MetaOpts: map[string]interface{}{},
+	}
+
+	act, err := newActioner(cfg, nil, nil, connMgr, &engine.APAction{
+		ID:   "ACT_SET_FIELD",
+		Type: utils.MetaSetAccountField,
+		Diktats: []*engine.APDiktat{
+			{Path: "Opts.Plan", Value: "~*req.Plan"},
+			{Path: "*account.FilterIDs"},
+		},
+	}, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := act.execute(nil, evNM, "1001"); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*utils.BalDiktat{
+		{Path: "*account.Opts.Plan", Value: "PREMIUM"},
+		{Path: "*account.FilterIDs"},
+	}
+	if !reflect.DeepEqual(exp, rcvDiktats) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcvDiktats))
+	}
+
+	act, err = newActioner(cfg, nil, nil, connMgr, &engine.APAction{
+		ID:   "ACT_DISABLE",
+		Type: utils.MetaDisableAccount,
+	}, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := act.execute(nil, evNM, "1001"); err != nil {
+		t.Fatal(err)
+	}
+	exp = []*utils.BalDiktat{{Path: "*account.Opts.Disabled", Value: utils.TrueStr}}
+	if !reflect.DeepEqual(exp, rcvDiktats) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcvDiktats))
+	}
+
+	act, err = newActioner(cfg, nil, nil, connMgr, &engine.APAction{
+		ID:   "ACT_ENABLE",
+		Type: utils.MetaEnableAccount,
+	}, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := act.execute(nil, evNM, "1001"); err != nil {
+		t.Fatal(err)
+	}
+	exp = []*utils.BalDiktat{{Path: "*account.Opts.Disabled"}}
+	if !reflect.DeepEqual(exp, rcvDiktats) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcvDiktats))
+	}
+	if trg := actionTarget(utils.MetaDisableAccount); trg != utils.MetaAccounts {
+		t.Errorf("Expected %+v, received %+v", utils.MetaAccounts, trg)
+	}
+}
+
+func TestActionResetAccount(t *testing.T) {
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	sMock := &testMockCDRsConn{
+		calls: map[string]func(arg interface{}, rply interface{}) error{
+			utils.AccountSv1ActionResetAccount: func(arg interface{}, rply interface{}) error {
+				argConv, can := arg.(*utils.ArgsActResetAccount)
+				if !can {
+					return fmt.Errorf("Wrong argument type: %T", arg)
+				}
+				exp := &utils.ArgsActResetAccount{
+					Tenant:     "cgrates.org",
+					AccountID:  "1001",
+					BalanceIDs: []string{"MONTHLY_SMS"},
+				}
+				if !reflect.DeepEqual(exp, argConv) {
+					return fmt.Errorf("Expected %+v, received %+v", utils.ToJSON(exp), utils.ToJSON(argConv))
+				}
+				return nil
+			},
+		},
+	}
+	internalChann := make(chan rpcclient.ClientConnector, 1)
+	internalChann <- sMock
+	cfg := config.NewDefaultCGRConfig()
+	act := &actResetAccount{
+		config: cfg,
+		connMgr: engine.NewConnManager(config.CgrConfig(), map[string]chan rpcclient.ClientConnector{
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts): internalChann,
+		}),
+		aCfg: &engine.APAction{
+			ID:      "ACT_RESET_ACC",
+			Type:    utils.MetaResetAccount,
+			Diktats: []*engine.APDiktat{{Path: "MONTHLY_SMS"}},
+		},
+		tnt: "cgrates.org",
+	}
+	evNM := utils.MapStorage{
+		utils.MetaOpts: map[string]interface{}{},
+	}
+	if err := act.execute(nil, evNM, "1001"); err == nil || err.Error() != "no connection with AccountS" {
+		t.Errorf("Expected %+v, received %+v", "no connection with AccountS", err)
+	}
+	cfg.ActionSCfg().AccountSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)}
+	if err := act.execute(nil, evNM, "1001"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestActionReleaseResources(t *testing.T) {
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	var usageIDs []string
+	sMock := &testMockCDRsConn{
+		calls: map[string]func(arg interface{}, rply interface{}) error{
+			utils.ResourceSv1ReleaseResources: func(arg interface{}, rply interface{}) error {
+				argConv, can := arg.(*utils.ArgRSv1ResourceUsage)
+				if !can {
+					return fmt.Errorf("Wrong argument type: %T", arg)
+				}
+				if argConv.Tenant != "cgrates.org" {
+					return fmt.Errorf("Expected %+v, received %+v", "cgrates.org", argConv.Tenant)
+				}
+				if argConv.UsageID == "UNKNOWN" {
+					return utils.ErrNotFound
+				}
+				usageIDs = append(usageIDs, argConv.UsageID)
+				return nil
+			},
+		},
+	}
+	internalChann := make(chan rpcclient.ClientConnector, 1)
+	internalChann <- sMock
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ActionSCfg().ResourceSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources)}
+	connMgr := engine.NewConnManager(config.CgrConfig(), map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources): internalChann,
+	})
+	act, err := newActioner(cfg, nil, nil, connMgr, &engine.APAction{
+		ID:   "ACT_RELEASE",
+		Type: utils.MetaReleaseResources,
+		Diktats: []*engine.APDiktat{
+			{Value: "~*req.OriginID"},
+			{Value: "UNKNOWN"},
+		},
+	}, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	evNM := utils.MapStorage{
+		utils.MetaReq: map[string]interface{}{
+			utils.OriginID: "session1",
+		},
+		utils.MetaOpts: map[string]interface{}{},
+	}
+	if err := act.execute(nil, evNM, utils.EmptyString); err != utils.ErrPartiallyExecuted {
+		t.Errorf("Expected %+v, received %+v", utils.ErrPartiallyExecuted, err)
+	}
+	if exp := []string{"session1"}; !reflect.DeepEqual(exp, usageIDs) {
+		t.Errorf("Expected %+v, received %+v", exp, usageIDs)
+	}
+	evNM[utils.MetaOpts] = utils.MapStorage{}
+	if err := act.execute(nil, evNM, utils.EmptyString); err == nil ||
+		err.Error() != "cannot cast *opts with value: {} to map" {
+		t.Errorf("received error: %v", err)
+	}
+}
+
+func TestV1DryRunActions(t *testing.T) {
+	engine.Cache.Clear(nil)
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	defaultCfg := config.NewDefaultCGRConfig()
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	acts := NewActionS(defaultCfg, filters, dm, nil)
+
+	if err := dm.SetAccountProfile(&utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*utils.Balance{
+			"MONETARY": {
+				ID:    "MONETARY",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(5, 0),
+			},
+		},
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	actPrf := &engine.ActionProfile{
+		Tenant:   "cgrates.org",
+		ID:       "AP_DRYRUN",
+		Schedule: utils.MetaASAP,
+		Targets: map[string]utils.StringSet{
+			utils.MetaAccounts: utils.NewStringSet([]string{"1001"}),
+		},
+		Actions: []*engine.APAction{
+			{
+				ID:   "TOPUP1",
+				Type: utils.MetaAddBalance,
+				Diktats: []*engine.APDiktat{{
+					Path:  "*balance.MONETARY.Units",
+					Value: "10",
+				}},
+			},
+			{
+				ID:   "TOPUP_INVALID",
+				Type: utils.MetaAddBalance,
+				Diktats: []*engine.APDiktat{{
+					Path:  "*balance.MONETARY.Units",
+					Value: "not_a_number",
+				}},
+			},
+			{
+				ID:   "TOPUP2",
+				Type: utils.MetaAddBalance,
+				Diktats: []*engine.APDiktat{{
+					Path:  "*balance.MONETARY.Units",
+					Value: "~*req.Units",
+				}},
+			},
+		},
+	}
+	if err := dm.SetActionProfile(actPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgActionSv1ScheduleActions{
+		ActionProfileIDs: []string{"AP_DRYRUN"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "test_dryrun",
+			Event: map[string]interface{}{
+				"Units": 20,
+			},
+		},
+	}
+	var diffs []*utils.ActionDiff
+	if err := acts.V1DryRunActions(args, &diffs); err != nil {
+		t.Fatal(err)
+	}
+	// the failed action is reported without stopping the simulation
+	if len(diffs) != 3 || diffs[1].ActionID != "TOPUP_INVALID" || diffs[1].Error == utils.EmptyString {
+		t.Fatalf("Expected the error of TOPUP_INVALID, received %s", utils.ToJSON(diffs))
+	}
+	diffs = append(diffs[:1], diffs[2:]...)
+	exp := []*utils.ActionDiff{
+		{
+			ActionProfileID: "AP_DRYRUN",
+			ActionID:        "TOPUP1",
+			Type:            utils.MetaAddBalance,
+			TargetType:      utils.MetaAccounts,
+			TargetID:        "1001",
+			Changes: map[string]*utils.ValueDiff{
+				"Balances.MONETARY.Units": {Old: 5., New: 15.},
+			},
+		},
+		{
+			ActionProfileID: "AP_DRYRUN",
+			ActionID:        "TOPUP2",
+			Type:            utils.MetaAddBalance,
+			TargetType:      utils.MetaAccounts,
+			TargetID:        "1001",
+			Changes: map[string]*utils.ValueDiff{
+				"Balances.MONETARY.Units": {Old: 15., New: 35.},
+			},
+		},
+	}
+	if !reflect.DeepEqual(exp, diffs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(diffs))
+	}
+	// ExecuteActions with the *dryrun option replies with the same changes
+	if err := acts.V1DryRunActions(args, &diffs); err != nil {
+		t.Fatal(err)
+	}
+	args.Opts = map[string]interface{}{utils.MetaDryRun: true}
+	var reply string
+	if err := acts.V1ExecuteActions(args, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.ToJSON(diffs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(diffs), reply)
+	}
+	// nothing should be modified
+	if acnt, err := dm.GetAccountProfile("cgrates.org", "1001"); err != nil {
+		t.Error(err)
+	} else if acnt.Balances["MONETARY"].Units.Compare(utils.NewDecimal(5, 0)) != 0 {
+		t.Errorf("Expected the account to not be modified, received: %s", utils.ToJSON(acnt))
+	}
+	if ap, err := dm.GetActionProfile("cgrates.org", "AP_DRYRUN", true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !ap.Targets[utils.MetaAccounts].Has("1001") {
+		t.Errorf("Expected the targets to not be modified, received: %s", utils.ToJSON(ap))
+	}
+}
+
+func TestActionResetThresholdDryRun(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	snooze := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := dm.SetThreshold(&engine.Threshold{
+		Tenant: "cgrates.org",
+		ID:     "TH1",
+		Hits:   3,
+		Snooze: snooze,
+	}, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	act := &actResetThreshold{
+		tnt:    "cgrates.org",
+		config: cfg,
+		aCfg: &engine.APAction{
+			ID:   "ACT_RESET_TH",
+			Type: utils.MetaResetThreshold,
+		},
+	}
+	exp := &utils.ActionDiff{
+		Changes: map[string]*utils.ValueDiff{
+			utils.Hits:   {Old: 3, New: 0},
+			utils.Snooze: {Old: snooze, New: time.Time{}},
+		},
+	}
+	state := newDryRunState(dm)
+	if diff, err := act.dryRun(nil, utils.MapStorage{}, "TH1", state); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, diff) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(diff))
+	}
+	// the second reset sees the threshold already reset by the first one
+	if diff, err := act.dryRun(nil, utils.MapStorage{}, "TH1", state); err != nil {
+		t.Error(err)
+	} else if len(diff.Changes) != 0 {
+		t.Errorf("Expected no changes, received %s", utils.ToJSON(diff))
+	}
+	if th, err := dm.GetThreshold("cgrates.org", "TH1", true, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if th.Hits != 3 {
+		t.Errorf("Expected the threshold to not be modified, received: %s", utils.ToJSON(th))
+	}
+	if _, err := act.dryRun(nil, utils.MapStorage{}, "TH2", newDryRunState(dm)); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
+func TestActionResetStatDryRun(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	tcd, err := engine.NewStatMetric(utils.MetaTCD, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tcd.AddEvent("ev1", utils.MapStorage{utils.MetaReq: map[string]interface{}{utils.Usage: time.Minute}}); err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.SetStatQueue(&engine.StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "SQ1",
+		SQItems:   []engine.SQItem{{EventID: "ev1"}},
+		SQMetrics: map[string]engine.StatMetric{utils.MetaTCD: tcd},
+	}, nil, 0, nil, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	act := &actResetStat{
+		tnt:    "cgrates.org",
+		config: cfg,
+		aCfg: &engine.APAction{
+			ID:   "ACT_RESET_SQ",
+			Type: utils.MetaResetStatQueue,
+		},
+	}
+	state := newDryRunState(dm)
+	exp := &utils.ActionDiff{
+		Changes: map[string]*utils.ValueDiff{
+			utils.SQMetrics + utils.NestingSep + utils.MetaTCD: {Old: time.Minute, New: -time.Nanosecond},
+		},
+	}
+	if diff, err := act.dryRun(nil, utils.MapStorage{}, "SQ1", state); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, diff) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(diff))
+	}
+	// the second reset sees the queue already reset by the first one
+	exp.Changes[utils.SQMetrics+utils.NestingSep+utils.MetaTCD].Old = -time.Nanosecond
+	if diff, err := act.dryRun(nil, utils.MapStorage{}, "SQ1", state); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, diff) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(diff))
+	}
+	if sq, err := dm.GetStatQueue("cgrates.org", "SQ1", true, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if len(sq.SQItems) != 1 {
+		t.Errorf("Expected the queue to not be modified, received: %s", utils.ToJSON(sq))
+	}
+}
+
+func TestActionRolloverBalances(t *testing.T) {
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	sMock := &testMockCDRsConn{
+		calls: map[string]func(arg interface{}, rply interface{}) error{
+			utils.AccountSv1ActionRolloverBalances: func(arg interface{}, rply interface{}) error {
+				argConv, can := arg.(*utils.ArgsActRolloverBalances)
+				if !can {
+					return fmt.Errorf("Wrong argument type: %T", arg)
+				}
+				if argConv.AccountID != "1001" || !argConv.Prorate ||
+					!reflect.DeepEqual(argConv.BalanceIDs, []string{"MONTHLY_SMS"}) {
+					return fmt.Errorf("Unexpected arguments: %s", utils.ToJSON(argConv))
+				}
+				return nil
+			},
+		},
+	}
+	internalChann := make(chan rpcclient.ClientConnector, 1)
+	internalChann <- sMock
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ActionSCfg().AccountSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)}
+	connMgr := engine.NewConnManager(config.CgrConfig(), map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts): internalChann,
+	})
+	act, err := newActioner(cfg, nil, nil, connMgr, &engine.APAction{
+		ID:      "ACT_PRORATE",
+		Type:    utils.MetaProrateBalances,
+		Diktats: []*engine.APDiktat{{Path: "MONTHLY_SMS"}},
+	}, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := act.execute(nil, utils.MapStorage{}, "1001"); err != nil {
+		t.Error(err)
+	}
+	if trg := actionTarget(utils.MetaRolloverBalances); trg != utils.MetaAccounts {
+		t.Errorf("Expected %+v, received %+v", utils.MetaAccounts, trg)
+	}
+}
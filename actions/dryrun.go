@@ -0,0 +1,233 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newDryRunState(dm *engine.DataManager) *dryRunState {
+	return &dryRunState{
+		dm:         dm,
+		ms:         engine.NewCodecMsgpackMarshaler(),
+		accounts:   make(map[string]*utils.AccountProfile),
+		statQueues: make(map[string]*engine.StatQueue),
+		thresholds: make(map[string]*engine.Threshold),
+	}
+}
+
+// dryRunState holds the cloned data the actions are simulated on
+// so the actions executed one after another see the changes of the previous ones
+type dryRunState struct {
+	dm         *engine.DataManager
+	ms         engine.Marshaler // used to clone the metrics
+	accounts   map[string]*utils.AccountProfile
+	statQueues map[string]*engine.StatQueue
+	thresholds map[string]*engine.Threshold
+}
+
+// account returns the cloned account from the state
+// if the account is missing it is created the same way AccountS does
+func (s *dryRunState) account(tnt, acntID string, create bool) (acnt *utils.AccountProfile, err error) {
+	tntID := utils.ConcatenatedKey(tnt, acntID)
+	if acnt, has := s.accounts[tntID]; has {
+		return acnt, nil
+	}
+	if acnt, err = s.dm.GetAccountProfile(tnt, acntID); err != nil {
+		if err != utils.ErrNotFound || !create {
+			return
+		}
+		err = nil
+		acnt = &utils.AccountProfile{
+			Tenant: tnt,
+			ID:     acntID,
+		}
+	} else {
+		acnt = acnt.Clone() // never modify the cached account
+	}
+	s.accounts[tntID] = acnt
+	return
+}
+
+// statQueue returns the cloned StatQueue from the state
+func (s *dryRunState) statQueue(tnt, sqID string) (sq *engine.StatQueue, err error) {
+	tntID := utils.ConcatenatedKey(tnt, sqID)
+	if sq, has := s.statQueues[tntID]; has {
+		return sq, nil
+	}
+	var dbSq *engine.StatQueue
+	if dbSq, err = s.dm.GetStatQueue(tnt, sqID,
+		true, false, utils.NonTransactional); err != nil {
+		return
+	}
+	dbSq.RLock()
+	defer dbSq.RUnlock()
+	sq = &engine.StatQueue{
+		Tenant:  dbSq.Tenant,
+		ID:      dbSq.ID,
+		SQItems: make([]engine.SQItem, len(dbSq.SQItems)),
+	}
+	copy(sq.SQItems, dbSq.SQItems)
+	if sq.SQMetrics, err = s.cloneMetrics(dbSq.SQMetrics); err != nil {
+		return
+	}
+	if len(dbSq.SQBuckets) != 0 {
+		sq.SQBuckets = make([]*engine.StatBucket, len(dbSq.SQBuckets))
+		for i, bkt := range dbSq.SQBuckets {
+			sq.SQBuckets[i] = &engine.StatBucket{StartTime: bkt.StartTime}
+			if sq.SQBuckets[i].Metrics, err = s.cloneMetrics(bkt.Metrics); err != nil {
+				return
+			}
+		}
+	}
+	s.statQueues[tntID] = sq
+	return
+}
+
+// cloneMetrics returns a deep copy of the metrics
+func (s *dryRunState) cloneMetrics(metrics map[string]engine.StatMetric) (cln map[string]engine.StatMetric, err error) {
+	cln = make(map[string]engine.StatMetric, len(metrics))
+	for id, m := range metrics {
+		var mrshled []byte
+		if mrshled, err = m.Marshal(s.ms); err != nil {
+			return
+		}
+		if cln[id], err = engine.NewStatMetric(id,
+			m.GetMinItems(), m.GetFilterIDs()); err != nil {
+			return
+		}
+		if err = cln[id].LoadMarshaled(s.ms, mrshled); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// threshold returns the cloned Threshold from the state
+func (s *dryRunState) threshold(tnt, thID string) (thd *engine.Threshold, err error) {
+	tntID := utils.ConcatenatedKey(tnt, thID)
+	if thd, has := s.thresholds[tntID]; has {
+		return thd, nil
+	}
+	var dbThd *engine.Threshold
+	if dbThd, err = s.dm.GetThreshold(tnt, thID,
+		true, false, utils.NonTransactional); err != nil {
+		return
+	}
+	thd = &engine.Threshold{
+		Tenant: dbThd.Tenant,
+		ID:     dbThd.ID,
+		Hits:   dbThd.Hits,
+		Snooze: dbThd.Snooze,
+	}
+	s.thresholds[tntID] = thd
+	return
+}
+
+// updateAccount applies the function on the cloned account returning the changed fields
+func (s *dryRunState) updateAccount(tnt, acntID string, create bool,
+	f func(acnt *utils.AccountProfile) error) (diff *utils.ActionDiff, err error) {
+	var acnt *utils.AccountProfile
+	if acnt, err = s.account(tnt, acntID, create); err != nil {
+		return
+	}
+	var before map[string]interface{}
+	if before, err = flattenedMap(acnt); err != nil {
+		return
+	}
+	if err = f(acnt); err != nil {
+		return
+	}
+	var after map[string]interface{}
+	if after, err = flattenedMap(acnt); err != nil {
+		return
+	}
+	return &utils.ActionDiff{Changes: valuesDiff(before, after)}, nil
+}
+
+// flattenedMap converts the item in a map with the paths of the fields as keys
+func flattenedMap(itm interface{}) (flat map[string]interface{}, err error) {
+	var b []byte
+	if b, err = json.Marshal(itm); err != nil {
+		return
+	}
+	var mp map[string]interface{}
+	if err = json.Unmarshal(b, &mp); err != nil {
+		return
+	}
+	flat = make(map[string]interface{})
+	flattenMap(flat, utils.EmptyString, mp)
+	return
+}
+
+func flattenMap(flat map[string]interface{}, prefix string, mp map[string]interface{}) {
+	for k, v := range mp {
+		if prefix != utils.EmptyString {
+			k = prefix + utils.NestingSep + k
+		}
+		if nested, isMap := v.(map[string]interface{}); isMap && len(nested) != 0 {
+			flattenMap(flat, k, nested)
+			continue
+		}
+		flat[k] = v
+	}
+}
+
+// valuesDiff returns the fields with different values
+func valuesDiff(before, after map[string]interface{}) (diff map[string]*utils.ValueDiff) {
+	diff = make(map[string]*utils.ValueDiff)
+	for k, bVal := range before {
+		if aVal, has := after[k]; !has || !reflect.DeepEqual(bVal, aVal) {
+			diff[k] = &utils.ValueDiff{Old: bVal, New: aVal}
+		}
+	}
+	for k, aVal := range after {
+		if _, has := before[k]; !has {
+			diff[k] = &utils.ValueDiff{New: aVal}
+		}
+	}
+	return
+}
+
+// dryRun simulates the execution of the actions without modifying the data
+// the actions failing the simulation are returned with their error
+func (s *scheduledActs) dryRun(state *dryRunState) (diffs []*utils.ActionDiff) {
+	for _, act := range s.acts {
+		diff, errAct := act.dryRun(s.ctx, s.data, s.trgID, state)
+		if errAct != nil {
+			utils.Logger.Warning(fmt.Sprintf("simulating action: <%s>, error: <%s>", act.id(), errAct))
+			diff = &utils.ActionDiff{Error: errAct.Error()}
+		}
+		if diff == nil {
+			diff = new(utils.ActionDiff)
+		}
+		diff.ActionProfileID = s.apID
+		diff.ActionID = act.id()
+		diff.Type = act.cfg().Type
+		diff.TargetType = s.trgTyp
+		diff.TargetID = s.trgID
+		diffs = append(diffs, diff)
+	}
+	return
+}
@@ -69,6 +69,15 @@ func (aL *actHTTPPost) execute(_ context.Context, data utils.MapStorage, _ strin
 	return
 }
 
+// dryRun implements actioner interface
+func (aL *actHTTPPost) dryRun(_ context.Context, data utils.MapStorage, _ string, _ *dryRunState) (diff *utils.ActionDiff, err error) {
+	payload := make(map[string]interface{}) // the same body is posted to all the paths
+	for _, actD := range aL.cfg().Diktats {
+		payload[actD.Path] = data
+	}
+	return &utils.ActionDiff{Payload: payload}, nil
+}
+
 func (aL *actHTTPPost) post(pstr *engine.HTTPPoster, body []byte, path string) (err error) {
 	if err = pstr.PostValues(body, make(http.Header)); err != nil {
 		utils.Logger.Warning(fmt.Sprintf("<%s> Failed posting event to: <%s> because: %s", utils.ActionS, path, err.Error()))
@@ -97,20 +106,30 @@ func (aL *actExport) cfg() *engine.APAction {
 
 // execute implements actioner interface
 func (aL *actExport) execute(_ context.Context, data utils.MapStorage, _ string) (err error) {
+	var rply map[string]map[string]interface{}
+	return aL.connMgr.Call(aL.config.ActionSCfg().EEsConns, nil,
+		utils.EeSv1ProcessEvent, aL.exportArgs(data), &rply)
+}
+
+// dryRun implements actioner interface
+func (aL *actExport) dryRun(_ context.Context, data utils.MapStorage, _ string, _ *dryRunState) (diff *utils.ActionDiff, err error) {
+	return &utils.ActionDiff{Payload: aL.exportArgs(data)}, nil
+}
+
+// exportArgs builds the event sent to EEs
+func (aL *actExport) exportArgs(data utils.MapStorage) *utils.CGREventWithEeIDs {
 	var exporterIDs []string
 	if expIDs, has := aL.cfg().Opts[utils.MetaExporterIDs]; has {
 		exporterIDs = strings.Split(utils.IfaceAsString(expIDs), utils.InfieldSep)
 	}
-	var rply map[string]map[string]interface{}
-	return aL.connMgr.Call(aL.config.ActionSCfg().EEsConns, nil,
-		utils.EeSv1ProcessEvent, &utils.CGREventWithEeIDs{
-			EeIDs: exporterIDs,
-			CGREvent: &utils.CGREvent{
-				Tenant: aL.tnt,
-				Time:   utils.TimePointer(time.Now()),
-				ID:     utils.GenUUID(),
-				Event:  data[utils.MetaReq].(map[string]interface{}),
-				Opts:   data[utils.MetaOpts].(map[string]interface{}),
-			},
-		}, &rply)
+	return &utils.CGREventWithEeIDs{
+		EeIDs: exporterIDs,
+		CGREvent: &utils.CGREvent{
+			Tenant: aL.tnt,
+			Time:   utils.TimePointer(time.Now()),
+			ID:     utils.GenUUID(),
+			Event:  data[utils.MetaReq].(map[string]interface{}),
+			Opts:   data[utils.MetaOpts].(map[string]interface{}),
+		},
+	}
 }
@@ -35,7 +35,10 @@ func actionTarget(act string) string {
 		return utils.MetaStats
 	case utils.MetaResetThreshold:
 		return utils.MetaThresholds
-	case utils.MetaAddBalance, utils.MetaSetBalance, utils.MetaRemBalance:
+	case utils.MetaAddBalance, utils.MetaSetBalance, utils.MetaRemBalance,
+		utils.MetaResetAccount, utils.MetaSetAccountField,
+		utils.MetaEnableAccount, utils.MetaDisableAccount,
+		utils.MetaRolloverBalances, utils.MetaProrateBalances:
 		return utils.MetaAccounts
 	default:
 		return utils.MetaNone
@@ -132,6 +135,20 @@ func newActioner(cfg *config.CGRConfig, fltrS *engine.FilterS, dm *engine.DataMa
 		return &actSetBalance{cfg, connMgr, aCfg, tnt, true}, nil
 	case utils.MetaRemBalance:
 		return &actRemBalance{cfg, connMgr, aCfg, tnt}, nil
+	case utils.MetaResetAccount:
+		return &actResetAccount{cfg, connMgr, aCfg, tnt}, nil
+	case utils.MetaSetAccountField:
+		return &actSetAccountField{cfg, connMgr, aCfg, tnt}, nil
+	case utils.MetaEnableAccount:
+		return &actSetAccountStatus{cfg, connMgr, aCfg, tnt, false}, nil
+	case utils.MetaDisableAccount:
+		return &actSetAccountStatus{cfg, connMgr, aCfg, tnt, true}, nil
+	case utils.MetaRolloverBalances:
+		return &actRolloverBalances{cfg, connMgr, aCfg, tnt, false}, nil
+	case utils.MetaProrateBalances:
+		return &actRolloverBalances{cfg, connMgr, aCfg, tnt, true}, nil
+	case utils.MetaReleaseResources:
+		return &actReleaseResources{tnt, cfg, connMgr, aCfg}, nil
 	default:
 		return nil, fmt.Errorf("unsupported action type: <%s>", aCfg.Type)
 
@@ -143,4 +160,6 @@ type actioner interface {
 	id() string
 	cfg() *engine.APAction
 	execute(ctx context.Context, data utils.MapStorage, trgID string) (err error)
+	// dryRun simulates the execution on the cloned state returning what would change
+	dryRun(ctx context.Context, data utils.MapStorage, trgID string, state *dryRunState) (diff *utils.ActionDiff, err error)
 }
@@ -46,6 +46,11 @@ func (actLog) execute(_ context.Context, data utils.MapStorage, _ string) (err e
 	return
 }
 
+// dryRun implements actioner interface
+func (actLog) dryRun(_ context.Context, data utils.MapStorage, _ string, _ *dryRunState) (diff *utils.ActionDiff, err error) {
+	return &utils.ActionDiff{Payload: data}, nil
+}
+
 // actCDRLog will log data to CGRateS logger
 type actCDRLog struct {
 	config  *config.CGRConfig
@@ -67,6 +72,26 @@ func (aL *actCDRLog) execute(_ context.Context, data utils.MapStorage, _ string)
 	if len(aL.config.ActionSCfg().CDRsConns) == 0 {
 		return fmt.Errorf("no connection with CDR Server")
 	}
+	var args *engine.ArgV1ProcessEvent
+	if args, err = aL.cdrArgs(data); err != nil {
+		return
+	}
+	var rply string
+	return aL.connMgr.Call(aL.config.ActionSCfg().CDRsConns, nil,
+		utils.CDRsV1ProcessEvent, args, &rply)
+}
+
+// dryRun implements actioner interface
+func (aL *actCDRLog) dryRun(_ context.Context, data utils.MapStorage, _ string, _ *dryRunState) (diff *utils.ActionDiff, err error) {
+	var args *engine.ArgV1ProcessEvent
+	if args, err = aL.cdrArgs(data); err != nil {
+		return
+	}
+	return &utils.ActionDiff{Payload: args}, nil
+}
+
+// cdrArgs builds the CDR sent to CDRs based on the template
+func (aL *actCDRLog) cdrArgs(data utils.MapStorage) (args *engine.ArgV1ProcessEvent, err error) {
 	template := aL.config.TemplatesCfg()[utils.MetaCdrLog]
 	if id, has := aL.cfg().Opts[utils.MetaTemplateID]; has { // if templateID is not present we use default template
 		template = aL.config.TemplatesCfg()[utils.IfaceAsString(id)]
@@ -91,12 +116,9 @@ func (aL *actCDRLog) execute(_ context.Context, data utils.MapStorage, _ string)
 	if err = cdrLogReq.SetFields(template); err != nil {
 		return
 	}
-	var rply string
-	return aL.connMgr.Call(aL.config.ActionSCfg().CDRsConns, nil,
-		utils.CDRsV1ProcessEvent,
-		&engine.ArgV1ProcessEvent{
-			Flags: []string{utils.ConcatenatedKey(utils.MetaChargers, utils.FalseStr)}, // do not try to get the chargers for cdrlog
-			CGREvent: *config.NMAsCGREvent(cdrLogReq.OrdNavMP[utils.MetaCDR], cdrLogReq.Tenant,
-				utils.NestingSep, cdrLogReq.OrdNavMP[utils.MetaOpts]),
-		}, &rply)
+	return &engine.ArgV1ProcessEvent{
+		Flags: []string{utils.ConcatenatedKey(utils.MetaChargers, utils.FalseStr)}, // do not try to get the chargers for cdrlog
+		CGREvent: *config.NMAsCGREvent(cdrLogReq.OrdNavMP[utils.MetaCDR], cdrLogReq.Tenant,
+			utils.NestingSep, cdrLogReq.OrdNavMP[utils.MetaOpts]),
+	}, nil
 }
@@ -20,6 +20,7 @@ package actions
 
 import (
 	"context"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
@@ -55,6 +56,36 @@ func (aL *actResetStat) execute(_ context.Context, data utils.MapStorage, trgID
 		utils.StatSv1ResetStatQueue, args, &rply)
 }
 
+// dryRun implements actioner interface
+func (aL *actResetStat) dryRun(_ context.Context, _ utils.MapStorage, trgID string, state *dryRunState) (diff *utils.ActionDiff, err error) {
+	tntID := utils.NewTenantID(trgID)
+	if tntID.Tenant == utils.EmptyString {
+		tntID.Tenant = aL.tnt
+	}
+	var sq *engine.StatQueue
+	if sq, err = state.statQueue(tntID.Tenant, tntID.ID); err != nil {
+		return
+	}
+	rndDec := aL.config.GeneralCfg().RoundingDecimals
+	diff = &utils.ActionDiff{Changes: make(map[string]*utils.ValueDiff)}
+	metrics := make(map[string]engine.StatMetric, len(sq.SQMetrics))
+	for id, m := range sq.SQMetrics {
+		if metrics[id], err = engine.NewStatMetric(id,
+			m.GetMinItems(), m.GetFilterIDs()); err != nil {
+			return
+		}
+		diff.Changes[utils.SQMetrics+utils.NestingSep+id] = &utils.ValueDiff{
+			Old: m.GetValue(rndDec),
+			New: metrics[id].GetValue(rndDec),
+		}
+	}
+	// reset the cloned queue same as StatS so the next actions see it
+	sq.SQItems = make([]engine.SQItem, 0)
+	sq.SQBuckets = nil
+	sq.SQMetrics = metrics
+	return
+}
+
 type actResetThreshold struct {
 	tnt     string
 	config  *config.CGRConfig
@@ -83,3 +114,23 @@ func (aL *actResetThreshold) execute(_ context.Context, data utils.MapStorage, t
 	return aL.connMgr.Call(aL.config.ActionSCfg().ThresholdSConns, nil,
 		utils.ThresholdSv1ResetThreshold, args, &rply)
 }
+
+// dryRun implements actioner interface
+func (aL *actResetThreshold) dryRun(_ context.Context, _ utils.MapStorage, trgID string, state *dryRunState) (diff *utils.ActionDiff, err error) {
+	tntID := utils.NewTenantID(trgID)
+	if tntID.Tenant == utils.EmptyString {
+		tntID.Tenant = aL.tnt
+	}
+	var thd *engine.Threshold
+	if thd, err = state.threshold(tntID.Tenant, tntID.ID); err != nil {
+		return
+	}
+	diff = &utils.ActionDiff{Changes: make(map[string]*utils.ValueDiff)}
+	if thd.Hits != 0 { // same as ThresholdS the unused thresholds are not modified
+		diff.Changes[utils.Hits] = &utils.ValueDiff{Old: thd.Hits, New: 0}
+		diff.Changes[utils.Snooze] = &utils.ValueDiff{Old: thd.Snooze, New: time.Time{}}
+		thd.Hits = 0
+		thd.Snooze = time.Time{}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// actReleaseResources will release the resource usages matching the event
+type actReleaseResources struct {
+	tnt     string
+	config  *config.CGRConfig
+	connMgr *engine.ConnManager
+	aCfg    *engine.APAction
+}
+
+func (aL *actReleaseResources) id() string {
+	return aL.aCfg.ID
+}
+
+func (aL *actReleaseResources) cfg() *engine.APAction {
+	return aL.aCfg
+}
+
+// execute implements actioner interface
+// each diktat value is parsed as an UsageID to be released
+func (aL *actReleaseResources) execute(_ context.Context, data utils.MapStorage, _ string) (err error) {
+	if len(aL.config.ActionSCfg().ResourceSConns) == 0 {
+		return fmt.Errorf("no connection with ResourceS")
+	}
+	var argsRU []*utils.ArgRSv1ResourceUsage
+	if argsRU, err = aL.usageArgs(data); err != nil {
+		return
+	}
+	var partExec bool
+	for _, args := range argsRU {
+		var rply string
+		if err = aL.connMgr.Call(aL.config.ActionSCfg().ResourceSConns, nil,
+			utils.ResourceSv1ReleaseResources, args, &rply); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed releasing resource usage <%s> because: %s",
+				utils.ActionS, args.UsageID, err.Error()))
+			partExec = true
+		}
+	}
+	if partExec {
+		err = utils.ErrPartiallyExecuted
+	}
+	return
+}
+
+// dryRun implements actioner interface
+func (aL *actReleaseResources) dryRun(_ context.Context, data utils.MapStorage, _ string, _ *dryRunState) (diff *utils.ActionDiff, err error) {
+	var argsRU []*utils.ArgRSv1ResourceUsage
+	if argsRU, err = aL.usageArgs(data); err != nil {
+		return
+	}
+	return &utils.ActionDiff{Payload: argsRU}, nil
+}
+
+// usageArgs builds the arguments for ResourceS based on the diktats
+func (aL *actReleaseResources) usageArgs(data utils.MapStorage) (argsRU []*utils.ArgRSv1ResourceUsage, err error) {
+	ev, canCast := data[utils.MetaReq].(map[string]interface{})
+	if !canCast {
+		return nil, fmt.Errorf("cannot cast %s with value: %v to map", utils.MetaReq, data[utils.MetaReq])
+	}
+	opts, canCast := data[utils.MetaOpts].(map[string]interface{})
+	if !canCast {
+		return nil, fmt.Errorf("cannot cast %s with value: %v to map", utils.MetaOpts, data[utils.MetaOpts])
+	}
+	argsRU = make([]*utils.ArgRSv1ResourceUsage, len(aL.cfg().Diktats))
+	for i, actD := range aL.cfg().Diktats {
+		var usageID string
+		var rsr config.RSRParsers
+		if rsr, err = actD.RSRValues(aL.config.GeneralCfg().RSRSep); err != nil {
+			return
+		}
+		if usageID, err = rsr.ParseDataProvider(data); err != nil {
+			return
+		}
+		argsRU[i] = &utils.ArgRSv1ResourceUsage{
+			CGREvent: &utils.CGREvent{
+				Tenant: aL.tnt,
+				Time:   utils.TimePointer(time.Now()),
+				ID:     utils.GenUUID(),
+				Event:  ev,
+				Opts:   opts,
+			},
+			UsageID: usageID,
+		}
+	}
+	return
+}
@@ -91,7 +91,7 @@ type AgentRequest struct {
 	filterS    *engine.FilterS
 	Header     utils.DataProvider
 	Trailer    utils.DataProvider
-	diamreq    *utils.OrderedNavigableMap // used in case of building requests (ie. DisconnectSession)
+	diamreq    *utils.OrderedNavigableMap // used in case of building requests (ie. DisconnectSession), shared by *diamreq and *radDAReq
 	tmp        utils.NavigableMap2        // used in case you want to store temporary items and access them later
 	Opts       *utils.OrderedNavigableMap
 	Cfg        utils.DataProvider
@@ -120,7 +120,7 @@ func (ar *AgentRequest) FieldAsInterface(fldPath []string) (val interface{}, err
 		val, err = ar.CGRRequest.FieldAsInterface(fldPath[1:])
 	case utils.MetaCgrep:
 		val, err = ar.CGRReply.FieldAsInterface(fldPath[1:])
-	case utils.MetaDiamreq:
+	case utils.MetaDiamreq, utils.MetaRadDAReq:
 		val, err = ar.diamreq.FieldAsInterface(fldPath[1:])
 	case utils.MetaRep:
 		val, err = ar.Reply.FieldAsInterface(fldPath[1:])
@@ -161,7 +161,7 @@ func (ar *AgentRequest) Field(fldPath utils.PathItems) (val utils.NMInterface, e
 		val, err = ar.CGRRequest.Field(fldPath[1:])
 	case utils.MetaCgrep:
 		val, err = ar.CGRReply.Field(fldPath[1:])
-	case utils.MetaDiamreq:
+	case utils.MetaDiamreq, utils.MetaRadDAReq:
 		val, err = ar.diamreq.Field(fldPath[1:])
 	case utils.MetaRep:
 		val, err = ar.Reply.Field(fldPath[1:])
@@ -276,6 +276,11 @@ func (ar *AgentRequest) Set(fullPath *utils.FullPath, nm utils.NMInterface) (add
 			PathItems: fullPath.PathItems[1:],
 			Path:      fullPath.Path[9:],
 		}, nm)
+	case utils.MetaRadDAReq:
+		return ar.diamreq.Set(&utils.FullPath{
+			PathItems: fullPath.PathItems[1:],
+			Path:      fullPath.Path[10:],
+		}, nm)
 	case utils.MetaTmp:
 		return ar.tmp.Set(fullPath.PathItems[1:], nm)
 	case utils.MetaOpts:
@@ -302,7 +307,7 @@ func (ar *AgentRequest) RemoveAll(prefix string) error {
 		ar.CGRReply = &utils.NavigableMap2{}
 	case utils.MetaRep:
 		ar.Reply.RemoveAll()
-	case utils.MetaDiamreq:
+	case utils.MetaDiamreq, utils.MetaRadDAReq:
 		ar.diamreq.RemoveAll()
 	case utils.MetaTmp:
 		ar.tmp = utils.NavigableMap2{}
@@ -338,6 +343,11 @@ func (ar *AgentRequest) Remove(fullPath *utils.FullPath) error {
 			PathItems: fullPath.PathItems[1:].Clone(),
 			Path:      fullPath.Path[9:],
 		})
+	case utils.MetaRadDAReq:
+		return ar.diamreq.Remove(&utils.FullPath{
+			PathItems: fullPath.PathItems[1:].Clone(),
+			Path:      fullPath.Path[10:],
+		})
 	case utils.MetaTmp:
 		return ar.tmp.Remove(fullPath.PathItems[1:])
 	case utils.MetaOpts:
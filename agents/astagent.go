@@ -213,8 +213,8 @@ func (sma *AsteriskAgent) handleStasisStart(ev *SMAsteriskEvent) {
 			return
 		}
 	}
-	if authReply.Routes != nil {
-		for i, route := range authReply.Routes.Routes {
+	if len(authReply.Routes) != 0 {
+		for i, route := range authReply.Routes[0].Routes {
 			if !sma.setChannelVar(ev.ChannelID(),
 				CGRRoute+strconv.Itoa(i+1), route.RouteID) {
 				return
@@ -116,6 +116,52 @@ func (fsa *FSsessions) setMaxCallDuration(uuid string, connIdx int,
 	return nil
 }
 
+// resetMaxCallDuration reschedules the end of an ongoing call
+func (fsa *FSsessions) resetMaxCallDuration(uuid string, connIdx int,
+	maxDur time.Duration, destNr string) (err error) {
+	// remove the previous schedule, ignoring the error since the call may have none
+	fsa.conns[connIdx].SendApiCmd(fmt.Sprintf("sched_del %s\n\n", uuid))
+	schedCmd := fmt.Sprintf("sched_hangup +%d %s alloted_timeout\n\n",
+		int(maxDur.Seconds()), uuid)
+	if len(fsa.cfg.EmptyBalanceContext) != 0 {
+		schedCmd = fmt.Sprintf("sched_transfer +%d %s %s XML %s\n\n",
+			int(maxDur.Seconds()), uuid, destNr, fsa.cfg.EmptyBalanceContext)
+	} else if len(fsa.cfg.EmptyBalanceAnnFile) != 0 {
+		schedCmd = fmt.Sprintf("sched_broadcast +%d %s playback!manager_request::%s aleg\n\n",
+			int(maxDur.Seconds()), uuid, fsa.cfg.EmptyBalanceAnnFile)
+	}
+	if _, err = fsa.conns[connIdx].SendApiCmd(schedCmd); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> Could not reschedule the end of call <%s>, error: <%s>, connIdx: %v",
+				utils.FreeSWITCHAgent, uuid, err.Error(), connIdx))
+	}
+	return
+}
+
+// reauthorizeChannel queries SessionS for the remaining usage of an ongoing call and reschedules its end
+func (fsa *FSsessions) reauthorizeChannel(fsev FSEvent, connIdx int) {
+	fsev[VarCGROriginHost] = utils.FirstNonEmpty(fsev[VarCGROriginHost], fsa.cfg.EventSocketConns[connIdx].Alias) // rewrite the OriginHost variable if it is empty
+	authArgs := fsev.V1ReauthorizeArgs()
+	if authArgs == nil {
+		return
+	}
+	authArgs.CGREvent.Event[FsConnID] = connIdx // Attach the connection ID
+	var authReply sessions.V1AuthorizeReply
+	if err := fsa.connMgr.Call(fsa.cfg.SessionSConns, fsa, utils.SessionSv1AuthorizeEvent, authArgs, &authReply); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> Could not reauthorize event %s, error: %s",
+				utils.FreeSWITCHAgent, fsev.GetUUID(), err.Error()))
+		return
+	}
+	if authReply.MaxUsage == nil || *authReply.MaxUsage == 0 {
+		fsa.disconnectSession(connIdx, fsev.GetUUID(), fsev.GetCallDestNr(utils.MetaDefault),
+			utils.ErrInsufficientCredit.Error())
+		return
+	}
+	fsa.resetMaxCallDuration(fsev.GetUUID(), connIdx,
+		*authReply.MaxUsage, fsev.GetCallDestNr(utils.MetaDefault))
+}
+
 // Sends the transfer command to unpark the call to freeswitch
 func (fsa *FSsessions) unparkCall(uuid string, connIdx int, callDestNb, notify string) (err error) {
 	_, err = fsa.conns[connIdx].SendApiCmd(
@@ -443,9 +489,25 @@ func (fsa *FSsessions) Reload() {
 	fsa.senderPools = make([]*fsock.FSockPool, len(fsa.cfg.EventSocketConns))
 }
 
-// V1ReAuthorize is used to implement the sessions.BiRPClient interface
-func (*FSsessions) V1ReAuthorize(originID string, reply *string) (err error) {
-	return utils.ErrNotImplemented
+// V1ReAuthorize will recompute the maximum duration of the ongoing call and reschedule its hangup
+func (fsa *FSsessions) V1ReAuthorize(originID string, reply *string) (err error) {
+	for connIdx, fsConn := range fsa.conns {
+		if fsConn == nil {
+			continue
+		}
+		var chanData string
+		if chanData, err = fsConn.SendApiCmd(fmt.Sprintf("uuid_dump %s\n\n", originID)); err != nil {
+			continue // channel not on this connection
+		}
+		// SessionS is waiting for our reply so authorize in a separate goroutine
+		go fsa.reauthorizeChannel(NewFSEvent(chanData), connIdx)
+		*reply = utils.OK
+		return nil
+	}
+	utils.Logger.Err(
+		fmt.Sprintf("<%s> could not find channel <%s> for reauthorization",
+			utils.FreeSWITCHAgent, originID))
+	return utils.ErrNotFound
 }
 
 // V1DisconnectPeer is used to implement the sessions.BiRPClient interface
@@ -20,9 +20,19 @@ package agents
 import (
 	"testing"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
 )
 
 func TestFAsSessionSClientIface(t *testing.T) {
 	_ = sessions.BiRPClient(new(FSsessions))
 }
+
+func TestFAV1ReAuthorizeNoChannel(t *testing.T) {
+	fsa := NewFSsessions(config.NewDefaultCGRConfig().FsAgentCfg(), utils.EmptyString, nil)
+	var reply string
+	if err := fsa.V1ReAuthorize("e3133bf7-dcde-4daf-9663-9a79ffcef5ad", &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
@@ -411,6 +411,19 @@ func (fsev FSEvent) V1AuthorizeArgs() (args *sessions.V1AuthorizeArgs) {
 	return
 }
 
+// V1ReauthorizeArgs returns the arguments used in SessionSv1.AuthorizeEvent to refresh the max usage of an answered call
+// only the max usage is requested since the other subsystems were processed when the call started
+func (fsev FSEvent) V1ReauthorizeArgs() (args *sessions.V1AuthorizeArgs) {
+	if args = fsev.V1AuthorizeArgs(); args == nil || !args.GetMaxUsage {
+		return nil
+	}
+	args.AuthorizeResources = false
+	args.ProcessThresholds = false
+	args.ProcessStats = false
+	args.GetRoutes = false
+	return
+}
+
 // V1InitSessionArgs returns the arguments used in SessionSv1.InitSession
 func (fsev FSEvent) V1InitSessionArgs() (args *sessions.V1InitSessionArgs) {
 	cgrEv, err := fsev.AsCGREvent(config.CgrConfig().GeneralCfg().DefaultTimezone)
@@ -1074,3 +1074,18 @@ func TestFsEvV1TerminateSessionArgs(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", expected.TerminateSession, rcv.TerminateSession)
 	}
 }
+
+func TestFsEvV1ReauthorizeArgs(t *testing.T) {
+	ev := NewFSEvent(hangupEv)
+	ev[VarCGRFlags] = "*resources,*thresholds,*stats,*routes"
+	if rcv := ev.V1ReauthorizeArgs(); rcv != nil {
+		t.Errorf("Expected no arguments without max usage, received %s", utils.ToJSON(rcv))
+	}
+	ev[VarCGRFlags] = "*accounts,*resources,*thresholds,*stats,*routes"
+	if rcv := ev.V1ReauthorizeArgs(); rcv == nil {
+		t.Error("Expected reauthorize arguments")
+	} else if !rcv.GetMaxUsage || rcv.AuthorizeResources ||
+		rcv.ProcessThresholds || rcv.ProcessStats || rcv.GetRoutes {
+		t.Errorf("Expected only the max usage requested, received %s", utils.ToJSON(rcv))
+	}
+}
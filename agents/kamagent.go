@@ -24,6 +24,7 @@ import (
 	"log"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/rpc2"
@@ -53,6 +54,7 @@ func NewKamailioAgent(kaCfg *config.KamAgentCfg,
 		timezone:         timezone,
 		conns:            make([]*kamevapi.KamEvapi, len(kaCfg.EvapiConns)),
 		activeSessionIDs: make(chan []*sessions.SessionID),
+		dlgsCache:        make(map[string]*kamDialog),
 	}
 	return
 }
@@ -63,6 +65,14 @@ type KamailioAgent struct {
 	timezone         string
 	conns            []*kamevapi.KamEvapi
 	activeSessionIDs chan []*sessions.SessionID
+	dlgsCache        map[string]*kamDialog // the started dialogs indexed on OriginID, used for reauthorization
+	dlgsCacheMux     sync.RWMutex          // protect dlgsCache
+}
+
+// kamDialog is the CGR_CALL_START event of a dialog together with the connection it came on
+type kamDialog struct {
+	kev     KamEvent
+	connIdx int
 }
 
 func (self *KamailioAgent) Connect() (err error) {
@@ -196,6 +206,9 @@ func (ka *KamailioAgent) onCallStart(evData []byte, connIdx int) {
 				utils.ErrServerError.Error()))
 		return
 	}
+	ka.dlgsCacheMux.Lock()
+	ka.dlgsCache[kev[utils.OriginID]] = &kamDialog{kev: kev, connIdx: connIdx}
+	ka.dlgsCacheMux.Unlock()
 }
 
 func (ka *KamailioAgent) onCallEnd(evData []byte, connIdx int) {
@@ -213,6 +226,9 @@ func (ka *KamailioAgent) onCallEnd(evData []byte, connIdx int) {
 	if kev[utils.RequestType] == utils.MetaNone { // Do not process this request
 		return
 	}
+	ka.dlgsCacheMux.Lock()
+	delete(ka.dlgsCache, kev[utils.OriginID])
+	ka.dlgsCacheMux.Unlock()
 	if kev.MissingParameter() {
 		utils.Logger.Err(fmt.Sprintf("<%s> mandatory IE missing out from event: %s",
 			utils.KamailioAgent, kev[utils.OriginID]))
@@ -429,11 +445,63 @@ func (ka *KamailioAgent) V1GetActiveSessionIDs(ignParam string, sessionIDs *[]*s
 // only used on reload
 func (ka *KamailioAgent) Reload() {
 	ka.conns = make([]*kamevapi.KamEvapi, len(ka.cfg.EvapiConns))
+	// the dialogs are indexed on the old connections and their end is not received anymore
+	ka.dlgsCacheMux.Lock()
+	ka.dlgsCache = make(map[string]*kamDialog)
+	ka.dlgsCacheMux.Unlock()
 }
 
-// V1ReAuthorize is used to implement the sessions.BiRPClient interface
-func (*KamailioAgent) V1ReAuthorize(originID string, reply *string) (err error) {
-	return utils.ErrNotImplemented
+// V1ReAuthorize authorizes again the dialog and updates its timeout in Kamailio
+func (ka *KamailioAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	ka.dlgsCacheMux.RLock()
+	dlg, has := ka.dlgsCache[originID]
+	ka.dlgsCacheMux.RUnlock()
+	if !has {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> could not find dialog <%s> for reauthorization",
+				utils.KamailioAgent, originID))
+		return utils.ErrNotFound
+	}
+	// SessionS is waiting for our reply so authorize in a separate goroutine
+	go ka.reauthorizeDialog(dlg)
+	*reply = utils.OK
+	return
+}
+
+// reauthorizeDialog queries SessionS for the new maximum usage of the dialog and sends it to Kamailio
+func (ka *KamailioAgent) reauthorizeDialog(dlg *kamDialog) {
+	if dlg.connIdx >= len(ka.conns) || ka.conns[dlg.connIdx] == nil { // connections were reloaded
+		utils.Logger.Err(fmt.Sprintf("<%s> no connection with index: %v to reauthorize dialog: %s",
+			utils.KamailioAgent, dlg.connIdx, dlg.kev[utils.OriginID]))
+		return
+	}
+	authArgs := dlg.kev.V1ReauthorizeArgs()
+	if authArgs == nil {
+		return
+	}
+	authArgs.CGREvent.Event[EvapiConnID] = dlg.connIdx // Attach the connection ID
+	var authReply sessions.V1AuthorizeReply
+	if err := ka.connMgr.Call(ka.cfg.SessionSConns, ka, utils.SessionSv1AuthorizeEvent,
+		authArgs, &authReply); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> could not reauthorize dialog %s, error: %s",
+				utils.KamailioAgent, dlg.kev[utils.OriginID], err.Error()))
+		return
+	}
+	if authReply.MaxUsage == nil || *authReply.MaxUsage == 0 {
+		ka.dlgsCacheMux.Lock()
+		delete(ka.dlgsCache, dlg.kev[utils.OriginID])
+		ka.dlgsCacheMux.Unlock()
+		ka.disconnectSession(dlg.connIdx,
+			NewKamSessionDisconnect(dlg.kev[KamHashEntry], dlg.kev[KamHashID],
+				utils.ErrInsufficientCredit.Error()))
+		return
+	}
+	if err := ka.conns[dlg.connIdx].Send(NewKamSessionTimeout(dlg.kev[KamHashEntry],
+		dlg.kev[KamHashID], *authReply.MaxUsage).String()); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed sending session timeout for dialog: %s, error: %s",
+			utils.KamailioAgent, dlg.kev[utils.OriginID], err.Error()))
+	}
 }
 
 // V1DisconnectPeer is used to implement the sessions.BiRPClient interface
@@ -441,9 +509,39 @@ func (*KamailioAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err
 	return utils.ErrNotImplemented
 }
 
-// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
-func (*KamailioAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
-	return utils.ErrNotImplemented
+// V1WarnDisconnect is called when the call goes under the minimum duration threshold, so Kamailio can play an announcement message
+func (ka *KamailioAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
+	hEntry := utils.IfaceAsString(args[KamHashEntry])
+	hID := utils.IfaceAsString(args[KamHashID])
+	connIdxIface, has := args[EvapiConnID]
+	if !has {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s:%s> when attempting to warn <%s:%s> and <%s:%s>",
+				utils.KamailioAgent, utils.ErrNotFound.Error(), EvapiConnID,
+				KamHashEntry, hEntry, KamHashID, hID))
+		return utils.ErrNotFound
+	}
+	var connIdx int64
+	if connIdx, err = utils.IfaceAsTInt64(connIdxIface); err != nil {
+		return
+	}
+	if int(connIdx) >= len(ka.conns) { // protection against index out of range panic
+		err = fmt.Errorf("Index out of range[0,%v): %v ", len(ka.conns), connIdx)
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.KamailioAgent, err.Error()))
+		return
+	}
+	if ka.conns[connIdx] == nil { // not connected yet or the connections were reloaded
+		utils.Logger.Err(fmt.Sprintf("<%s> no connection with index: %v to warn <%s:%s> and <%s:%s>",
+			utils.KamailioAgent, connIdx, KamHashEntry, hEntry, KamHashID, hID))
+		return utils.ErrDisconnected
+	}
+	if err = ka.conns[connIdx].Send(NewKamWarnDisconnect(hEntry, hID).String()); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed sending warn disconnect for <%s:%s> and <%s:%s>, connection id: %v, error %s",
+			utils.KamailioAgent, KamHashEntry, hEntry, KamHashID, hID, connIdx, err.Error()))
+		return
+	}
+	*reply = utils.OK
+	return
 }
 
 // CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
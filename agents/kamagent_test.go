@@ -20,9 +20,71 @@ package agents
 import (
 	"testing"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
 )
 
 func TestKAsSessionSClientIface(t *testing.T) {
 	_ = sessions.BiRPClient(new(KamailioAgent))
 }
+
+func TestKAV1ReAuthorizeUnknownDialog(t *testing.T) {
+	ka := NewKamailioAgent(config.NewDefaultCGRConfig().KamAgentCfg(), nil, utils.EmptyString)
+	var reply string
+	if err := ka.V1ReAuthorize("callid1;ftag1", &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestKAV1ReAuthorize(t *testing.T) {
+	ka := NewKamailioAgent(config.NewDefaultCGRConfig().KamAgentCfg(), nil, utils.EmptyString)
+	ka.dlgsCache["callid1;ftag1"] = &kamDialog{kev: KamEvent{
+		EVENT:          CGR_CALL_START,
+		KamHashEntry:   "1234",
+		KamHashID:      "5678",
+		utils.OriginID: "callid1;ftag1",
+	}}
+	var reply string
+	if err := ka.V1ReAuthorize("callid1;ftag1", &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %s, received %s", utils.OK, reply)
+	}
+}
+
+func TestKAV1WarnDisconnectErrors(t *testing.T) {
+	ka := NewKamailioAgent(config.NewDefaultCGRConfig().KamAgentCfg(), nil, utils.EmptyString)
+	var reply string
+	if err := ka.V1WarnDisconnect(map[string]interface{}{
+		KamHashEntry: "1234",
+		KamHashID:    "5678",
+	}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	expErr := "Index out of range[0,1): 3 "
+	if err := ka.V1WarnDisconnect(map[string]interface{}{
+		KamHashEntry: "1234",
+		KamHashID:    "5678",
+		EvapiConnID:  3,
+	}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %q, received %v", expErr, err)
+	}
+	if err := ka.V1WarnDisconnect(map[string]interface{}{
+		KamHashEntry: "1234",
+		KamHashID:    "5678",
+		EvapiConnID:  0,
+	}, &reply); err != utils.ErrDisconnected {
+		t.Errorf("Expected %v, received %v", utils.ErrDisconnected, err)
+	}
+}
+
+func TestKAReloadClearsDialogs(t *testing.T) {
+	ka := NewKamailioAgent(config.NewDefaultCGRConfig().KamAgentCfg(), nil, utils.EmptyString)
+	ka.dlgsCache["callid1;ftag1"] = &kamDialog{kev: KamEvent{utils.OriginID: "callid1;ftag1"}}
+	ka.Reload()
+	var reply string
+	if err := ka.V1ReAuthorize("callid1;ftag1", &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
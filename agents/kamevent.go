@@ -44,6 +44,8 @@ const (
 	KamReplyRoute          = "reply_route"
 	EvapiConnID            = "EvapiConnID" // used to share connID info in event for remote disconnects
 	CGR_DLG_LIST           = "CGR_DLG_LIST"
+	CGR_SESSION_TIMEOUT    = "CGR_SESSION_TIMEOUT"
+	CGR_WARN_DISCONNECT    = "CGR_WARN_DISCONNECT"
 )
 
 var (
@@ -70,6 +72,48 @@ func (ksd *KamSessionDisconnect) String() string {
 	return utils.ToJSON(ksd)
 }
 
+// NewKamSessionTimeout returns the event updating the timeout of the dialog after reauthorization
+func NewKamSessionTimeout(hEntry, hID string, maxUsage time.Duration) *KamSessionTimeout {
+	return &KamSessionTimeout{
+		Event:     CGR_SESSION_TIMEOUT,
+		HashEntry: hEntry,
+		HashId:    hID,
+		MaxUsage:  int(utils.Round(maxUsage.Seconds(), 0, utils.MetaRoundingMiddle)),
+	}
+}
+
+// KamSessionTimeout is sent to Kamailio with the new maximum usage of the dialog
+type KamSessionTimeout struct {
+	Event     string
+	HashEntry string
+	HashId    string
+	MaxUsage  int // Maximum session time from now on, in seconds
+}
+
+func (kst *KamSessionTimeout) String() string {
+	return utils.ToJSON(kst)
+}
+
+// NewKamWarnDisconnect returns the event warning Kamailio that the dialog is running out of credit
+func NewKamWarnDisconnect(hEntry, hID string) *KamWarnDisconnect {
+	return &KamWarnDisconnect{
+		Event:     CGR_WARN_DISCONNECT,
+		HashEntry: hEntry,
+		HashId:    hID,
+	}
+}
+
+// KamWarnDisconnect is sent to Kamailio so it can play a low balance announcement
+type KamWarnDisconnect struct {
+	Event     string
+	HashEntry string
+	HashId    string
+}
+
+func (kwd *KamWarnDisconnect) String() string {
+	return utils.ToJSON(kwd)
+}
+
 // NewKamEvent parses bytes received over the wire from Kamailio into KamEvent
 func NewKamEvent(kamEvData []byte, alias, adress string) (KamEvent, error) {
 	kev := make(map[string]string)
@@ -217,6 +261,19 @@ func (kev KamEvent) V1AuthorizeArgs() (args *sessions.V1AuthorizeArgs) {
 	return
 }
 
+// V1ReauthorizeArgs returns the arguments used in SessionSv1.AuthorizeEvent to refresh the max usage of a started dialog
+// only the max usage is requested since the other subsystems were processed when the dialog started
+func (kev KamEvent) V1ReauthorizeArgs() (args *sessions.V1AuthorizeArgs) {
+	if args = kev.V1AuthorizeArgs(); args == nil || !args.GetMaxUsage {
+		return nil
+	}
+	args.AuthorizeResources = false
+	args.ProcessThresholds = false
+	args.ProcessStats = false
+	args.GetRoutes = false
+	return
+}
+
 // AsKamAuthReply builds up a Kamailio AuthReply based on arguments and reply from SessionS
 func (kev KamEvent) AsKamAuthReply(authArgs *sessions.V1AuthorizeArgs,
 	authReply *sessions.V1AuthorizeReply, rplyErr error) (kar *KamReply, err error) {
@@ -503,3 +503,36 @@ func TestKamEvAsKamProcessEventReply(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", expected, rcv)
 	}
 }
+
+func TestKamEventV1ReauthorizeArgs(t *testing.T) {
+	kev := KamEvent{
+		EVENT:              CGR_CALL_START,
+		utils.OriginID:     "callid1;ftag1",
+		utils.AccountField: "1001",
+		utils.CGRFlags:     "*resources,*thresholds,*stats,*routes",
+	}
+	if rcv := kev.V1ReauthorizeArgs(); rcv != nil {
+		t.Errorf("Expected no arguments without max usage, received %s", utils.ToJSON(rcv))
+	}
+	kev[utils.CGRFlags] = "*accounts,*resources,*thresholds,*stats,*routes"
+	if rcv := kev.V1ReauthorizeArgs(); rcv == nil {
+		t.Error("Expected reauthorize arguments")
+	} else if !rcv.GetMaxUsage || rcv.AuthorizeResources ||
+		rcv.ProcessThresholds || rcv.ProcessStats || rcv.GetRoutes {
+		t.Errorf("Expected only the max usage requested, received %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestKamSessionTimeoutString(t *testing.T) {
+	exp := `{"Event":"CGR_SESSION_TIMEOUT","HashEntry":"1234","HashId":"5678","MaxUsage":90}`
+	if rcv := NewKamSessionTimeout("1234", "5678", 90*time.Second).String(); rcv != exp {
+		t.Errorf("Expected %s, received %s", exp, rcv)
+	}
+}
+
+func TestKamWarnDisconnectString(t *testing.T) {
+	exp := `{"Event":"CGR_WARN_DISCONNECT","HashEntry":"1234","HashId":"5678"}`
+	if rcv := NewKamWarnDisconnect("1234", "5678").String(); rcv != exp {
+		t.Errorf("Expected %s, received %s", exp, rcv)
+	}
+}
@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
@@ -43,7 +44,8 @@ func newHADataProvider(reqPayload string,
 		return newHTTPUrlDP(req)
 	case utils.MetaXml:
 		return newHTTPXmlDP(req)
-
+	case utils.MetaJSON:
+		return newHTTPJSONDP(req)
 	}
 }
 
@@ -187,6 +189,47 @@ func (hU *httpXmlDP) RemoteHost() net.Addr {
 	return utils.NewNetAddr("TCP", hU.addr)
 }
 
+func newHTTPJSONDP(req *http.Request) (dP utils.DataProvider, err error) {
+	var data map[string]interface{}
+	if err = json.NewDecoder(req.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	dP = &httpJSONDP{data: data, addr: req.RemoteAddr}
+	return
+}
+
+// httpJSONDP implements utils.DataProvider, serving as json data decoder
+// nested objects and arrays are reachable with the usual path and index notation (ie. Items[0].ID)
+type httpJSONDP struct {
+	data utils.MapStorage
+	addr string
+}
+
+// String is part of utils.DataProvider interface
+func (hJ *httpJSONDP) String() string {
+	return hJ.data.String()
+}
+
+// FieldAsInterface is part of utils.DataProvider interface
+func (hJ *httpJSONDP) FieldAsInterface(fldPath []string) (data interface{}, err error) {
+	return hJ.data.FieldAsInterface(fldPath)
+}
+
+// FieldAsString is part of utils.DataProvider interface
+func (hJ *httpJSONDP) FieldAsString(fldPath []string) (data string, err error) {
+	var valIface interface{}
+	valIface, err = hJ.FieldAsInterface(fldPath)
+	if err != nil {
+		return
+	}
+	return utils.IfaceAsString(valIface), nil
+}
+
+// RemoteHost is part of utils.DataProvider interface
+func (hJ *httpJSONDP) RemoteHost() net.Addr {
+	return utils.NewNetAddr("TCP", hJ.addr)
+}
+
 // httpAgentReplyEncoder will encode  []*engine.NMElement
 // and write content to http writer
 type httpAgentReplyEncoder interface {
@@ -203,6 +246,8 @@ func newHAReplyEncoder(encType string,
 		return newHAXMLEncoder(w)
 	case utils.MetaTextPlain:
 		return newHATextPlainEncoder(w)
+	case utils.MetaJSON:
+		return newHAJSONEncoder(w)
 	}
 }
 
@@ -265,3 +310,46 @@ func (xE *haTextPlainEncoder) Encode(nM *utils.OrderedNavigableMap) (err error)
 	_, err = xE.w.Write([]byte(str))
 	return
 }
+
+func newHAJSONEncoder(w http.ResponseWriter) (jE httpAgentReplyEncoder, err error) {
+	return &haJSONEncoder{w: w}, nil
+}
+
+type haJSONEncoder struct {
+	w http.ResponseWriter
+}
+
+// Encode implements httpAgentReplyEncoder
+// the paths are built as nested objects while multiple values on the same path are grouped in arrays
+func (jE *haJSONEncoder) Encode(nM *utils.OrderedNavigableMap) (err error) {
+	msg := utils.MapStorage{}
+	for el := nM.GetFirstElement(); el != nil; el = el.Next() {
+		val := el.Value
+		var nmIt utils.NMInterface
+		if nmIt, err = nM.Field(val); err != nil {
+			return
+		}
+		nmItem, isNMItems := nmIt.(*config.NMItem)
+		if !isNMItems {
+			return fmt.Errorf("value: %s is not *NMItem", val)
+		}
+		data := nmItem.Data
+		if prev, errPrev := msg.FieldAsInterface(nmItem.Path); errPrev == nil {
+			if prevSls, isSlice := prev.([]interface{}); isSlice {
+				data = append(prevSls, data)
+			} else {
+				data = []interface{}{prev, data}
+			}
+		}
+		if err = msg.Set(nmItem.Path, data); err != nil {
+			return
+		}
+	}
+	var jsnOut []byte
+	if jsnOut, err = json.Marshal(msg); err != nil {
+		return
+	}
+	jE.w.Header().Set("Content-Type", "application/json")
+	_, err = jE.w.Write(jsnOut)
+	return
+}
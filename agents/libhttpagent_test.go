@@ -22,8 +22,12 @@ import (
 	"bufio"
 	"bytes"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
 )
 
 func TestHttpUrlDPFieldAsInterface(t *testing.T) {
@@ -190,3 +194,72 @@ func TestHttpXmlDPFieldAsInterface2(t *testing.T) {
 		t.Errorf("expecting: 0.0225, received: <%s>", data)
 	}
 }
+
+func TestHttpJSONDPFieldAsInterface(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/voucher",
+		strings.NewReader(`{"Account":"1001","Amount":10.5,"Voucher":{"Code":"V123","Tags":["promo","summer"]},"Items":[{"ID":"I1"},{"ID":"I2"}]}`))
+	hJ, err := newHADataProvider(utils.MetaJSON, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, exp := range map[string]string{
+		"Account":         "1001",
+		"Amount":          "10.5",
+		"Voucher.Code":    "V123",
+		"Voucher.Tags[1]": "summer",
+		"Items[1].ID":     "I2",
+	} {
+		if data, err := hJ.FieldAsString(strings.Split(path, utils.NestingSep)); err != nil {
+			t.Errorf("path: %s, err: %v", path, err)
+		} else if data != exp {
+			t.Errorf("path: %s, expecting: <%s>, received: <%s>", path, exp, data)
+		}
+	}
+	if _, err := hJ.FieldAsString([]string{"nonexistent"}); err != utils.ErrNotFound {
+		t.Errorf("Expected %s, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := newHADataProvider(utils.MetaJSON,
+		httptest.NewRequest(http.MethodPost, "/voucher", strings.NewReader(`{"Account":`))); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestHAJSONEncoder(t *testing.T) {
+	nM := utils.NewOrderedNavigableMap()
+	for _, itm := range []*config.NMItem{
+		{Path: []string{"Result"}, Data: "OK"},
+		{Path: []string{"Balance", "Value"}, Data: 10.5},
+		{Path: []string{"Balance", "Unit"}, Data: "EUR"},
+	} {
+		if _, err := nM.Set(&utils.FullPath{
+			PathItems: utils.NewPathItems(itm.Path),
+			Path:      strings.Join(itm.Path, utils.NestingSep),
+		}, &utils.NMSlice{itm}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := nM.Set(&utils.FullPath{
+		PathItems: utils.PathItems{{Field: "Tags"}},
+		Path:      "Tags",
+	}, &utils.NMSlice{
+		&config.NMItem{Path: []string{"Tags"}, Data: "promo"},
+		&config.NMItem{Path: []string{"Tags"}, Data: "summer"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	encdr, err := newHAReplyEncoder(utils.MetaJSON, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = encdr.Encode(nM); err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"Balance":{"Unit":"EUR","Value":10.5},"Result":"OK","Tags":["promo","summer"]}`
+	if rcv := w.Body.String(); rcv != exp {
+		t.Errorf("Expected %s, received %s", exp, rcv)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Unexpected Content-Type: %s", ct)
+	}
+}
@@ -19,14 +19,39 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/radigo"
 )
 
+// Dynamic Authorization packet codes as defined by RFC 5176
+const (
+	radDisconnectRequest radigo.PacketCode = 40
+	radDisconnectACK     radigo.PacketCode = 41
+	radDisconnectNAK     radigo.PacketCode = 42
+	radCoARequest        radigo.PacketCode = 43
+	radCoAACK            radigo.PacketCode = 44
+	radCoANAK            radigo.PacketCode = 45
+
+	radErrorCauseAVP = 101    // Error-Cause attribute number
+	radDynAuthPort   = "3799" // default Dynamic Authorization port of the clients
+)
+
+// radMsgData is cached per session so we can build the Dynamic Authorization requests towards the client
+type radMsgData struct {
+	req  *radigo.Packet
+	vars utils.NavigableMap2
+	host string // client which sent the request
+}
+
 // radReplyAppendAttributes appends attributes to a RADIUS reply based on predefined template
 func radReplyAppendAttributes(reply *radigo.Packet, rplNM *utils.OrderedNavigableMap) (err error) {
 	for el := rplNM.GetFirstElement(); el != nil; el = el.Next() {
@@ -188,3 +213,78 @@ func radauthReq(flags utils.FlagsWithParams, req *radigo.Packet, aReq *AgentRequ
 
 	return true, nil
 }
+
+// radDAEncode encodes the Dynamic Authorization request into b,
+// computing the Request Authenticator as described by RFC 5176
+func radDAEncode(pkt *radigo.Packet, secret string, b []byte) (n int, err error) {
+	if n, err = pkt.Encode(b); err != nil {
+		return
+	}
+	var nul [16]byte
+	copy(b[4:20], nul[:])
+	hash := md5.New()
+	hash.Write(b[:n])
+	hash.Write([]byte(secret))
+	copy(pkt.Authenticator[:], hash.Sum(nil))
+	copy(b[4:20], pkt.Authenticator[:])
+	return
+}
+
+// radDAIsAuthentic checks the Response Authenticator of a Dynamic Authorization reply
+func radDAIsAuthentic(rawRpl []byte, secret string, reqAuthenticator [16]byte) bool {
+	raw := make([]byte, len(rawRpl))
+	copy(raw, rawRpl)
+	copy(raw[4:20], reqAuthenticator[:])
+	hash := md5.New()
+	hash.Write(raw)
+	hash.Write([]byte(secret))
+	return bytes.Equal(rawRpl[4:20], hash.Sum(nil))
+}
+
+// radDAErrorCause returns the Error-Cause out of a NAK reply
+func radDAErrorCause(rpl *radigo.Packet) (errCause uint32, has bool) {
+	for _, avp := range rpl.AVPs {
+		if avp.Number == radErrorCauseAVP &&
+			len(avp.RawValue) == 4 {
+			return binary.BigEndian.Uint32(avp.RawValue), true
+		}
+	}
+	return
+}
+
+// sendRadDAReq sends the Dynamic Authorization request to the client at daAddr and returns it's reply
+func sendRadDAReq(pkt *radigo.Packet, daAddr, secret string,
+	dict *radigo.Dictionary, timeout time.Duration) (rpl *radigo.Packet, err error) {
+	var b [4096]byte
+	var n int
+	if n, err = radDAEncode(pkt, secret, b[:]); err != nil {
+		return
+	}
+	var conn net.Conn
+	if conn, err = net.DialTimeout(utils.UDP, daAddr, timeout); err != nil {
+		return
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return
+	}
+	if _, err = conn.Write(b[:n]); err != nil {
+		return
+	}
+	for { // ignore the packets not matching our request
+		if n, err = conn.Read(b[:]); err != nil {
+			return
+		}
+		if n < 20 ||
+			int(binary.BigEndian.Uint16(b[2:4])) != n ||
+			b[1] != pkt.Identifier {
+			continue
+		}
+		if !radDAIsAuthentic(b[:n], secret, pkt.Authenticator) {
+			return nil, errors.New("invalid reply authenticator")
+		}
+		rpl = radigo.NewPacket(radigo.PacketCode(b[0]), b[1], dict, radigo.NewCoder(), secret)
+		err = rpl.Decode(b[:n])
+		return
+	}
+}
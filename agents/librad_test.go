@@ -141,3 +141,28 @@ func TestRadiusDPFieldAsString(t *testing.T) {
 		t.Errorf("Expecting: flopsy, received: <%s>", data)
 	}
 }
+
+func TestRadDAEncode(t *testing.T) {
+	pkt := radigo.NewPacket(radCoARequest, 7, dictRad, coder, "CGRateS.org")
+	if err := pkt.AddAVPWithName("User-Name", "1001", ""); err != nil {
+		t.Fatal(err)
+	}
+	var b [4096]byte
+	n, err := radDAEncode(pkt, "CGRateS.org", b[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != byte(radCoARequest) || b[1] != 7 {
+		t.Errorf("Unexpected header: %v", b[:4])
+	}
+	if !reflect.DeepEqual(b[4:20], pkt.Authenticator[:]) {
+		t.Errorf("Expected authenticator %v, received %v", pkt.Authenticator, b[4:20])
+	}
+	// the Request Authenticator is computed over a zeroed authenticator
+	if !radDAIsAuthentic(b[:n], "CGRateS.org", [16]byte{}) {
+		t.Error("Expected the request to be authentic")
+	}
+	if radDAIsAuthentic(b[:n], "wrongSecret", [16]byte{}) {
+		t.Error("Expected the request to not be authentic with a different secret")
+	}
+}
@@ -20,12 +20,16 @@ package agents
 
 import (
 	"fmt"
+	"net"
+	"sync/atomic"
 
+	"github.com/cenkalti/rpc2"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/radigo"
+	"github.com/cgrates/rpcclient"
 )
 
 const (
@@ -52,8 +56,9 @@ func NewRadiusAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 		}
 	}
 	dicts := radigo.NewDictionaries(dts)
-	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr}
 	secrets := radigo.NewSecrets(cgrCfg.RadiusAgentCfg().ClientSecrets)
+	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr,
+		dicts: dicts, secrets: secrets}
 	ra.rsAuth = radigo.NewServer(cgrCfg.RadiusAgentCfg().ListenNet,
 		cgrCfg.RadiusAgentCfg().ListenAuth, secrets, dicts,
 		map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
@@ -71,6 +76,9 @@ type RadiusAgent struct {
 	filterS *engine.FilterS
 	rsAuth  *radigo.Server
 	rsAcct  *radigo.Server
+	dicts   *radigo.Dictionaries
+	secrets *radigo.Secrets
+	daReqID uint32 // Identifier of the last Dynamic Authorization request
 }
 
 // handleAuth handles RADIUS Authorization request
@@ -222,7 +230,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1AuthorizeReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1AuthorizeEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1AuthorizeEvent,
 			authArgs, rply)
 		rply.SetMaxUsageNeeded(authArgs.GetMaxUsage)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -240,7 +248,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1InitSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1InitiateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1InitiateSession,
 			initArgs, rply)
 		rply.SetMaxUsageNeeded(initArgs.InitSession)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -253,7 +261,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1UpdateSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1UpdateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1UpdateSession,
 			updateArgs, rply)
 		rply.SetMaxUsageNeeded(updateArgs.UpdateSession)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -269,7 +277,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamsSlice(utils.MetaStats, utils.MetaIDs),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		var rply string
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1TerminateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1TerminateSession,
 			terminateArgs, &rply)
 		if err = agReq.setCGRReply(nil, err); err != nil {
 			return
@@ -291,7 +299,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1ProcessMessageReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessMessage, evArgs, rply)
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessMessage, evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
 		} else if evArgs.Debit {
@@ -308,7 +316,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			Paginator: cgrArgs,
 		}
 		rply := new(sessions.V1ProcessEventReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessEvent,
 			evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
@@ -326,10 +334,15 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			agReq.CGRReply.Set(utils.PathItems{{Field: utils.Error}}, utils.NewNMData(utils.RadauthFailed))
 		}
 	}
+	// cache the request data needed for building up the Dynamic Authorization requests
+	if ra.cgrCfg.RadiusAgentCfg().CoATemplate != utils.EmptyString ||
+		ra.cgrCfg.RadiusAgentCfg().DMRTemplate != utils.EmptyString {
+		ra.cacheRadMsg(reqType, req, agReq.Vars, cgrEv)
+	}
 	// separate request so we can capture the Terminate/Event also here
 	if reqProcessor.Flags.GetBool(utils.MetaCDRs) {
 		var rplyCDRs string
-		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessCDR,
+		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessCDR,
 			cgrEv, &rplyCDRs); err != nil {
 			agReq.CGRReply.Set(utils.PathItems{{Field: utils.Error}}, utils.NewNMData(err.Error()))
 		}
@@ -369,3 +382,224 @@ func (ra *RadiusAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 	err = <-errListen
 	return
 }
+
+// cacheRadMsg caches the request of the session so it can be used later to disconnect or reauthorize it
+func (ra *RadiusAgent) cacheRadMsg(reqType string, req *radigo.Packet,
+	vars utils.NavigableMap2, cgrEv *utils.CGREvent) {
+	originID, has := cgrEv.Event[utils.OriginID]
+	if !has {
+		return
+	}
+	switch reqType {
+	case utils.MetaNone, utils.MetaDryRun, utils.MetaCDRs:
+	case utils.MetaTerminate:
+		if err := engine.Cache.Remove(utils.CacheRadiusPackets, utils.IfaceAsString(originID),
+			true, utils.NonTransactional); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed removing the packet with OriginID: <%s> from Cache: %s",
+				utils.RadiusAgent, originID, err.Error()))
+		}
+	default:
+		host, _, err := net.SplitHostPort(req.RemoteAddr().String())
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed retrieving the client of packet with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, originID, err.Error()))
+			return
+		}
+		if err = engine.Cache.Set(utils.CacheRadiusPackets, utils.IfaceAsString(originID),
+			&radMsgData{req: req, vars: vars, host: host},
+			nil, true, utils.NonTransactional); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed caching the packet with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, originID, err.Error()))
+		}
+	}
+}
+
+// sendRadDAReq builds the Dynamic Authorization request out of template
+// and sends it to the client of the session, expecting an ACK back
+func (ra *RadiusAgent) sendRadDAReq(reqCode radigo.PacketCode, tplID, originID string,
+	vars utils.NavigableMap2) (err error) {
+	msg, has := engine.Cache.Get(utils.CacheRadiusPackets, originID)
+	if !has {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot retrieve packet from cache with OriginID: <%s>",
+				utils.RadiusAgent, originID))
+		return utils.ErrMandatoryIeMissing
+	}
+	rmd := msg.(*radMsgData)
+	reqVars := make(utils.NavigableMap2, len(rmd.vars)+len(vars))
+	for k, v := range rmd.vars {
+		reqVars[k] = v
+	}
+	for k, v := range vars {
+		reqVars[k] = v
+	}
+	aReq := NewAgentRequest(
+		newRADataProvider(rmd.req),
+		reqVars, nil, nil, nil, nil,
+		ra.cgrCfg.GeneralCfg().DefaultTenant,
+		ra.cgrCfg.GeneralCfg().DefaultTimezone, ra.filterS, nil, nil)
+	if err = aReq.SetFields(ra.cgrCfg.TemplatesCfg()[tplID]); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot send %s with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, radDAReqName(reqCode), originID, err.Error()))
+		return utils.ErrServerError
+	}
+	dict := ra.dicts.GetInstance(rmd.host)
+	if dict == nil {
+		dict = radigo.RFC2865Dictionary()
+	}
+	secret := ra.secrets.GetSecret(rmd.host)
+	pkt := radigo.NewPacket(reqCode, uint8(atomic.AddUint32(&ra.daReqID, 1)),
+		dict, radigo.NewCoder(), secret)
+	if err = radReplyAppendAttributes(pkt, aReq.diamreq); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot send %s with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, radDAReqName(reqCode), originID, err.Error()))
+		return utils.ErrServerError
+	}
+	daAddr, has := ra.cgrCfg.RadiusAgentCfg().ClientDaAddresses[rmd.host]
+	if !has {
+		daAddr = net.JoinHostPort(rmd.host, radDynAuthPort)
+	}
+	var rpl *radigo.Packet
+	if rpl, err = sendRadDAReq(pkt, daAddr, secret, dict,
+		ra.cgrCfg.GeneralCfg().ReplyTimeout); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed sending %s with OriginID: <%s> to <%s>, err: %s",
+				utils.RadiusAgent, radDAReqName(reqCode), originID, daAddr, err.Error()))
+		return
+	}
+	switch rpl.Code {
+	case reqCode + 1: // ACK
+		return
+	case reqCode + 2: // NAK
+		if errCause, has := radDAErrorCause(rpl); has {
+			return fmt.Errorf("%s rejected with Error-Cause: <%d>", radDAReqName(reqCode), errCause)
+		}
+		return fmt.Errorf("%s rejected", radDAReqName(reqCode))
+	default:
+		return fmt.Errorf("unexpected reply code: <%d>", rpl.Code)
+	}
+}
+
+// radDAReqName returns the name of the Dynamic Authorization request for logging
+func radDAReqName(reqCode radigo.PacketCode) string {
+	if reqCode == radCoARequest {
+		return "CoA-Request"
+	}
+	return "Disconnect-Request"
+}
+
+// Call implements rpcclient.ClientConnector interface
+func (ra *RadiusAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(ra, serviceMethod, args, reply)
+}
+
+// V1DisconnectSession is part of the sessions.BiRPClient
+// sends a Disconnect-Request to the client of the session
+func (ra *RadiusAgent) V1DisconnectSession(args utils.AttrDisconnectSession, reply *string) (err error) {
+	if ra.cgrCfg.RadiusAgentCfg().DMRTemplate == utils.EmptyString {
+		return utils.ErrNotImplemented
+	}
+	ssID, has := args.EventStart[utils.OriginID]
+	if !has {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot disconnect session, missing OriginID in event: %s",
+				utils.RadiusAgent, utils.ToJSON(args.EventStart)))
+		return utils.ErrMandatoryIeMissing
+	}
+	if err = ra.sendRadDAReq(radDisconnectRequest, ra.cgrCfg.RadiusAgentCfg().DMRTemplate,
+		utils.IfaceAsString(ssID),
+		utils.NavigableMap2{utils.DisconnectCause: utils.NewNMData(args.Reason)}); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetActiveSessionIDs is part of the sessions.BiRPClient
+func (ra *RadiusAgent) V1GetActiveSessionIDs(ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return utils.ErrNotImplemented
+}
+
+// V1ReAuthorize sends a CoA-Request to the client of the session
+func (ra *RadiusAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	if ra.cgrCfg.RadiusAgentCfg().CoATemplate == utils.EmptyString {
+		return utils.ErrNotImplemented
+	}
+	if originID == utils.EmptyString {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot send CoA-Request, missing session ID",
+				utils.RadiusAgent))
+		return utils.ErrMandatoryIeMissing
+	}
+	if err = ra.sendRadDAReq(radCoARequest, ra.cgrCfg.RadiusAgentCfg().CoATemplate,
+		originID, nil); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
+func (ra *RadiusAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.BiRPCCall(ra, clnt, serviceMethod, args, reply)
+}
+
+// BiRPCv1DisconnectSession is internal method to disconnect session in radius
+func (ra *RadiusAgent) BiRPCv1DisconnectSession(clnt rpcclient.ClientConnector, args utils.AttrDisconnectSession, reply *string) error {
+	return ra.V1DisconnectSession(args, reply)
+}
+
+// BiRPCv1GetActiveSessionIDs is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1GetActiveSessionIDs(clnt rpcclient.ClientConnector, ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return ra.V1GetActiveSessionIDs(ignParam, sessionIDs)
+}
+
+// BiRPCv1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector, originID string, reply *string) (err error) {
+	return ra.V1ReAuthorize(originID, reply)
+}
+
+// BiRPCv1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1DisconnectPeer(clnt rpcclient.ClientConnector, args *utils.DPRArgs, reply *string) (err error) {
+	return ra.V1DisconnectPeer(args, reply)
+}
+
+// BiRPCv1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, args map[string]interface{}, reply *string) (err error) {
+	return ra.V1WarnDisconnect(args, reply)
+}
+
+// Handlers is used to implement the rpcclient.BiRPCConector interface
+func (ra *RadiusAgent) Handlers() map[string]interface{} {
+	return map[string]interface{}{
+		utils.SessionSv1DisconnectSession: func(clnt *rpc2.Client, args utils.AttrDisconnectSession, rply *string) error {
+			return ra.BiRPCv1DisconnectSession(clnt, args, rply)
+		},
+		utils.SessionSv1GetActiveSessionIDs: func(clnt *rpc2.Client, args string, rply *[]*sessions.SessionID) error {
+			return ra.BiRPCv1GetActiveSessionIDs(clnt, args, rply)
+		},
+		utils.SessionSv1ReAuthorize: func(clnt *rpc2.Client, args string, rply *string) (err error) {
+			return ra.BiRPCv1ReAuthorize(clnt, args, rply)
+		},
+		utils.SessionSv1DisconnectPeer: func(clnt *rpc2.Client, args *utils.DPRArgs, rply *string) (err error) {
+			return ra.BiRPCv1DisconnectPeer(clnt, args, rply)
+		},
+		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
+			return ra.BiRPCv1WarnDisconnect(clnt, args, rply)
+		},
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"crypto/md5"
+	"net"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+)
+
+func TestRAsSessionSClientIface(t *testing.T) {
+	_ = sessions.BiRPClient(new(RadiusAgent))
+}
+
+// testRadDAServer mocks the Dynamic Authorization server of a NAS,
+// replying to each request with the packet built by rplFunc
+func testRadDAServer(t *testing.T, secret string,
+	rplFunc func(req *radigo.Packet) *radigo.Packet) (addr string, reqs chan *radigo.Packet, stop func()) {
+	conn, err := net.ListenPacket(utils.UDP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs = make(chan *radigo.Packet, 1)
+	go func() {
+		var b [4096]byte
+		for {
+			n, rAddr, err := conn.ReadFrom(b[:])
+			if err != nil {
+				return
+			}
+			if !radDAIsAuthentic(b[:n], secret, [16]byte{}) {
+				t.Errorf("invalid request authenticator")
+				continue
+			}
+			req := radigo.NewPacket(0, 0, dictRad, coder, secret)
+			if err = req.Decode(b[:n]); err != nil {
+				t.Error(err)
+				continue
+			}
+			req.SetAVPValues()
+			reqs <- req
+			rpl := rplFunc(req)
+			var rb [4096]byte
+			rn, err := rpl.Encode(rb[:])
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+			copy(rb[4:20], req.Authenticator[:])
+			hash := md5.New()
+			hash.Write(rb[:rn])
+			hash.Write([]byte(secret))
+			copy(rb[4:20], hash.Sum(nil))
+			conn.WriteTo(rb[:rn], rAddr)
+		}
+	}()
+	return conn.LocalAddr().String(), reqs, func() { conn.Close() }
+}
+
+func TestRadiusAgentDynamicAuthorization(t *testing.T) {
+	secret := "CGRateS.org"
+	var nak bool
+	daAddr, reqs, stop := testRadDAServer(t, secret, func(req *radigo.Packet) *radigo.Packet {
+		rpl := radigo.NewPacket(req.Code+1, req.Identifier, dictRad, coder, secret)
+		if nak {
+			rpl.Code = req.Code + 2
+			rpl.AVPs = append(rpl.AVPs, &radigo.AVP{Number: radErrorCauseAVP,
+				RawValue: []byte{0x00, 0x00, 0x01, 0xf7}}) // Session Context Not Found
+		}
+		return rpl
+	})
+	defer stop()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RadiusAgentCfg().ClientDaAddresses = map[string]string{"127.0.0.1": daAddr}
+	cfg.RadiusAgentCfg().DMRTemplate = "*dmr"
+	cfg.RadiusAgentCfg().CoATemplate = "*coa"
+	cfg.TemplatesCfg()["*dmr"] = []*config.FCTemplate{
+		{Tag: "User-Name", Path: "*radDAReq.User-Name", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.User-Name", utils.InfieldSep)},
+		{Tag: "Reply-Message", Path: "*radDAReq.Reply-Message", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*vars.DisconnectCause", utils.InfieldSep)},
+	}
+	cfg.TemplatesCfg()["*coa"] = []*config.FCTemplate{
+		{Tag: "User-Name", Path: "*radDAReq.User-Name", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.User-Name", utils.InfieldSep)},
+		{Tag: "Filter-Id", Path: "*radDAReq.Filter-Id", Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("10Mbps", utils.InfieldSep)},
+	}
+	for _, tpl := range cfg.TemplatesCfg() {
+		for _, fld := range tpl {
+			fld.ComputePath()
+		}
+	}
+	filterS := engine.NewFilterS(cfg, nil, nil)
+	ra := &RadiusAgent{cgrCfg: cfg, filterS: filterS,
+		dicts:   radigo.NewDictionaries(map[string]*radigo.Dictionary{utils.MetaDefault: dictRad}),
+		secrets: radigo.NewSecrets(map[string]string{utils.MetaDefault: secret})}
+
+	req := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, secret)
+	if err := req.AddAVPWithName("User-Name", "1001", utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	engine.Cache.Set(utils.CacheRadiusPackets, "session1",
+		&radMsgData{req: req, vars: utils.NavigableMap2{}, host: "127.0.0.1"},
+		nil, true, utils.NonTransactional)
+	defer engine.Cache.Remove(utils.CacheRadiusPackets, "session1", true, utils.NonTransactional)
+
+	var reply string
+	if err := ra.V1DisconnectSession(utils.AttrDisconnectSession{
+		EventStart: map[string]interface{}{utils.OriginID: "session1"},
+		Reason:     utils.ErrInsufficientCredit.Error(),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %s", reply)
+	}
+	dmr := <-reqs
+	if dmr.Code != radDisconnectRequest {
+		t.Errorf("Expected code %d, received %d", radDisconnectRequest, dmr.Code)
+	}
+	if avps := dmr.AttributesWithName("User-Name", utils.EmptyString); len(avps) != 1 ||
+		avps[0].GetStringValue() != "1001" {
+		t.Errorf("Unexpected User-Name: %s", utils.ToJSON(avps))
+	}
+	if avps := dmr.AttributesWithName("Reply-Message", utils.EmptyString); len(avps) != 1 ||
+		avps[0].GetStringValue() != utils.ErrInsufficientCredit.Error() {
+		t.Errorf("Unexpected Reply-Message: %s", utils.ToJSON(avps))
+	}
+
+	reply = utils.EmptyString
+	if err := ra.V1ReAuthorize("session1", &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %s", reply)
+	}
+	coa := <-reqs
+	if coa.Code != radCoARequest {
+		t.Errorf("Expected code %d, received %d", radCoARequest, coa.Code)
+	}
+	if coa.Identifier == dmr.Identifier {
+		t.Errorf("Expected different identifiers for the requests")
+	}
+	if avps := coa.AttributesWithName("Filter-Id", utils.EmptyString); len(avps) != 1 ||
+		avps[0].GetStringValue() != "10Mbps" {
+		t.Errorf("Unexpected Filter-Id: %s", utils.ToJSON(avps))
+	}
+
+	nak = true
+	expErr := "CoA-Request rejected with Error-Cause: <503>"
+	if err := ra.V1ReAuthorize("session1", &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received %v", expErr, err)
+	}
+	<-reqs
+	if err := ra.V1ReAuthorize("session2", &reply); err != utils.ErrMandatoryIeMissing {
+		t.Errorf("Expected error %s, received %v", utils.ErrMandatoryIeMissing, err)
+	}
+	cfg.RadiusAgentCfg().DMRTemplate = utils.EmptyString
+	if err := ra.V1DisconnectSession(utils.AttrDisconnectSession{
+		EventStart: map[string]interface{}{utils.OriginID: "session1"},
+	}, &reply); err != utils.ErrNotImplemented {
+		t.Errorf("Expected error %s, received %v", utils.ErrNotImplemented, err)
+	}
+}
@@ -49,6 +49,7 @@ type AnalyzerService struct {
 	cfg *config.CGRConfig
 
 	filterS *engine.FilterS
+	connMgr *engine.ConnManager
 }
 
 // SetFilterS will set the filterS used in APIs
@@ -57,6 +58,12 @@ func (aS *AnalyzerService) SetFilterS(fS *engine.FilterS) {
 	aS.filterS = fS
 }
 
+// SetConnManager will set the connManager used to replay the API calls
+// this function is called before the API is registerd
+func (aS *AnalyzerService) SetConnManager(cM *engine.ConnManager) {
+	aS.connMgr = cM
+}
+
 func (aS *AnalyzerService) initDB() (err error) {
 	dbPath := path.Join(aS.cfg.AnalyzerSCfg().DBPath, utils.AnzDBDir)
 	if _, err = os.Stat(dbPath); err == nil {
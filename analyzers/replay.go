@@ -0,0 +1,218 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package analyzers
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// ReplayArgs selects the recorded API calls and the engine they are replayed on
+type ReplayArgs struct {
+	QueryArgs
+	// the rpc_conns of the target engine
+	ConnIDs []string
+	// the reply paths not compared(e.g. *rep.CGRID for the generated values)
+	IgnorePaths []string
+}
+
+// ReplayReply is the result of the replay
+type ReplayReply struct {
+	Replayed int
+	Matched  int
+	Diffs    []*ReplayDiff // the calls with a different outcome than the recorded one
+}
+
+// ReplayDiff describes a replayed API call that did not match the recorded one
+type ReplayDiff struct {
+	RequestID        uint64
+	RequestMethod    string
+	RequestStartTime time.Time
+	RecordedReply    interface{}
+	Reply            interface{}
+	RecordedError    interface{}
+	ReplyError       interface{}
+	Paths            []string // the reply paths with different values
+	Error            string   // the error that prevented replaying the call or comparing the reply
+}
+
+// replayConnector sends the replayed calls through the ConnManager
+type replayConnector struct {
+	connMgr *engine.ConnManager
+	connIDs []string
+}
+
+func (c *replayConnector) Call(serviceMethod string, args, reply interface{}) error {
+	return c.connMgr.Call(c.connIDs, nil, serviceMethod, args, reply)
+}
+
+// V1Replay sends again the API calls matching the query to the target engine
+// and compares the replies and errors with the recorded ones
+// the calls are replayed in the order they were received
+func (aS *AnalyzerService) V1Replay(args *ReplayArgs, reply *ReplayReply) (err error) {
+	if len(args.ConnIDs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.ConnIDs)
+	}
+	var hits []map[string]interface{}
+	if err = aS.V1StringQuery(&args.QueryArgs, &hits); err != nil {
+		return
+	}
+	aS.replay(&replayConnector{connMgr: aS.connMgr, connIDs: args.ConnIDs},
+		hits, utils.NewStringSet(args.IgnorePaths), reply)
+	return
+}
+
+// replay calls the recorded requests on the connection
+// the calls that can not be replayed or compared are reported in the Diffs with their Error
+func (aS *AnalyzerService) replay(conn rpcclient.ClientConnector, hits []map[string]interface{},
+	ignorePaths utils.StringSet, reply *ReplayReply) {
+	startTimes := make([]time.Time, len(hits))
+	for i, hit := range hits {
+		startTimes[i], _ = utils.IfaceAsTime(hit[utils.RequestStartTime], aS.cfg.GeneralCfg().DefaultTimezone)
+	}
+	idxs := make([]int, len(hits))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sort.SliceStable(idxs, func(i, j int) bool {
+		return startTimes[idxs[i]].Before(startTimes[idxs[j]])
+	})
+	rply := ReplayReply{Diffs: make([]*ReplayDiff, 0)}
+	for _, idx := range idxs {
+		hit := hits[idx]
+		method := utils.IfaceAsString(hit[utils.RequestMethod])
+		dff := &ReplayDiff{
+			RequestMethod:    method,
+			RequestStartTime: startTimes[idx],
+			RecordedError:    hit[utils.ReplyError],
+		}
+		if id, errID := utils.IfaceAsTInt64(hit[utils.RequestID]); errID == nil {
+			dff.RequestID = uint64(id)
+		}
+		args, out, err := newReplayParams(method, hit[utils.RequestParams])
+		if err != nil {
+			dff.Error = err.Error()
+			rply.Diffs = append(rply.Diffs, dff)
+			continue
+		}
+		errCall := conn.Call(method, args, out)
+		if rpcclient.IsNetworkError(errCall) {
+			dff.Error = errCall.Error()
+			rply.Diffs = append(rply.Diffs, dff)
+			continue
+		}
+		rply.Replayed++
+		if dff.RecordedReply, err = unmarshalJSON(asRawJSON(hit[utils.Reply])); err != nil {
+			dff.Error = err.Error()
+			rply.Diffs = append(rply.Diffs, dff)
+			continue
+		}
+		if errCall != nil {
+			dff.ReplyError = errCall.Error()
+		} else if dff.Reply, err = unmarshalJSON(json.RawMessage(utils.ToJSON(out))); err != nil {
+			dff.Error = err.Error()
+			rply.Diffs = append(rply.Diffs, dff)
+			continue
+		}
+		switch {
+		case !reflect.DeepEqual(dff.RecordedError, dff.ReplyError):
+		case dff.ReplyError != nil: // same error, the replies are not relevant
+			rply.Matched++
+			continue
+		default:
+			if dff.Paths = diffPaths(utils.MetaRep, dff.RecordedReply, dff.Reply, ignorePaths); len(dff.Paths) == 0 {
+				rply.Matched++
+				continue
+			}
+		}
+		rply.Diffs = append(rply.Diffs, dff)
+	}
+	*reply = rply
+}
+
+// newReplayParams builds the arguments and the reply of the method out of the recorded request
+// the registered methods use their own types so any codec can be used
+// otherwise the raw JSON is sent requiring a JSON connection
+func newReplayParams(method string, params interface{}) (args, reply interface{}, err error) {
+	raw := asRawJSON(params)
+	rpcParams, err := utils.GetRpcParams(method)
+	if err != nil {
+		return raw, new(json.RawMessage), nil
+	}
+	in := reflect.New(reflect.TypeOf(rpcParams.InParam).Elem())
+	if err = json.Unmarshal(raw, in.Interface()); err != nil {
+		return
+	}
+	return in.Elem().Interface(),
+		reflect.New(reflect.TypeOf(rpcParams.OutParam).Elem()).Interface(), nil
+}
+
+// asRawJSON returns the JSON of the recorded field
+func asRawJSON(fld interface{}) json.RawMessage {
+	if raw, canCast := fld.(json.RawMessage); canCast {
+		return raw
+	}
+	return json.RawMessage(utils.IfaceAsString(fld))
+}
+
+// diffPaths returns the paths of the values that differ between the recorded and the replayed reply
+func diffPaths(path string, recorded, replayed interface{}, ignorePaths utils.StringSet) (paths []string) {
+	if ignorePaths.Has(path) {
+		return
+	}
+	switch rec := recorded.(type) {
+	case map[string]interface{}:
+		rep, canCast := replayed.(map[string]interface{})
+		if !canCast {
+			return []string{path}
+		}
+		keys := make(utils.StringSet)
+		for k := range rec {
+			keys.Add(k)
+		}
+		for k := range rep {
+			keys.Add(k)
+		}
+		for _, k := range keys.AsOrderedSlice() {
+			paths = append(paths, diffPaths(path+utils.NestingSep+k, rec[k], rep[k], ignorePaths)...)
+		}
+		return
+	case []interface{}:
+		rep, canCast := replayed.([]interface{})
+		if !canCast || len(rec) != len(rep) {
+			return []string{path}
+		}
+		for i := range rec {
+			paths = append(paths, diffPaths(path+utils.IdxStart+strconv.Itoa(i)+utils.IdxEnd,
+				rec[i], rep[i], ignorePaths)...)
+		}
+		return
+	}
+	if !reflect.DeepEqual(recorded, replayed) {
+		paths = []string{path}
+	}
+	return
+}
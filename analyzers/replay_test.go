@@ -0,0 +1,309 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package analyzers
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+type replayConnMock struct {
+	calls   []string
+	replies map[string]string
+	errs    map[string]error
+}
+
+func (c *replayConnMock) Call(serviceMethod string, args, reply interface{}) error {
+	c.calls = append(c.calls, serviceMethod)
+	if err, has := c.errs[serviceMethod]; has {
+		return err
+	}
+	rpl, has := c.replies[serviceMethod]
+	if !has {
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	*reply.(*json.RawMessage) = json.RawMessage(rpl)
+	return nil
+}
+
+func TestAnalyzerSReplay(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AnalyzerSCfg().DBPath = "/tmp/analyzers"
+	if err := os.RemoveAll(cfg.AnalyzerSCfg().DBPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(cfg.AnalyzerSCfg().DBPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	anz, err := NewAnalyzerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cfg.AnalyzerSCfg().DBPath)
+	defer anz.db.Close()
+	t1 := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, call := range []struct {
+		id     uint64
+		method string
+		reply  interface{}
+		err    interface{}
+		sTime  time.Time
+	}{
+		{3, utils.ThresholdSv1ProcessEvent, []string{"TH1"}, nil, t1.Add(3 * time.Second)},
+		{0, utils.CoreSv1Ping, utils.Pong, nil, t1},
+		{2, utils.ResourceSv1AuthorizeResources, nil, utils.ErrNotFound, t1.Add(2 * time.Second)},
+		{1, utils.AttributeSv1ProcessEvent, map[string]interface{}{
+			utils.ID: "ATTR1",
+			utils.Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				utils.AnswerTime:   "2021-01-01T10:00:01Z",
+			},
+		}, nil, t1.Add(time.Second)},
+	} {
+		if err = anz.logTrafic(call.id, call.method,
+			&utils.CGREvent{Tenant: "cgrates.org", ID: "EV1"}, call.reply, call.err,
+			utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012",
+			call.sTime, call.sTime.Add(time.Millisecond)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conn := &replayConnMock{
+		replies: map[string]string{
+			utils.CoreSv1Ping:              `"Pong"`,
+			utils.AttributeSv1ProcessEvent: `{"ID":"ATTR2","Event":{"Account":"1001","AnswerTime":"2021-02-01T10:00:01Z"}}`,
+		},
+		errs: map[string]error{
+			utils.ResourceSv1AuthorizeResources: utils.ErrNotFound,
+			utils.ThresholdSv1ProcessEvent:      utils.ErrServerError,
+		},
+	}
+	var hits []map[string]interface{}
+	if err = anz.V1StringQuery(&QueryArgs{HeaderFilters: "RequestEncoding:*json"}, &hits); err != nil {
+		t.Fatal(err)
+	}
+	var rply ReplayReply
+	anz.replay(conn, hits, utils.NewStringSet([]string{"*rep.Event.AnswerTime"}), &rply)
+	expCalls := []string{utils.CoreSv1Ping, utils.AttributeSv1ProcessEvent,
+		utils.ResourceSv1AuthorizeResources, utils.ThresholdSv1ProcessEvent}
+	if !reflect.DeepEqual(expCalls, conn.calls) {
+		t.Errorf("Expected calls in the recorded order: %q, received: %q", expCalls, conn.calls)
+	}
+	if rply.Replayed != 4 || rply.Matched != 2 {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(rply))
+	}
+	exp := []*ReplayDiff{
+		{
+			RequestID:        1,
+			RequestMethod:    utils.AttributeSv1ProcessEvent,
+			RequestStartTime: t1.Add(time.Second),
+			RecordedReply: map[string]interface{}{
+				utils.ID: "ATTR1",
+				utils.Event: map[string]interface{}{
+					utils.AccountField: "1001",
+					utils.AnswerTime:   "2021-01-01T10:00:01Z",
+				},
+			},
+			Reply: map[string]interface{}{
+				utils.ID: "ATTR2",
+				utils.Event: map[string]interface{}{
+					utils.AccountField: "1001",
+					utils.AnswerTime:   "2021-02-01T10:00:01Z",
+				},
+			},
+			Paths: []string{"*rep.ID"},
+		},
+		{
+			RequestID:        3,
+			RequestMethod:    utils.ThresholdSv1ProcessEvent,
+			RequestStartTime: t1.Add(3 * time.Second),
+			RecordedReply:    []interface{}{"TH1"},
+			ReplyError:       utils.ErrServerError.Error(),
+		},
+	}
+	if len(rply.Diffs) != len(exp) {
+		t.Fatalf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply.Diffs))
+	}
+	for i := range exp {
+		if !exp[i].RequestStartTime.Equal(rply.Diffs[i].RequestStartTime) {
+			t.Errorf("Expected: %v, received: %v", exp[i].RequestStartTime, rply.Diffs[i].RequestStartTime)
+		}
+		rply.Diffs[i].RequestStartTime = exp[i].RequestStartTime
+		if !reflect.DeepEqual(exp[i], rply.Diffs[i]) {
+			t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp[i]), utils.ToJSON(rply.Diffs[i]))
+		}
+	}
+
+	// the failed calls are reported and the replay continues
+	conn.calls = nil
+	conn.errs[utils.CoreSv1Ping] = rpcclient.ErrDisconnected
+	anz.replay(conn, hits, utils.NewStringSet([]string{"*rep.Event.AnswerTime"}), &rply)
+	if !reflect.DeepEqual(expCalls, conn.calls) {
+		t.Errorf("Expected calls in the recorded order: %q, received: %q", expCalls, conn.calls)
+	}
+	if rply.Replayed != 3 || rply.Matched != 1 || len(rply.Diffs) != 3 {
+		t.Fatalf("Unexpected reply: %s", utils.ToJSON(rply))
+	}
+	if rply.Diffs[0].RequestMethod != utils.CoreSv1Ping ||
+		rply.Diffs[0].Error != rpcclient.ErrDisconnected.Error() {
+		t.Errorf("Unexpected diff: %s", utils.ToJSON(rply.Diffs[0]))
+	}
+
+	// the requests that can not be decoded are reported and the replay continues
+	conn.calls = nil
+	delete(conn.errs, utils.CoreSv1Ping)
+	utils.RegisterRpcParams(utils.EmptyString, new(replayTestSv1))
+	hits = append([]map[string]interface{}{{
+		utils.RequestID:        4.,
+		utils.RequestMethod:    "replayTestSv1.Echo",
+		utils.RequestParams:    `{"ID":1}`,
+		utils.RequestStartTime: t1.Add(-time.Second).Format(time.RFC3339),
+	}}, hits...)
+	anz.replay(conn, hits, utils.NewStringSet([]string{"*rep.Event.AnswerTime"}), &rply)
+	if !reflect.DeepEqual(expCalls, conn.calls) {
+		t.Errorf("Expected calls in the recorded order: %q, received: %q", expCalls, conn.calls)
+	}
+	if rply.Replayed != 4 || rply.Matched != 2 || len(rply.Diffs) != 3 {
+		t.Fatalf("Unexpected reply: %s", utils.ToJSON(rply))
+	}
+	if rply.Diffs[0].RequestID != 4 || rply.Diffs[0].Error == utils.EmptyString {
+		t.Errorf("Unexpected diff: %s", utils.ToJSON(rply.Diffs[0]))
+	}
+
+	if err = anz.V1Replay(new(ReplayArgs), &rply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.ConnIDs).Error() {
+		t.Errorf("Expected %v, received: %v", utils.NewErrMandatoryIeMissing(utils.ConnIDs), err)
+	}
+}
+
+func TestAnalyzerSV1ReplayConnManager(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AnalyzerSCfg().DBPath = "/tmp/analyzers"
+	if err := os.RemoveAll(cfg.AnalyzerSCfg().DBPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(cfg.AnalyzerSCfg().DBPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	anz, err := NewAnalyzerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cfg.AnalyzerSCfg().DBPath)
+	defer anz.db.Close()
+	if err = anz.logTrafic(0, utils.CoreSv1Ping, &utils.CGREvent{Tenant: "cgrates.org", ID: "EV1"},
+		utils.Pong, nil, utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012",
+		time.Now(), time.Now().Add(time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	conn := &replayConnMock{
+		replies: map[string]string{
+			utils.CoreSv1Ping: `"Pong"`,
+		},
+	}
+	connChan := make(chan rpcclient.ClientConnector, 1)
+	connChan <- conn
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	defer engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	anz.SetConnManager(engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		"replayConn": connChan,
+	}))
+	var rply ReplayReply
+	if err = anz.V1Replay(&ReplayArgs{
+		QueryArgs: QueryArgs{HeaderFilters: "RequestEncoding:*json"},
+		ConnIDs:   []string{"replayConn"},
+	}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if rply.Replayed != 1 || rply.Matched != 1 || len(rply.Diffs) != 0 {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(rply))
+	}
+}
+
+type replayTestSv1 struct{}
+
+func (replayTestSv1) Echo(args *utils.CGREvent, reply *string) error {
+	*reply = args.ID
+	return nil
+}
+
+func TestNewReplayParams(t *testing.T) {
+	utils.RegisterRpcParams(utils.EmptyString, new(replayTestSv1))
+	args, reply, err := newReplayParams("replayTestSv1.Echo", json.RawMessage(`{"Tenant":"cgrates.org","ID":"EV1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (&utils.CGREvent{Tenant: "cgrates.org", ID: "EV1"}); !reflect.DeepEqual(exp, args) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(args))
+	}
+	if _, canCast := reply.(*string); !canCast {
+		t.Errorf("Expected *string reply, received: %T", reply)
+	}
+	// the unknown methods are sent as raw JSON
+	if args, reply, err = newReplayParams("UnknownSv1.Echo", `{"ID":"EV1"}`); err != nil {
+		t.Fatal(err)
+	}
+	if exp := json.RawMessage(`{"ID":"EV1"}`); !reflect.DeepEqual(exp, args) {
+		t.Errorf("Expected: %s, received: %v", exp, args)
+	}
+	if _, canCast := reply.(*json.RawMessage); !canCast {
+		t.Errorf("Expected *json.RawMessage reply, received: %T", reply)
+	}
+	if _, _, err = newReplayParams("replayTestSv1.Echo", `{"ID":1}`); err == nil {
+		t.Error("Expected unmarshal error")
+	}
+}
+
+func TestDiffPaths(t *testing.T) {
+	rec := map[string]interface{}{
+		"ID":     "RU1",
+		"Units":  1.,
+		"Hosts":  []interface{}{"H1", "H2"},
+		"Usages": []interface{}{map[string]interface{}{"ID": "U1"}},
+	}
+	rep := map[string]interface{}{
+		"ID":     "RU1",
+		"Units":  2.,
+		"Hosts":  []interface{}{"H1"},
+		"Usages": []interface{}{map[string]interface{}{"ID": "U2"}},
+		"New":    true,
+	}
+	exp := []string{"*rep.Hosts", "*rep.New", "*rep.Units", "*rep.Usages[0].ID"}
+	if rcv := diffPaths(utils.MetaRep, rec, rep, nil); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %q, received: %q", exp, rcv)
+	}
+	exp = []string{"*rep.Hosts", "*rep.New"}
+	if rcv := diffPaths(utils.MetaRep, rec, rep,
+		utils.NewStringSet([]string{"*rep.Units", "*rep.Usages"})); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %q, received: %q", exp, rcv)
+	}
+	if rcv := diffPaths(utils.MetaRep, "OK", rec, nil); !reflect.DeepEqual([]string{utils.MetaRep}, rcv) {
+		t.Errorf("Expected: %q, received: %q", []string{utils.MetaRep}, rcv)
+	}
+	if rcv := diffPaths(utils.MetaRep, rec, rec, nil); len(rcv) != 0 {
+		t.Errorf("Expected no differences, received: %q", rcv)
+	}
+}
@@ -104,7 +104,7 @@ func (apierSv1 *APIerSv1) SetAccountProfile(extAp *APIAccountProfileWithCache, r
 	if err != nil {
 		return err
 	}
-	if err := apierSv1.DataManager.SetAccountProfile(ap, true); err != nil {
+	if err := apierSv1.DataManager.SetProvisionedAccountProfile(ap, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheAccountProfiles and store it in database
@@ -199,3 +199,33 @@ func (aSv1 *AccountSv1) ActionRemoveBalance(args *utils.ArgsActRemoveBalances,
 	eEc *string) (err error) {
 	return aSv1.aS.V1ActionRemoveBalance(args, eEc)
 }
+
+// ActionResetAccount restores the balances of an account to their initial units
+func (aSv1 *AccountSv1) ActionResetAccount(args *utils.ArgsActResetAccount,
+	eEc *string) (err error) {
+	return aSv1.aS.V1ActionResetAccount(args, eEc)
+}
+
+// ActionRolloverBalances renews the balances of an account applying the rollover options
+func (aSv1 *AccountSv1) ActionRolloverBalances(args *utils.ArgsActRolloverBalances,
+	eEc *string) (err error) {
+	return aSv1.aS.V1ActionRolloverBalances(args, eEc)
+}
+
+// ReserveAbstracts holds the abstract units for the event until commit or release
+func (aSv1 *AccountSv1) ReserveAbstracts(args *utils.ArgsAccountsForEvent,
+	rsv *utils.AccountsReservation) (err error) {
+	return aSv1.aS.V1ReserveAbstracts(args, rsv)
+}
+
+// CommitReservation debits the used units out of a reservation
+func (aSv1 *AccountSv1) CommitReservation(args *utils.ArgsAccountsReservation,
+	eEc *utils.ExtEventCharges) (err error) {
+	return aSv1.aS.V1CommitReservation(args, eEc)
+}
+
+// ReleaseReservation gives back the units held by a reservation
+func (aSv1 *AccountSv1) ReleaseReservation(args *utils.ArgsAccountsReservation,
+	rply *string) (err error) {
+	return aSv1.aS.V1ReleaseReservation(args, rply)
+}
@@ -173,3 +173,8 @@ func (aSv1 *ActionSv1) ScheduleActions(args *utils.ArgActionSv1ScheduleActions,
 func (aSv1 *ActionSv1) ExecuteActions(args *utils.ArgActionSv1ScheduleActions, rpl *string) error {
 	return aSv1.aS.V1ExecuteActions(args, rpl)
 }
+
+// DryRunActions returns the changes the matching action profiles would make without executing them
+func (aSv1 *ActionSv1) DryRunActions(args *utils.ArgActionSv1ScheduleActions, rpl *[]*utils.ActionDiff) error {
+	return aSv1.aS.V1DryRunActions(args, rpl)
+}
@@ -49,3 +49,9 @@ func (aSv1 *AnalyzerSv1) Ping(ign *utils.CGREvent, reply *string) error {
 func (aSv1 *AnalyzerSv1) StringQuery(search *analyzers.QueryArgs, reply *[]map[string]interface{}) error {
 	return aSv1.aS.V1StringQuery(search, reply)
 }
+
+// Replay sends the recorded API calls matching the query to the target engine
+// returning the differences from the recorded replies
+func (aSv1 *AnalyzerSv1) Replay(args *analyzers.ReplayArgs, reply *analyzers.ReplayReply) error {
+	return aSv1.aS.V1Replay(args, reply)
+}
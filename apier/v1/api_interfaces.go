@@ -43,6 +43,7 @@ type StatSv1Interface interface {
 	GetStatQueuesForEvent(args *engine.StatsArgsProcessEvent, reply *[]string) (err error)
 	GetQueueStringMetrics(args *utils.TenantIDWithOpts, reply *map[string]string) (err error)
 	GetQueueFloatMetrics(args *utils.TenantIDWithOpts, reply *map[string]float64) (err error)
+	GetQueueBucketMetrics(args *utils.TenantIDWithOpts, reply *[]*engine.StatBucketMetrics) (err error)
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
@@ -150,6 +151,9 @@ type CDRsV1Interface interface {
 	ProcessEvent(arg *engine.ArgV1ProcessEvent, reply *string) error
 	ProcessExternalCDR(cdr *engine.ExternalCDRWithOpts, reply *string) error
 	RateCDRs(arg *engine.ArgRateCDRs, reply *string) error
+	StartRerate(args *engine.ArgStartRerate, reply *string) error
+	RerateStatus(args *utils.TenantIDWithOpts, reply *engine.RerateJob) error
+	CancelRerate(args *utils.TenantIDWithOpts, reply *string) error
 	StoreSessionCost(attr *engine.AttrCDRSStoreSMCost, reply *string) error
 	GetCDRsCount(args *utils.RPCCDRsFilterWithOpts, reply *int64) error
 	GetCDRs(args *utils.RPCCDRsFilterWithOpts, reply *[]*engine.CDR) error
@@ -185,6 +189,8 @@ type CoreSv1Interface interface {
 type RateSv1Interface interface {
 	Ping(ign *utils.CGREvent, reply *string) error
 	CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.RateProfileCost) error
+	CostForEvents(args *utils.ArgsCostForEvents, rpCosts *engine.RateProfileCosts) error
+	CompareRateProfiles(args *utils.ArgsCostForEvents, rpTotals *[]*engine.RateProfileTotal) error
 }
 
 type RateProfileSv1Interface interface {
@@ -284,6 +290,7 @@ type ReplicatorSv1Interface interface {
 type ActionSv1Interface interface {
 	ScheduleActions(args *utils.ArgActionSv1ScheduleActions, rpl *string) error
 	ExecuteActions(args *utils.ArgActionSv1ScheduleActions, rpl *string) error
+	DryRunActions(args *utils.ArgActionSv1ScheduleActions, rpl *[]*utils.ActionDiff) error
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
@@ -296,4 +303,9 @@ type AccountSv1Interface interface {
 	MaxConcretes(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error)
 	DebitConcretes(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error)
 	ActionRemoveBalance(args *utils.ArgsActRemoveBalances, eEc *string) (err error)
+	ActionResetAccount(args *utils.ArgsActResetAccount, eEc *string) (err error)
+	ActionRolloverBalances(args *utils.ArgsActRolloverBalances, eEc *string) (err error)
+	ReserveAbstracts(args *utils.ArgsAccountsForEvent, rsv *utils.AccountsReservation) (err error)
+	CommitReservation(args *utils.ArgsAccountsReservation, eEc *utils.ExtEventCharges) (err error)
+	ReleaseReservation(args *utils.ArgsAccountsReservation, rply *string) (err error)
 }
@@ -109,6 +109,21 @@ func (cdrSv1 *CDRsV1) RateCDRs(arg *engine.ArgRateCDRs, reply *string) error {
 	return cdrSv1.CDRs.V1RateCDRs(arg, reply)
 }
 
+// StartRerate starts an asynchronous re-rating job, replying with the job ID
+func (cdrSv1 *CDRsV1) StartRerate(args *engine.ArgStartRerate, reply *string) error {
+	return cdrSv1.CDRs.V1StartRerate(args, reply)
+}
+
+// RerateStatus returns the progress of a re-rating job
+func (cdrSv1 *CDRsV1) RerateStatus(args *utils.TenantIDWithOpts, reply *engine.RerateJob) error {
+	return cdrSv1.CDRs.V1RerateStatus(args, reply)
+}
+
+// CancelRerate stops a running re-rating job
+func (cdrSv1 *CDRsV1) CancelRerate(args *utils.TenantIDWithOpts, reply *string) error {
+	return cdrSv1.CDRs.V1CancelRerate(args, reply)
+}
+
 // StoreSMCost will store
 func (cdrSv1 *CDRsV1) StoreSessionCost(attr *engine.AttrCDRSStoreSMCost, reply *string) error {
 	return cdrSv1.CDRs.V1StoreSessionCost(attr, reply)
@@ -285,6 +285,12 @@ func (dSts *DispatcherStatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithOpts
 	return dSts.dS.StatSv1GetQueueFloatMetrics(args, reply)
 }
 
+// GetQueueBucketMetrics implements StatSv1GetQueueBucketMetrics
+func (dSts *DispatcherStatSv1) GetQueueBucketMetrics(args *utils.TenantIDWithOpts,
+	reply *[]*engine.StatBucketMetrics) error {
+	return dSts.dS.StatSv1GetQueueBucketMetrics(args, reply)
+}
+
 func (dSts *DispatcherStatSv1) GetQueueIDs(args *utils.TenantWithOpts,
 	reply *[]string) error {
 	return dSts.dS.StatSv1GetQueueIDs(args, reply)
@@ -795,6 +801,18 @@ func (dS *DispatcherSCDRsV1) RateCDRs(args *engine.ArgRateCDRs, reply *string) e
 	return dS.dS.CDRsV1RateCDRs(args, reply)
 }
 
+func (dS *DispatcherSCDRsV1) StartRerate(args *engine.ArgStartRerate, reply *string) error {
+	return dS.dS.CDRsV1StartRerate(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) RerateStatus(args *utils.TenantIDWithOpts, reply *engine.RerateJob) error {
+	return dS.dS.CDRsV1RerateStatus(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) CancelRerate(args *utils.TenantIDWithOpts, reply *string) error {
+	return dS.dS.CDRsV1CancelRerate(args, reply)
+}
+
 func (dS *DispatcherSCDRsV1) ProcessExternalCDR(args *engine.ExternalCDRWithOpts, reply *string) error {
 	return dS.dS.CDRsV1ProcessExternalCDR(args, reply)
 }
@@ -1346,6 +1364,14 @@ func (dR *DispatcherRateSv1) CostForEvent(args *utils.ArgsCostForEvent, rpCost *
 	return dR.dR.RateSv1CostForEvent(args, rpCost)
 }
 
+func (dR *DispatcherRateSv1) CostForEvents(args *utils.ArgsCostForEvents, rpCosts *engine.RateProfileCosts) error {
+	return dR.dR.RateSv1CostForEvents(args, rpCosts)
+}
+
+func (dR *DispatcherRateSv1) CompareRateProfiles(args *utils.ArgsCostForEvents, rpTotals *[]*engine.RateProfileTotal) error {
+	return dR.dR.RateSv1CompareRateProfiles(args, rpTotals)
+}
+
 func NewDispatcherActionSv1(dps *dispatchers.DispatcherService) *DispatcherActionSv1 {
 	return &DispatcherActionSv1{dR: dps}
 }
@@ -1366,6 +1392,9 @@ func (dR *DispatcherActionSv1) ScheduleActions(args *utils.ArgActionSv1ScheduleA
 func (dR *DispatcherActionSv1) ExecuteActions(args *utils.ArgActionSv1ScheduleActions, rpl *string) error {
 	return dR.dR.ActionSv1ExecuteActions(args, rpl)
 }
+func (dR *DispatcherActionSv1) DryRunActions(args *utils.ArgActionSv1ScheduleActions, rpl *[]*utils.ActionDiff) error {
+	return dR.dR.ActionSv1DryRunActions(args, rpl)
+}
 
 func NewDispatcherAccountSv1(dps *dispatchers.DispatcherService) *DispatcherAccountSv1 {
 	return &DispatcherAccountSv1{dR: dps}
@@ -1408,3 +1437,23 @@ func (dR *DispatcherAccountSv1) ActionSetBalance(args *utils.ArgsActSetBalance,
 func (dR *DispatcherAccountSv1) ActionRemoveBalance(args *utils.ArgsActRemoveBalances, eEc *string) (err error) {
 	return dR.dR.AccountSv1ActionRemoveBalance(args, eEc)
 }
+
+func (dR *DispatcherAccountSv1) ActionResetAccount(args *utils.ArgsActResetAccount, eEc *string) (err error) {
+	return dR.dR.AccountSv1ActionResetAccount(args, eEc)
+}
+
+func (dR *DispatcherAccountSv1) ActionRolloverBalances(args *utils.ArgsActRolloverBalances, eEc *string) (err error) {
+	return dR.dR.AccountSv1ActionRolloverBalances(args, eEc)
+}
+
+func (dR *DispatcherAccountSv1) ReserveAbstracts(args *utils.ArgsAccountsForEvent, rsv *utils.AccountsReservation) (err error) {
+	return dR.dR.AccountSv1ReserveAbstracts(args, rsv)
+}
+
+func (dR *DispatcherAccountSv1) CommitReservation(args *utils.ArgsAccountsReservation, eEc *utils.ExtEventCharges) (err error) {
+	return dR.dR.AccountSv1CommitReservation(args, eEc)
+}
+
+func (dR *DispatcherAccountSv1) ReleaseReservation(args *utils.ArgsAccountsReservation, rply *string) (err error) {
+	return dR.dR.AccountSv1ReleaseReservation(args, rply)
+}
@@ -48,6 +48,12 @@ func (ldrSv1 *LoaderSv1) Remove(args *loaders.ArgsProcessFolder,
 	return ldrSv1.ldrS.V1Remove(args, rply)
 }
 
+// Rollback restores the profiles changed by a transactional load
+func (ldrSv1 *LoaderSv1) Rollback(args *loaders.ArgsRollback,
+	rply *string) error {
+	return ldrSv1.ldrS.V1Rollback(args, rply)
+}
+
 func (rsv1 *LoaderSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
@@ -218,6 +218,7 @@ func testPrecacheGetCacheStatsAfterRestart(t *testing.T) {
 		utils.CacheThresholds:                   {Items: 7},
 		utils.CacheTimings:                      {},
 		utils.CacheDiameterMessages:             {},
+		utils.CacheRadiusPackets:                {},
 		utils.CacheClosedSessions:               {},
 		utils.CacheLoadIDs:                      {},
 		utils.CacheRPCConnections:               {},
@@ -226,6 +227,8 @@ func testPrecacheGetCacheStatsAfterRestart(t *testing.T) {
 		utils.CacheUCH:                          {},
 		utils.CacheReverseFilterIndexes:         {},
 		utils.CacheAccounts:                     {},
+		utils.CacheSessionsBackup:               {},
+		utils.CacheRerateJobs:                   {},
 		utils.CacheVersions:                     {},
 		utils.CacheTBLTPTimings:                 {},
 		utils.CacheTBLTPDestinations:            {},
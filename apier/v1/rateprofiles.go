@@ -222,6 +222,16 @@ func (rSv1 *RateSv1) CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.R
 	return rSv1.rS.V1CostForEvent(args, rpCost)
 }
 
+// CostForEvents rates a batch of events
+func (rSv1 *RateSv1) CostForEvents(args *utils.ArgsCostForEvents, rpCosts *engine.RateProfileCosts) (err error) {
+	return rSv1.rS.V1CostForEvents(args, rpCosts)
+}
+
+// CompareRateProfiles rates the same events with each of the RateProfiles
+func (rSv1 *RateSv1) CompareRateProfiles(args *utils.ArgsCostForEvents, rpTotals *[]*engine.RateProfileTotal) (err error) {
+	return rSv1.rS.V1CompareRateProfiles(args, rpTotals)
+}
+
 func (rSv1 *RateSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package v1
 
 import (
+	"errors"
 	"net/rpc"
 	"path"
 	"reflect"
@@ -471,7 +472,7 @@ func testV1RsAuthorizeResources(t *testing.T) {
 		Units: 7,
 	}
 	if err := rlsV1Rpc.Call(utils.ResourceSv1AuthorizeResources,
-		&argsRU, &reply); err.Error() != utils.ErrResourceUnauthorized.Error() {
+		&argsRU, &reply); !errors.Is(utils.CastRPCErr(err), utils.ErrResourceUnauthorized) {
 		t.Error(err)
 	}
 }
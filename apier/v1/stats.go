@@ -73,6 +73,9 @@ func (apierSv1 *APIerSv1) SetStatQueueProfile(arg *engine.StatQueueWithCache, re
 	if arg.Tenant == utils.EmptyString {
 		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
+	if err = engine.CheckStatWindowType(arg.WindowType); err != nil {
+		return utils.APIErrorHandler(err)
+	}
 	if err = apierSv1.DataManager.SetStatQueueProfile(arg.StatQueueProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
@@ -197,6 +200,11 @@ func (stsv1 *StatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithOpts, reply *
 	return stsv1.sS.V1GetQueueFloatMetrics(args.TenantID, reply)
 }
 
+// GetQueueBucketMetrics returns the float metrics for each bucket of a windowed Queue
+func (stsv1 *StatSv1) GetQueueBucketMetrics(args *utils.TenantIDWithOpts, reply *[]*engine.StatBucketMetrics) (err error) {
+	return stsv1.sS.V1GetQueueBucketMetrics(args.TenantID, reply)
+}
+
 // ResetStatQueue resets the stat queue
 func (stsv1 *StatSv1) ResetStatQueue(tntID *utils.TenantIDWithOpts, reply *string) error {
 	return stsv1.sS.V1ResetStatQueue(tntID.TenantID, reply)
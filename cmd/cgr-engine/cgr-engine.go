@@ -597,6 +597,9 @@ func main() {
 	if cfg.ConfigSCfg().Enabled {
 		server.RegisterHttpFunc(cfg.ConfigSCfg().URL, config.HandlerConfigS)
 	}
+	if cfg.HTTPCfg().PrometheusURL != utils.EmptyString {
+		server.RegisterPrometheus(cfg.HTTPCfg().PrometheusURL)
+	}
 	if *httpPprofPath != utils.EmptyString {
 		server.RegisterProfiler(*httpPprofPath)
 	}
@@ -606,6 +609,7 @@ func main() {
 
 	// init AnalyzerS
 	anz := services.NewAnalyzerService(cfg, server, filterSChan, shdChan, internalAnalyzerSChan, srvDep)
+	anz.SetConnManager(connManager)
 	if anz.ShouldRun() {
 		shdWg.Add(1)
 		if err := anz.Start(); err != nil {
@@ -636,7 +640,7 @@ func main() {
 	dspH := services.NewRegistrarCService(cfg, server, connManager, anz, srvDep)
 	chrS := services.NewChargerService(cfg, dmService, cacheS, filterSChan, server,
 		internalChargerSChan, connManager, anz, srvDep)
-	tS := services.NewThresholdService(cfg, dmService, cacheS, filterSChan, server, internalThresholdSChan, anz, srvDep)
+	tS := services.NewThresholdService(cfg, dmService, cacheS, filterSChan, server, internalThresholdSChan, connManager, anz, srvDep)
 	stS := services.NewStatService(cfg, dmService, cacheS, filterSChan, server,
 		internalStatSChan, connManager, anz, srvDep)
 	reS := services.NewResourceService(cfg, dmService, cacheS, filterSChan, server,
@@ -18,7 +18,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 // AccountSCfg is the configuration of ActionS
 type AccountSCfg struct {
@@ -33,6 +37,7 @@ type AccountSCfg struct {
 	NestedFields        bool
 	MaxIterations       int
 	MaxUsage            *utils.Decimal
+	ReservationTTL      time.Duration
 }
 
 func (acS *AccountSCfg) loadFromJSONCfg(jsnCfg *AccountSJsonCfg) (err error) {
@@ -107,6 +112,11 @@ func (acS *AccountSCfg) loadFromJSONCfg(jsnCfg *AccountSJsonCfg) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.Reservation_ttl != nil {
+		if acS.ReservationTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reservation_ttl); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -117,6 +127,7 @@ func (acS *AccountSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.IndexedSelectsCfg: acS.IndexedSelects,
 		utils.NestedFieldsCfg:   acS.NestedFields,
 		utils.MaxIterations:     acS.MaxIterations,
+		utils.ReservationTTLCfg: acS.ReservationTTL.String(),
 	}
 	if acS.AttributeSConns != nil {
 		attributeSConns := make([]string, len(acS.AttributeSConns))
@@ -183,6 +194,7 @@ func (acS AccountSCfg) Clone() (cln *AccountSCfg) {
 		NestedFields:   acS.NestedFields,
 		MaxIterations:  acS.MaxIterations,
 		MaxUsage:       acS.MaxUsage,
+		ReservationTTL: acS.ReservationTTL,
 	}
 	if acS.AttributeSConns != nil {
 		cln.AttributeSConns = make([]string, len(acS.AttributeSConns))
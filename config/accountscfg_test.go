@@ -21,6 +21,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -38,6 +39,7 @@ func TestAccountSCfgLoadFromJSONCfg(t *testing.T) {
 		Nested_fields:         utils.BoolPointer(true),
 		Max_iterations:        utils.IntPointer(1000),
 		Max_usage:             utils.StringPointer("200h"),
+		Reservation_ttl:       utils.StringPointer("10m"),
 	}
 	usage, err := utils.NewDecimalFromUsage("200h")
 	if err != nil {
@@ -55,6 +57,7 @@ func TestAccountSCfgLoadFromJSONCfg(t *testing.T) {
 		NestedFields:        true,
 		MaxIterations:       1000,
 		MaxUsage:            usage,
+		ReservationTTL:      10 * time.Minute,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.accountSCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -89,6 +92,7 @@ func TestAccountSCfgAsMapInterface(t *testing.T) {
 	"nested_fields": true,			
     "max_iterations": 100,
     "max_usage": "72h",
+    "reservation_ttl": "30m",
 },	
 }`
 
@@ -103,6 +107,7 @@ func TestAccountSCfgAsMapInterface(t *testing.T) {
 		utils.SuffixIndexedFieldsCfg: []string{"*req.index1"},
 		utils.NestedFieldsCfg:        true,
 		utils.MaxIterations:          100,
+		utils.ReservationTTLCfg:      "30m0s",
 	}
 	usage, err := utils.NewDecimalFromUsage("72h")
 	if err != nil {
@@ -134,6 +139,7 @@ func TestAccountSCfgClone(t *testing.T) {
 		NestedFields:        true,
 		MaxIterations:       1000,
 		MaxUsage:            usage,
+		ReservationTTL:      time.Hour,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
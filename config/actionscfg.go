@@ -28,6 +28,7 @@ type ActionSCfg struct {
 	ThresholdSConns     []string
 	StatSConns          []string
 	AccountSConns       []string
+	ResourceSConns      []string
 	Tenants             *[]string
 	IndexedSelects      bool
 	StringIndexedFields *[]string
@@ -90,6 +91,16 @@ func (acS *ActionSCfg) loadFromJSONCfg(jsnCfg *ActionSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Resources_conns != nil {
+		acS.ResourceSConns = make([]string, len(*jsnCfg.Resources_conns))
+		for idx, connID := range *jsnCfg.Resources_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			acS.ResourceSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				acS.ResourceSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources)
+			}
+		}
+	}
 	if jsnCfg.Enabled != nil {
 		acS.Enabled = *jsnCfg.Enabled
 	}
@@ -177,6 +188,16 @@ func (acS *ActionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.AccountSConnsCfg] = accountSConns
 	}
+	if acS.ResourceSConns != nil {
+		resourceSConns := make([]string, len(acS.ResourceSConns))
+		for i, item := range acS.ResourceSConns {
+			resourceSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources) {
+				resourceSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.ResourceSConnsCfg] = resourceSConns
+	}
 	if acS.EEsConns != nil {
 		eesConns := make([]string, len(acS.EEsConns))
 		for i, item := range acS.EEsConns {
@@ -249,6 +270,12 @@ func (acS ActionSCfg) Clone() (cln *ActionSCfg) {
 			cln.AccountSConns[i] = con
 		}
 	}
+	if acS.ResourceSConns != nil {
+		cln.ResourceSConns = make([]string, len(acS.ResourceSConns))
+		for i, con := range acS.ResourceSConns {
+			cln.ResourceSConns[i] = con
+		}
+	}
 	if acS.EEsConns != nil {
 		cln.EEsConns = make([]string, len(acS.EEsConns))
 		for i, k := range acS.EEsConns {
@@ -33,6 +33,7 @@ func TestActionSCfgLoadFromJSONCfg(t *testing.T) {
 		Thresholds_conns:      &[]string{utils.MetaInternal},
 		Stats_conns:           &[]string{utils.MetaInternal},
 		Accounts_conns:        &[]string{utils.MetaInternal},
+		Resources_conns:       &[]string{utils.MetaInternal},
 		Indexed_selects:       utils.BoolPointer(false),
 		Tenants:               &[]string{"itsyscom.com"},
 		String_indexed_fields: &[]string{"*req.index1"},
@@ -47,6 +48,7 @@ func TestActionSCfgLoadFromJSONCfg(t *testing.T) {
 		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)},
 		AccountSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)},
+		ResourceSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources)},
 		IndexedSelects:      false,
 		Tenants:             &[]string{"itsyscom.com"},
 		StringIndexedFields: &[]string{"*req.index1"},
@@ -71,6 +73,7 @@ func TestActionSCfgAsMapInterface(t *testing.T) {
 	"thresholds_conns": ["*internal"],					
 	"stats_conns": ["*internal"],						
 	"accounts_conns": ["*internal"],						
+	"resources_conns": ["*internal"],
 	"tenants": ["itsyscom.com"],
 	"indexed_selects": false,
 	"string_indexed_fields": ["*req.index1"],			
@@ -87,6 +90,7 @@ func TestActionSCfgAsMapInterface(t *testing.T) {
 		utils.StatSConnsCfg:          []string{utils.MetaInternal},
 		utils.CDRsConnsCfg:           []string{utils.MetaInternal},
 		utils.AccountSConnsCfg:       []string{utils.MetaInternal},
+		utils.ResourceSConnsCfg:      []string{utils.MetaInternal},
 		utils.Tenants:                []string{"itsyscom.com"},
 		utils.IndexedSelectsCfg:      false,
 		utils.StringIndexedFieldsCfg: []string{"*req.index1"},
@@ -109,6 +113,7 @@ func TestActionSCfgClone(t *testing.T) {
 		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)},
 		AccountSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)},
+		ResourceSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources)},
 		Tenants:             &[]string{"itsyscom.com"},
 		IndexedSelects:      false,
 		StringIndexedFields: &[]string{"*req.index1"},
@@ -147,4 +152,7 @@ func TestActionSCfgClone(t *testing.T) {
 	if rcv.AccountSConns[0] = utils.EmptyString; ban.AccountSConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts) {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.ResourceSConns[0] = utils.EmptyString; ban.ResourceSConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources) {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
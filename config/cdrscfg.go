@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -36,6 +38,9 @@ type CdrsCfg struct {
 	OnlineCDRExports []string // list of CDRE templates to use for real-time CDR exports
 	SchedulerConns   []string
 	EEsConns         []string
+	DedupFields      []string      // fields building the deduplication key
+	DedupTTL         time.Duration // time window for deduplication
+	PartialCache     time.Duration // time to wait for the legs of partial CDRs, 0 disables merging
 }
 
 // loadFromJSONCfg loads Cdrs config from JsonCfg
@@ -133,16 +138,45 @@ func (cdrscfg *CdrsCfg) loadFromJSONCfg(jsnCdrsCfg *CdrsJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCdrsCfg.Dedup_fields != nil {
+		cdrscfg.DedupFields = make([]string, len(*jsnCdrsCfg.Dedup_fields))
+		for i, fld := range *jsnCdrsCfg.Dedup_fields {
+			cdrscfg.DedupFields[i] = fld
+		}
+	}
+	if jsnCdrsCfg.Dedup_ttl != nil {
+		if cdrscfg.DedupTTL, err = utils.ParseDurationWithNanosecs(*jsnCdrsCfg.Dedup_ttl); err != nil {
+			return
+		}
+	}
+	if jsnCdrsCfg.Partial_record_cache != nil {
+		if cdrscfg.PartialCache, err = utils.ParseDurationWithNanosecs(*jsnCdrsCfg.Partial_record_cache); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
 func (cdrscfg *CdrsCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:       cdrscfg.Enabled,
-		utils.StoreCdrsCfg:     cdrscfg.StoreCdrs,
-		utils.SMCostRetriesCfg: cdrscfg.SMCostRetries,
+		utils.EnabledCfg:            cdrscfg.Enabled,
+		utils.StoreCdrsCfg:          cdrscfg.StoreCdrs,
+		utils.SMCostRetriesCfg:      cdrscfg.SMCostRetries,
+		utils.DedupTTLCfg:           "0",
+		utils.PartialRecordCacheCfg: "0",
+	}
+	if cdrscfg.DedupTTL != 0 {
+		initialMP[utils.DedupTTLCfg] = cdrscfg.DedupTTL.String()
+	}
+	if cdrscfg.PartialCache != 0 {
+		initialMP[utils.PartialRecordCacheCfg] = cdrscfg.PartialCache.String()
 	}
+	dedupFields := make([]string, len(cdrscfg.DedupFields))
+	for i, fld := range cdrscfg.DedupFields {
+		dedupFields[i] = fld
+	}
+	initialMP[utils.DedupFieldsCfg] = dedupFields
 
 	extraFields := make([]string, len(cdrscfg.ExtraFields))
 	for i, item := range cdrscfg.ExtraFields {
@@ -236,6 +270,14 @@ func (cdrscfg CdrsCfg) Clone() (cln *CdrsCfg) {
 		ExtraFields:   cdrscfg.ExtraFields.Clone(),
 		StoreCdrs:     cdrscfg.StoreCdrs,
 		SMCostRetries: cdrscfg.SMCostRetries,
+		DedupTTL:      cdrscfg.DedupTTL,
+		PartialCache:  cdrscfg.PartialCache,
+	}
+	if cdrscfg.DedupFields != nil {
+		cln.DedupFields = make([]string, len(cdrscfg.DedupFields))
+		for i, fld := range cdrscfg.DedupFields {
+			cln.DedupFields[i] = fld
+		}
 	}
 	if cdrscfg.ChargerSConns != nil {
 		cln.ChargerSConns = make([]string, len(cdrscfg.ChargerSConns))
@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -37,6 +38,9 @@ func TestCdrsCfgloadFromJsonCfg(t *testing.T) {
 		Online_cdr_exports:   &[]string{"randomVal"},
 		Scheduler_conns:      &[]string{utils.MetaInternal, "*conn1"},
 		Ees_conns:            &[]string{utils.MetaInternal, "*conn1"},
+		Dedup_fields:         &[]string{utils.OriginID, utils.OriginHost},
+		Dedup_ttl:            utils.StringPointer("10m"),
+		Partial_record_cache: utils.StringPointer("1s"),
 	}
 	expected := &CdrsCfg{
 		Enabled:          true,
@@ -51,6 +55,9 @@ func TestCdrsCfgloadFromJsonCfg(t *testing.T) {
 		SchedulerConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler), "*conn1"},
 		EEsConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "*conn1"},
 		ExtraFields:      RSRParsers{},
+		DedupFields:      []string{utils.OriginID, utils.OriginHost},
+		DedupTTL:         10 * time.Minute,
+		PartialCache:     time.Second,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.cdrsCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -71,6 +78,17 @@ func TestExtraFieldsinloadFromJsonCfg(t *testing.T) {
 	}
 }
 
+func TestCdrsCfgloadFromJsonCfgDedupTTL(t *testing.T) {
+	cfgJSON := &CdrsJsonCfg{
+		Dedup_ttl: utils.StringPointer("1ss"),
+	}
+	expected := "time: unknown unit \"ss\" in duration \"1ss\""
+	jsonCfg := NewDefaultCGRConfig()
+	if err := jsonCfg.cdrsCfg.loadFromJSONCfg(cfgJSON); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+}
+
 func TestCdrsCfgAsMapInterface(t *testing.T) {
 	cfgJSONStr := `{
 	"cdrs": {
@@ -86,21 +104,26 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 		"online_cdr_exports":["http_localhost", "amqp_localhost", "http_test_file"],
 		"scheduler_conns": ["*internal:*scheduler","*conn1"],		
         "ees_conns": ["*internal:*ees","*conn1"],
+		"dedup_fields": ["OriginID", "OriginHost"],
+		"partial_record_cache": "1s",
 	},
 }`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg:          true,
-		utils.ExtraFieldsCfg:      []string{"~*req.PayPalAccount", "~*req.LCRProfile", "~*req.ResourceID"},
-		utils.StoreCdrsCfg:        true,
-		utils.SessionCostRetires:  5,
-		utils.ChargerSConnsCfg:    []string{utils.MetaInternal, "*conn1"},
-		utils.RALsConnsCfg:        []string{utils.MetaInternal, "*conn1"},
-		utils.AttributeSConnsCfg:  []string{utils.MetaInternal, "*conn1"},
-		utils.ThresholdSConnsCfg:  []string{utils.MetaInternal, "*conn1"},
-		utils.StatSConnsCfg:       []string{utils.MetaInternal, "*conn1"},
-		utils.OnlineCDRExportsCfg: []string{"http_localhost", "amqp_localhost", "http_test_file"},
-		utils.SchedulerConnsCfg:   []string{utils.MetaInternal, "*conn1"},
-		utils.EEsConnsCfg:         []string{utils.MetaInternal, "*conn1"},
+		utils.EnabledCfg:            true,
+		utils.ExtraFieldsCfg:        []string{"~*req.PayPalAccount", "~*req.LCRProfile", "~*req.ResourceID"},
+		utils.StoreCdrsCfg:          true,
+		utils.SessionCostRetires:    5,
+		utils.ChargerSConnsCfg:      []string{utils.MetaInternal, "*conn1"},
+		utils.RALsConnsCfg:          []string{utils.MetaInternal, "*conn1"},
+		utils.AttributeSConnsCfg:    []string{utils.MetaInternal, "*conn1"},
+		utils.ThresholdSConnsCfg:    []string{utils.MetaInternal, "*conn1"},
+		utils.StatSConnsCfg:         []string{utils.MetaInternal, "*conn1"},
+		utils.OnlineCDRExportsCfg:   []string{"http_localhost", "amqp_localhost", "http_test_file"},
+		utils.SchedulerConnsCfg:     []string{utils.MetaInternal, "*conn1"},
+		utils.EEsConnsCfg:           []string{utils.MetaInternal, "*conn1"},
+		utils.DedupFieldsCfg:        []string{utils.OriginID, utils.OriginHost},
+		utils.DedupTTLCfg:           "1h0m0s",
+		utils.PartialRecordCacheCfg: "1s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -119,18 +142,21 @@ func TestCdrsCfgAsMapInterface2(t *testing.T) {
        },
 }`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg:          true,
-		utils.ExtraFieldsCfg:      []string{},
-		utils.StoreCdrsCfg:        true,
-		utils.SessionCostRetires:  5,
-		utils.ChargerSConnsCfg:    []string{"conn1", "conn2"},
-		utils.RALsConnsCfg:        []string{},
-		utils.AttributeSConnsCfg:  []string{"*internal"},
-		utils.ThresholdSConnsCfg:  []string{},
-		utils.StatSConnsCfg:       []string{},
-		utils.OnlineCDRExportsCfg: []string{},
-		utils.SchedulerConnsCfg:   []string{},
-		utils.EEsConnsCfg:         []string{"conn1"},
+		utils.EnabledCfg:            true,
+		utils.ExtraFieldsCfg:        []string{},
+		utils.StoreCdrsCfg:          true,
+		utils.SessionCostRetires:    5,
+		utils.ChargerSConnsCfg:      []string{"conn1", "conn2"},
+		utils.RALsConnsCfg:          []string{},
+		utils.AttributeSConnsCfg:    []string{"*internal"},
+		utils.ThresholdSConnsCfg:    []string{},
+		utils.StatSConnsCfg:         []string{},
+		utils.OnlineCDRExportsCfg:   []string{},
+		utils.SchedulerConnsCfg:     []string{},
+		utils.EEsConnsCfg:           []string{"conn1"},
+		utils.DedupFieldsCfg:        []string{},
+		utils.DedupTTLCfg:           "1h0m0s",
+		utils.PartialRecordCacheCfg: "0",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -202,6 +202,7 @@ const CGRATES_CFG_JSON = `
 	"ws_url": "/ws",										// WebSockets relative URL ("" to disable)
 	"freeswitch_cdrs_url": "/freeswitch_json",				// Freeswitch CDRS relative URL ("" to disable)
 	"http_cdrs": "/cdr_http",								// CDRS relative URL ("" to disable)
+	"prometheus_url": "",									// Prometheus metrics relative URL, ie: "/metrics" ("" to disable)
 	"use_basic_auth": false,								// use basic authentication
 	"auth_users": {},										// basic authentication usernames and base64-encoded passwords (eg: { "username1": "cGFzc3dvcmQ=", "username2": "cGFzc3dvcmQy "})
 	"client_opts":{
@@ -278,6 +279,7 @@ const CGRATES_CFG_JSON = `
 		"*dispatcher_loads": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// control dispatcher load( in case of *ratio ConnParams is present)
 		"*dispatchers": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 								// control dispatcher interface
 		"*diameter_messages": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},						// diameter messages caching
+		"*radius_packets": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},							// radius packets caching
 		"*rpc_responses": {"limit": 0, "ttl": "2s", "static_ttl": false, "replicate": false},							// RPC responses caching
 		"*closed_sessions": {"limit": -1, "ttl": "10s", "static_ttl": false, "replicate": false},						// closed sessions cached for CDRs
 		"*event_charges": {"limit": -1, "ttl": "10s", "static_ttl": false, "replicate": false},							// events proccessed by ChargerS
@@ -293,6 +295,8 @@ const CGRATES_CFG_JSON = `
 		// only for *internal database
 		"*versions": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},									// for version storing
 		"*accounts": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},									// for account storing
+		"*sessions_backup": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// for sessions backup storing
+		"*rerate_jobs": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},								// for rerate jobs storing
 		// internal storDB tabels
 		"*session_costs": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 
 		"*cdrs": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 		
@@ -367,12 +371,16 @@ const CGRATES_CFG_JSON = `
 	"online_cdr_exports":[],				// list of CDRE profiles to use for real-time CDR exports
 	"scheduler_conns": [],					// connections to SchedulerS in case of *dynaprepaid request
 	"ees_conns": [],						// connections to EventExporter
+	"dedup_fields": [],						// event fields building the key used to drop duplicated CDRs, empty to disable deduplication
+	"dedup_ttl": "1h",						// time window in which duplicated CDRs are dropped
+	"partial_record_cache": "0",			// duration to wait for the remaining legs of partial CDRs before merging them: <0 to disable merging|$dur>
 },
 
 
 "ers": {									// EventReaderService
 	"enabled": false,						// starts the EventReader service: <true|false>
 	"sessions_conns":["*internal"],			// RPC Connections IDs
+	"ees_conns": [],						// connections to EEs for exporting the failed events: <""|*internal|$rpc_conns_id>
 	"readers": [
 		{
 			"id": "*default",									// identifier of the EventReader profile
@@ -468,6 +476,7 @@ const CGRATES_CFG_JSON = `
 	"terminate_attempts": 5,				// attempts to get the session before terminating it
 	"alterable_fields": [],					// the session fields that can be updated
 	//"min_dur_low_balance": "5s",			// threshold which will trigger low balance warnings for prepaid calls (needs to be lower than debit_interval)
+	"backup_interval": "0",					// checkpoint active sessions into DataDB so they survive a restart: <0 to disable|-1 on each change|$dur>
 	"stir": {
 		"allowed_attest": ["*any"],			// the default attest for stir/shaken authentication <*any|A|B|C>
 		"payload_maxduration": "-1", 		// the duration that stir header is valid after it was created
@@ -547,7 +556,10 @@ const CGRATES_CFG_JSON = `
 	"client_dictionaries": {									// per client path towards directory holding additional dictionaries to load (extra to RFC)
 		"*default": "/usr/share/cgrates/radius/dict/",			// key represents the client IP or catch-all <*default|$client_ip>
 	},
-	"sessions_conns": ["*internal"],
+	"client_da_addresses": {},									// Dynamic Authorization server address of the clients, defaults to $client_ip:3799 <$client_ip:$da_address>
+	"sessions_conns": ["*birpc_internal"],
+	"coa_template": "",											// template used to build the CoA-Request sent to the client on ReAuthorize
+	"dmr_template": "",											// template used to build the Disconnect-Request sent to the client on DisconnectSession
 	"request_processors": [										// request processors to be applied to Radius messages
 	],
 },
@@ -626,6 +638,7 @@ const CGRATES_CFG_JSON = `
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
+	"actions_conns": [],					// connections to ActionS for executing the ActionProfiles: <""|*internal|$rpc_conns_id>
 },
 
 
@@ -656,6 +669,20 @@ const CGRATES_CFG_JSON = `
 		"field_separator": ",",								// separator used in case of csv files
 		"tp_in_dir": "/var/spool/cgrates/loader/in",		// absolute path towards the directory where the TPs are stored
 		"tp_out_dir": "/var/spool/cgrates/loader/out",		// absolute path towards the directory where processed TPs will be moved
+		"type": "*file_csv",								// source of the data: <*file_csv|*file_ndjson|*s3_csv|*s3_ndjson|*sql>; tp_in_dir is the endpoint for *s3 and the connection URL for *sql
+		"opts": {
+			// "queueID": "cgrates_cdrs",					// *s3_csv, *s3_ndjson: bucket containing the files
+			// "folderPath": "",							// *s3_csv, *s3_ndjson: prefix of the object keys within the bucket
+			// "awsRegion": "",
+			// "awsKey": "",
+			// "awsSecret": "",
+			// "awsToken": "",
+			// "s3ForcePathStyle": false,					// *s3_csv, *s3_ndjson: use path style addressing (ie. MinIO)
+			// "dbName": "cgrates",						// *sql: database name
+			// "sslmode": "disable",						// *sql: postgres ssl mode
+			// "sqlKeyColumn": "id",					// *sql: column identifying the rows, the processed rows are deleted on move
+		},
+		"transactional": false,								// stage all the data, validate the references and store it at once with rollback support
 		"data":[											// data profiles to load
 			{
 				"type": "*attributes",						// data source type
@@ -701,6 +728,7 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Stored", "path": "Stored", "type": "*variable", "value": "~*req.8"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.9"},
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~*req.10"},
+					{"tag": "RateWindow", "path": "RateWindow", "type": "*variable", "value": "~*req.11"},
 				],
 			},
 			{
@@ -720,6 +748,9 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Stored", "path": "Stored", "type": "*variable", "value": "~*req.10"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.11"},
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~*req.12"},
+					{"tag": "BucketWidth", "path": "BucketWidth", "type": "*variable", "value": "~*req.13"},
+					{"tag": "Window", "path": "Window", "type": "*variable", "value": "~*req.14"},
+					{"tag": "WindowType", "path": "WindowType", "type": "*variable", "value": "~*req.15"},
 				],
 			},
 			{
@@ -737,6 +768,7 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.8"},
 					{"tag": "ActionIDs", "path": "ActionIDs", "type": "*variable", "value": "~*req.9"},
 					{"tag": "Async", "path": "Async", "type": "*variable", "value": "~*req.10"},
+					{"tag": "ActionProfileIDs", "path": "ActionProfileIDs", "type": "*variable", "value": "~*req.11"},
 				],
 			},
 			{
@@ -826,6 +858,8 @@ const CGRATES_CFG_JSON = `
                     {"tag": "RateRecurrentFee", "path": "RateRecurrentFee", "type": "*variable", "value": "~*req.15"},
 					{"tag": "RateUnit", "path": "RateUnit", "type": "*variable", "value": "~*req.16"},
 					{"tag": "RateIncrement", "path": "RateIncrement", "type": "*variable", "value": "~*req.17"},
+					{"tag": "VolumeUsage", "path": "VolumeUsage", "type": "*variable", "value": "~*req.18"},
+					{"tag": "VolumeTiers", "path": "VolumeTiers", "type": "*variable", "value": "~*req.19"},
 				],
 			},
 			{
@@ -965,6 +999,9 @@ const CGRATES_CFG_JSON = `
 	"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
 	"attributes_conns": [],					// connections to AttributeS for API authorization, empty to disable auth functionality: <""|*internal|$rpc_conns_id>
+	"probe_interval": "",					// interval for pinging the hosts with CoreSv1.Status, empty to disable <""|$dur>
+	"breaker_threshold": 0,				// consecutive failures excluding a host from dispatching, 0 to disable
+	"breaker_timeout": "5s",				// time a failed host is excluded before retrying it
 },
 
 
@@ -1140,6 +1177,7 @@ const CGRATES_CFG_JSON = `
 	"thresholds_conns": [],					// connections to ThresholdS for *reset_threshold action <""|*internal|$rpc_conns_id>
 	"stats_conns": [],						// connections to StatS for *reset_stat_queue action: <""|*internal|$rpc_conns_id>
 	"accounts_conns": [],					// connections to AccountS for *topup/*topup_reset action: <""|*internal|$rpc_conns_id>
+	"resources_conns": [],					// connections to ResourceS for *release_resources action: <""|*internal|$rpc_conns_id>
 	"tenants":[],							// List of tenants to operate on
 	"indexed_selects": true,				// enable profile matching exclusively on indexes
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
@@ -1161,6 +1199,7 @@ const CGRATES_CFG_JSON = `
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
     "max_iterations": 1000,                 // maximum number of iterations
     "max_usage": "72h",                     // maximum time of usage
+	"reservation_ttl": "1h",				// maximum time the units are held by a reservation, used when the reservation has no TTL
 },
 
 
@@ -211,6 +211,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheDiameterMessages: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheRadiusPackets: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheRPCResponses: {Limit: utils.IntPointer(0),
 				Ttl: utils.StringPointer("2s"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -246,6 +249,12 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheAccounts: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheSessionsBackup: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheRerateJobs: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 
 			utils.CacheTBLTPTimings: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
@@ -715,6 +724,9 @@ func TestDfCdrsJsonCfg(t *testing.T) {
 		Online_cdr_exports:   &[]string{},
 		Scheduler_conns:      &[]string{},
 		Ees_conns:            &[]string{},
+		Dedup_fields:         &[]string{},
+		Dedup_ttl:            utils.StringPointer("1h"),
+		Partial_record_cache: utils.StringPointer("0"),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -762,6 +774,7 @@ func TestSmgJsonCfg(t *testing.T) {
 			Privatekey_path:     utils.StringPointer(""),
 			Publickey_path:      utils.StringPointer(""),
 		},
+		Backup_interval: utils.StringPointer("0"),
 		Scheduler_conns: &[]string{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
@@ -897,8 +910,11 @@ func TestRadiusAgentJsonCfg(t *testing.T) {
 		Client_dictionaries: utils.MapStringStringPointer(map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		}),
-		Sessions_conns:     &[]string{utils.MetaInternal},
-		Request_processors: &[]*ReqProcessorJsnCfg{},
+		Client_da_addresses: utils.MapStringStringPointer(map[string]string{}),
+		Sessions_conns:      &[]string{rpcclient.BiRPCInternal},
+		Coa_template:        utils.StringPointer(""),
+		Dmr_template:        utils.StringPointer(""),
+		Request_processors:  &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1061,6 +1077,7 @@ func TestDfThresholdSJsonCfg(t *testing.T) {
 		Prefix_indexed_fields: &[]string{},
 		Suffix_indexed_fields: &[]string{},
 		Nested_fields:         utils.BoolPointer(false),
+		Actions_conns:         &[]string{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1111,6 +1128,9 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 			Field_separator: utils.StringPointer(","),
 			Tp_in_dir:       utils.StringPointer("/var/spool/cgrates/loader/in"),
 			Tp_out_dir:      utils.StringPointer("/var/spool/cgrates/loader/out"),
+			Type:            utils.StringPointer(utils.MetaFileCSV),
+			Opts:            map[string]interface{}{},
+			Transactional:   utils.BoolPointer(false),
 			Data: &[]*LoaderJsonDataType{
 				{
 					Type:      utils.StringPointer(utils.MetaAttributes),
@@ -1246,6 +1266,10 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ThresholdIDs"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.10")},
+						{Tag: utils.StringPointer("RateWindow"),
+							Path:  utils.StringPointer("RateWindow"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.11")},
 					},
 				},
 				{
@@ -1307,6 +1331,18 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ThresholdIDs"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.12")},
+						{Tag: utils.StringPointer("BucketWidth"),
+							Path:  utils.StringPointer("BucketWidth"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.13")},
+						{Tag: utils.StringPointer("Window"),
+							Path:  utils.StringPointer("Window"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.14")},
+						{Tag: utils.StringPointer("WindowType"),
+							Path:  utils.StringPointer("WindowType"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.15")},
 					},
 				},
 				{
@@ -1359,6 +1395,10 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Async"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.10")},
+						{Tag: utils.StringPointer("ActionProfileIDs"),
+							Path:  utils.StringPointer("ActionProfileIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.11")},
 					},
 				},
 				{
@@ -1635,6 +1675,14 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("RateIncrement"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.17")},
+						{Tag: utils.StringPointer("VolumeUsage"),
+							Path:  utils.StringPointer("VolumeUsage"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.18")},
+						{Tag: utils.StringPointer("VolumeTiers"),
+							Path:  utils.StringPointer("VolumeTiers"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.19")},
 					},
 				},
 				{
@@ -1865,6 +1913,7 @@ func TestDfHttpJsonCfg(t *testing.T) {
 		Ws_url:              utils.StringPointer("/ws"),
 		Freeswitch_cdrs_url: utils.StringPointer("/freeswitch_json"),
 		Http_Cdrs:           utils.StringPointer("/cdr_http"),
+		Prometheus_url:      utils.StringPointer(""),
 		Use_basic_auth:      utils.BoolPointer(false),
 		Auth_users:          utils.MapStringStringPointer(map[string]string{}),
 		Client_opts: map[string]interface{}{
@@ -1904,6 +1953,9 @@ func TestDfDispatcherSJsonCfg(t *testing.T) {
 		Suffix_indexed_fields: &[]string{},
 		Attributes_conns:      &[]string{},
 		Nested_fields:         utils.BoolPointer(false),
+		Probe_interval:        utils.StringPointer(utils.EmptyString),
+		Breaker_threshold:     utils.IntPointer(0),
+		Breaker_timeout:       utils.StringPointer("5s"),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -2067,6 +2119,7 @@ func TestDfEventReaderCfg(t *testing.T) {
 	eCfg := &ERsJsonCfg{
 		Enabled:        utils.BoolPointer(false),
 		Sessions_conns: &[]string{utils.MetaInternal},
+		Ees_conns:      &[]string{},
 		Readers: &[]*EventReaderJsonCfg{
 			{
 				Id:                      utils.StringPointer(utils.MetaDefault),
@@ -2435,6 +2488,7 @@ func TestDfActionSJsonCfg(t *testing.T) {
 		Thresholds_conns:      &[]string{},
 		Stats_conns:           &[]string{},
 		Accounts_conns:        &[]string{},
+		Resources_conns:       &[]string{},
 		Tenants:               &[]string{},
 		Indexed_selects:       utils.BoolPointer(true),
 		String_indexed_fields: nil,
@@ -114,19 +114,31 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
 			}
 		}
+		if len(cfg.cdrsCfg.DedupFields) != 0 && cfg.cdrsCfg.DedupTTL <= 0 {
+			return fmt.Errorf("<%s> %s requires a positive %s", utils.CDRs, utils.DedupFieldsCfg, utils.DedupTTLCfg)
+		}
 	}
 	// Loaders sanity checks
 	for _, ldrSCfg := range cfg.loaderCfg {
 		if !ldrSCfg.Enabled {
 			continue
 		}
-		if _, err := os.Stat(ldrSCfg.TpInDir); err != nil && os.IsNotExist(err) { // if loader is enabled tpInDir must exist
-			return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, ldrSCfg.TpInDir)
-		}
-		if ldrSCfg.TpOutDir != utils.EmptyString { // tpOutDir support empty string for no moving files after process
-			if _, err := os.Stat(ldrSCfg.TpOutDir); err != nil && os.IsNotExist(err) {
-				return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, ldrSCfg.TpOutDir)
+		switch ldrSCfg.Type {
+		case utils.EmptyString, utils.MetaFileCSV, utils.MetaFileNDJSON:
+			if _, err := os.Stat(ldrSCfg.TpInDir); err != nil && os.IsNotExist(err) { // if loader is enabled tpInDir must exist
+				return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, ldrSCfg.TpInDir)
+			}
+			if ldrSCfg.TpOutDir != utils.EmptyString { // tpOutDir support empty string for no moving files after process
+				if _, err := os.Stat(ldrSCfg.TpOutDir); err != nil && os.IsNotExist(err) {
+					return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, ldrSCfg.TpOutDir)
+				}
 			}
+		case utils.MetaS3CSV, utils.MetaS3NDJSON, utils.MetaSQL:
+			if ldrSCfg.RunDelay == -1 {
+				return fmt.Errorf("<%s> run_delay -1 not supported for type %s", utils.LoaderS, ldrSCfg.Type)
+			}
+		default:
+			return fmt.Errorf("<%s> unsupported type %s", utils.LoaderS, ldrSCfg.Type)
 		}
 		for _, data := range ldrSCfg.Data {
 			if !posibleLoaderTypes.Has(data.Type) {
@@ -381,10 +393,10 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> template with ID <%s> has connection with id: <%s> not defined", utils.HTTPAgent, httpAgentCfg.ID, connID)
 			}
 		}
-		if !utils.SliceHasMember([]string{utils.MetaUrl, utils.MetaXml}, httpAgentCfg.RequestPayload) {
+		if !utils.SliceHasMember([]string{utils.MetaUrl, utils.MetaXml, utils.MetaJSON}, httpAgentCfg.RequestPayload) {
 			return fmt.Errorf("<%s> unsupported request payload %s", utils.HTTPAgent, httpAgentCfg.RequestPayload)
 		}
-		if !utils.SliceHasMember([]string{utils.MetaTextPlain, utils.MetaXml}, httpAgentCfg.ReplyPayload) {
+		if !utils.SliceHasMember([]string{utils.MetaTextPlain, utils.MetaXml, utils.MetaJSON}, httpAgentCfg.ReplyPayload) {
 			return fmt.Errorf("<%s> unsupported reply payload %s", utils.HTTPAgent, httpAgentCfg.ReplyPayload)
 		}
 		for _, req := range httpAgentCfg.RequestProcessors {
@@ -466,6 +478,17 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// ThresholdS checks
+	if cfg.thresholdSCfg.Enabled {
+		for _, connID := range cfg.thresholdSCfg.ActionSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.actionSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.ActionS, utils.ThresholdS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.ThresholdS, connID)
+			}
+		}
+	}
 	// RouteS checks
 	if cfg.routeSCfg.Enabled {
 		for _, connID := range cfg.routeSCfg.AttributeSConns {
@@ -538,6 +561,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.ERs, connID)
 			}
 		}
+		for _, connID := range cfg.ersCfg.EEsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.eesCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.EEs, utils.ERs)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.ERs, connID)
+			}
+		}
 		for _, rdr := range cfg.ersCfg.Readers {
 			if !possibleReaderTypes.Has(rdr.Type) {
 				return fmt.Errorf("<%s> unsupported data type: %s for reader with ID: %s", utils.ERs, rdr.Type, rdr.ID)
@@ -553,7 +584,7 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				if rdr.FieldSep == utils.EmptyString {
 					return fmt.Errorf("<%s> empty FieldSep for reader with ID: %s", utils.ERs, rdr.ID)
 				}
-			case utils.MetaKafkajsonMap:
+			case utils.MetaKafkajsonMap, utils.MetaNatsjsonMap:
 				if rdr.RunDelay > 0 {
 					return fmt.Errorf("<%s> the RunDelay field can not be bigger than zero for reader with ID: %s", utils.ERs, rdr.ID)
 				}
@@ -564,6 +595,26 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 					}
 				}
 			}
+			if dlvMode, has := rdr.Opts[utils.DeliveryMode]; has {
+				switch utils.IfaceAsString(dlvMode) {
+				case utils.MetaAtMostOnce:
+				case utils.MetaAtLeastOnce: // the *amqpv1_json_map, *s3_json_map and *sql readers acknowledge the messages before processing
+					if !utils.IsSliceMember([]string{utils.MetaKafkajsonMap, utils.MetaAMQPjsonMap,
+						utils.MetaSQSjsonMap, utils.MetaNatsjsonMap}, rdr.Type) {
+						return fmt.Errorf("<%s> unsupported %s: %s for reader with ID: %s", utils.ERs, utils.DeliveryMode, dlvMode, rdr.ID)
+					}
+				default:
+					return fmt.Errorf("<%s> unsupported %s: %s for reader with ID: %s", utils.ERs, utils.DeliveryMode, dlvMode, rdr.ID)
+				}
+			}
+			if retries, has := rdr.Opts[utils.DeliveryRetries]; has {
+				if _, err := utils.IfaceAsTInt64(retries); err != nil {
+					return fmt.Errorf("<%s> invalid %s: %v for reader with ID: %s", utils.ERs, utils.DeliveryRetries, retries, rdr.ID)
+				}
+			}
+			if _, has := rdr.Opts[utils.DeadLetterExporterID]; has && len(cfg.ersCfg.EEsConns) == 0 {
+				return fmt.Errorf("<%s> %s defined without %s for reader with ID: %s", utils.ERs, utils.DeadLetterExporterID, utils.EEsConnsCfg, rdr.ID)
+			}
 			for _, field := range rdr.CacheDumpFields {
 				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
 					return fmt.Errorf("<%s> %s for %s at %s", utils.ERs, utils.NewErrMandatoryIeMissing(utils.Path), rdr.ID, field.Tag)
@@ -696,6 +747,16 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.DispatcherS, connID)
 			}
 		}
+		if cfg.dispatcherSCfg.ProbeInterval < 0 {
+			return fmt.Errorf("<%s> the probe_interval needs to be positive", utils.DispatcherS)
+		}
+		if cfg.dispatcherSCfg.BreakerThreshold < 0 {
+			return fmt.Errorf("<%s> the breaker_threshold needs to be positive", utils.DispatcherS)
+		}
+		if cfg.dispatcherSCfg.BreakerThreshold != 0 &&
+			cfg.dispatcherSCfg.BreakerTimeout <= 0 {
+			return fmt.Errorf("<%s> the breaker_timeout needs to be bigger than 0", utils.DispatcherS)
+		}
 	}
 	// Cache check
 	for _, connID := range cfg.cacheCfg.ReplicationConns {
@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -31,6 +33,9 @@ type DispatcherSCfg struct {
 	SuffixIndexedFields *[]string
 	AttributeSConns     []string
 	NestedFields        bool
+	ProbeInterval       time.Duration // interval for pinging the DispatcherHosts, 0 to disable
+	BreakerThreshold    int           // consecutive failures opening the circuit of a host, 0 to disable
+	BreakerTimeout      time.Duration // time a host circuit stays open before a half-open retry
 }
 
 func (dps *DispatcherSCfg) loadFromJSONCfg(jsnCfg *DispatcherSJsonCfg) (err error) {
@@ -77,15 +82,34 @@ func (dps *DispatcherSCfg) loadFromJSONCfg(jsnCfg *DispatcherSJsonCfg) (err erro
 	if jsnCfg.Nested_fields != nil {
 		dps.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Probe_interval != nil {
+		if dps.ProbeInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Probe_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Breaker_threshold != nil {
+		dps.BreakerThreshold = *jsnCfg.Breaker_threshold
+	}
+	if jsnCfg.Breaker_timeout != nil {
+		if dps.BreakerTimeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Breaker_timeout); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
 func (dps *DispatcherSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:        dps.Enabled,
-		utils.IndexedSelectsCfg: dps.IndexedSelects,
-		utils.NestedFieldsCfg:   dps.NestedFields,
+		utils.EnabledCfg:          dps.Enabled,
+		utils.IndexedSelectsCfg:   dps.IndexedSelects,
+		utils.NestedFieldsCfg:     dps.NestedFields,
+		utils.ProbeIntervalCfg:    utils.EmptyString,
+		utils.BreakerThresholdCfg: dps.BreakerThreshold,
+		utils.BreakerTimeoutCfg:   dps.BreakerTimeout.String(),
+	}
+	if dps.ProbeInterval != 0 {
+		initialMP[utils.ProbeIntervalCfg] = dps.ProbeInterval.String()
 	}
 	if dps.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*dps.StringIndexedFields))
@@ -124,9 +148,12 @@ func (dps *DispatcherSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 // Clone returns a deep copy of DispatcherSCfg
 func (dps DispatcherSCfg) Clone() (cln *DispatcherSCfg) {
 	cln = &DispatcherSCfg{
-		Enabled:        dps.Enabled,
-		IndexedSelects: dps.IndexedSelects,
-		NestedFields:   dps.NestedFields,
+		Enabled:          dps.Enabled,
+		IndexedSelects:   dps.IndexedSelects,
+		NestedFields:     dps.NestedFields,
+		ProbeInterval:    dps.ProbeInterval,
+		BreakerThreshold: dps.BreakerThreshold,
+		BreakerTimeout:   dps.BreakerTimeout,
 	}
 
 	if dps.AttributeSConns != nil {
@@ -21,6 +21,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -34,6 +35,9 @@ func TestDispatcherSCfgloadFromJsonCfg(t *testing.T) {
 		Suffix_indexed_fields: &[]string{"*req.prefix", "*req.indexed", "*req.fields"},
 		Attributes_conns:      &[]string{utils.MetaInternal, "*conn1"},
 		Nested_fields:         utils.BoolPointer(true),
+		Probe_interval:        utils.StringPointer("1s"),
+		Breaker_threshold:     utils.IntPointer(3),
+		Breaker_timeout:       utils.StringPointer("10s"),
 	}
 	expected := &DispatcherSCfg{
 		Enabled:             true,
@@ -43,6 +47,9 @@ func TestDispatcherSCfgloadFromJsonCfg(t *testing.T) {
 		SuffixIndexedFields: &[]string{"*req.prefix", "*req.indexed", "*req.fields"},
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		NestedFields:        true,
+		ProbeInterval:       time.Second,
+		BreakerThreshold:    3,
+		BreakerTimeout:      10 * time.Second,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.dispatcherSCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -71,6 +78,9 @@ func TestDispatcherSCfgAsMapInterface(t *testing.T) {
 		utils.SuffixIndexedFieldsCfg: []string{},
 		utils.NestedFieldsCfg:        false,
 		utils.AttributeSConnsCfg:     []string{},
+		utils.ProbeIntervalCfg:       utils.EmptyString,
+		utils.BreakerThresholdCfg:    0,
+		utils.BreakerTimeoutCfg:      "5s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -89,6 +99,9 @@ func TestDispatcherSCfgAsMapInterface1(t *testing.T) {
             "suffix_indexed_fields": ["*req.prefix"],
 			"nested_fields": false,
 			"attributes_conns": ["*internal:*attributes", "*conn1"],
+			"probe_interval": "1s",
+			"breaker_threshold": 3,
+			"breaker_timeout": "10s",
 		},
 		
 }`
@@ -100,6 +113,9 @@ func TestDispatcherSCfgAsMapInterface1(t *testing.T) {
 		utils.SuffixIndexedFieldsCfg: []string{"*req.prefix"},
 		utils.NestedFieldsCfg:        false,
 		utils.AttributeSConnsCfg:     []string{"*internal", "*conn1"},
+		utils.ProbeIntervalCfg:       "1s",
+		utils.BreakerThresholdCfg:    3,
+		utils.BreakerTimeoutCfg:      "10s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -119,6 +135,9 @@ func TestDispatcherSCfgAsMapInterface2(t *testing.T) {
 		utils.SuffixIndexedFieldsCfg: []string{},
 		utils.NestedFieldsCfg:        false,
 		utils.AttributeSConnsCfg:     []string{},
+		utils.ProbeIntervalCfg:       utils.EmptyString,
+		utils.BreakerThresholdCfg:    0,
+		utils.BreakerTimeoutCfg:      "5s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -135,6 +154,9 @@ func TestDispatcherSCfgClone(t *testing.T) {
 		SuffixIndexedFields: &[]string{"*req.prefix", "*req.indexed", "*req.fields"},
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		NestedFields:        true,
+		ProbeInterval:       time.Second,
+		BreakerThreshold:    3,
+		BreakerTimeout:      10 * time.Second,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
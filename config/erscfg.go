@@ -28,6 +28,7 @@ import (
 type ERsCfg struct {
 	Enabled       bool
 	SessionSConns []string
+	EEsConns      []string // used to export the events failed to be processed
 	Readers       []*EventReaderCfg
 }
 
@@ -48,6 +49,16 @@ func (erS *ERsCfg) loadFromJSONCfg(jsnCfg *ERsJsonCfg, msgTemplates map[string][
 			}
 		}
 	}
+	if jsnCfg.Ees_conns != nil {
+		erS.EEsConns = make([]string, len(*jsnCfg.Ees_conns))
+		for i, fID := range *jsnCfg.Ees_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			erS.EEsConns[i] = fID
+			if fID == utils.MetaInternal {
+				erS.EEsConns[i] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)
+			}
+		}
+	}
 	return erS.appendERsReaders(jsnCfg.Readers, msgTemplates, sep, dfltRdrCfg)
 }
 
@@ -93,6 +104,12 @@ func (erS *ERsCfg) Clone() (cln *ERsCfg) {
 	for idx, sConn := range erS.SessionSConns {
 		cln.SessionSConns[idx] = sConn
 	}
+	if erS.EEsConns != nil {
+		cln.EEsConns = make([]string, len(erS.EEsConns))
+		for idx, eConn := range erS.EEsConns {
+			cln.EEsConns[idx] = eConn
+		}
+	}
 	for idx, rdr := range erS.Readers {
 		cln.Readers[idx] = rdr.Clone()
 	}
@@ -114,6 +131,16 @@ func (erS *ERsCfg) AsMapInterface(separator string) (initialMP map[string]interf
 		}
 		initialMP[utils.SessionSConnsCfg] = sessionSConns
 	}
+	if erS.EEsConns != nil {
+		eesConns := make([]string, len(erS.EEsConns))
+		for i, item := range erS.EEsConns {
+			eesConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs) {
+				eesConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.EEsConnsCfg] = eesConns
+	}
 	if erS.Readers != nil {
 		readers := make([]map[string]interface{}, len(erS.Readers))
 		for i, item := range erS.Readers {
@@ -63,6 +63,7 @@ func TestERSClone(t *testing.T) {
 	expectedERsCfg := &ERsCfg{
 		Enabled:       true,
 		SessionSConns: []string{"*internal:*sessions"},
+		EEsConns:      []string{},
 		Readers: []*EventReaderCfg{
 			{
 				ID:               utils.MetaDefault,
@@ -214,6 +215,7 @@ func TestERSLoadFromjsonCfg(t *testing.T) {
 	expectedERsCfg := &ERsCfg{
 		Enabled:       true,
 		SessionSConns: []string{"conn1", "conn3"},
+		EEsConns:      []string{},
 		Readers: []*EventReaderCfg{
 			{
 				ID:               utils.MetaDefault,
@@ -435,6 +437,7 @@ func TestERSloadFromJsonCase3(t *testing.T) {
 	expectedERsCfg := &ERsCfg{
 		Enabled:       true,
 		SessionSConns: []string{"*conn1"},
+		EEsConns:      []string{},
 		Readers: []*EventReaderCfg{
 			{
 				ID:               utils.MetaDefault,
@@ -566,6 +569,7 @@ func TestERSloadFromJsonCase4(t *testing.T) {
 	expectedERsCfg := &ERsCfg{
 		Enabled:       true,
 		SessionSConns: []string{"*conn1"},
+		EEsConns:      []string{},
 		Readers: []*EventReaderCfg{
 			{
 				ID:               utils.MetaDefault,
@@ -685,6 +689,7 @@ func TestEventReaderSameID(t *testing.T) {
 	expectedERsCfg := &ERsCfg{
 		Enabled:       true,
 		SessionSConns: []string{"conn1"},
+		EEsConns:      []string{},
 		Readers: []*EventReaderCfg{
 			{
 				ID:               utils.MetaDefault,
@@ -817,6 +822,7 @@ func TestERsCfgAsMapInterfaceCase1(t *testing.T) {
 	eMap := map[string]interface{}{
 		utils.EnabledCfg:       true,
 		utils.SessionSConnsCfg: []string{"conn1", "conn3"},
+		utils.EEsConnsCfg:      []string{},
 		utils.ReadersCfg: []map[string]interface{}{
 			{
 				utils.FiltersCfg:                  []string{},
@@ -924,6 +930,7 @@ func TestERSCfgAsMapInterfaceCase2(t *testing.T) {
 	eMap := map[string]interface{}{
 		utils.EnabledCfg:       true,
 		utils.SessionSConnsCfg: []string{"conn1", "conn3"},
+		utils.EEsConnsCfg:      []string{},
 		utils.ReadersCfg: []map[string]interface{}{
 			{
 				utils.FiltersCfg:                  []string{},
@@ -1045,6 +1052,7 @@ func TestERsloadFromJsonCfg(t *testing.T) {
 	expectedERsCfg := &ERsCfg{
 		Enabled:       true,
 		SessionSConns: []string{"*conn1"},
+		EEsConns:      []string{},
 		Readers: []*EventReaderCfg{
 			{
 				ID:               utils.MetaDefault,
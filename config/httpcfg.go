@@ -29,6 +29,7 @@ type HTTPCfg struct {
 	HTTPWSURL             string            // WebSocket relative URL ("" to disable)
 	HTTPFreeswitchCDRsURL string            // Freeswitch CDRS relative URL ("" to disable)
 	HTTPCDRsURL           string            // CDRS relative URL ("" to disable)
+	PrometheusURL         string            // Prometheus metrics relative URL ("" to disable)
 	HTTPUseBasicAuth      bool              // Use basic auth for HTTP API
 	HTTPAuthUsers         map[string]string // Basic auth user:password map (base64 passwords)
 	ClientOpts            map[string]interface{}
@@ -54,6 +55,9 @@ func (httpcfg *HTTPCfg) loadFromJSONCfg(jsnHTTPCfg *HTTPJsonCfg) (err error) {
 	if jsnHTTPCfg.Http_Cdrs != nil {
 		httpcfg.HTTPCDRsURL = *jsnHTTPCfg.Http_Cdrs
 	}
+	if jsnHTTPCfg.Prometheus_url != nil {
+		httpcfg.PrometheusURL = *jsnHTTPCfg.Prometheus_url
+	}
 	if jsnHTTPCfg.Use_basic_auth != nil {
 		httpcfg.HTTPUseBasicAuth = *jsnHTTPCfg.Use_basic_auth
 	}
@@ -80,6 +84,7 @@ func (httpcfg *HTTPCfg) AsMapInterface() map[string]interface{} {
 		utils.HTTPWSURLCfg:             httpcfg.HTTPWSURL,
 		utils.HTTPFreeswitchCDRsURLCfg: httpcfg.HTTPFreeswitchCDRsURL,
 		utils.HTTPCDRsURLCfg:           httpcfg.HTTPCDRsURL,
+		utils.HTTPPrometheusURLCfg:     httpcfg.PrometheusURL,
 		utils.HTTPUseBasicAuthCfg:      httpcfg.HTTPUseBasicAuth,
 		utils.HTTPAuthUsersCfg:         httpcfg.HTTPAuthUsers,
 		utils.HTTPClientOptsCfg:        clientOpts,
@@ -94,6 +99,7 @@ func (httpcfg HTTPCfg) Clone() (cln *HTTPCfg) {
 		HTTPWSURL:             httpcfg.HTTPWSURL,
 		HTTPFreeswitchCDRsURL: httpcfg.HTTPFreeswitchCDRsURL,
 		HTTPCDRsURL:           httpcfg.HTTPCDRsURL,
+		PrometheusURL:         httpcfg.PrometheusURL,
 		HTTPUseBasicAuth:      httpcfg.HTTPUseBasicAuth,
 		HTTPAuthUsers:         make(map[string]string),
 		ClientOpts:            make(map[string]interface{}),
@@ -77,6 +77,7 @@ func TestHTTPCfgAsMapInterface(t *testing.T) {
 		utils.HTTPWSURLCfg:             "/ws",
 		utils.HTTPFreeswitchCDRsURLCfg: "/freeswitch_json",
 		utils.HTTPCDRsURLCfg:           "/cdr_http",
+		utils.HTTPPrometheusURLCfg:     "",
 		utils.HTTPUseBasicAuthCfg:      false,
 		utils.HTTPAuthUsersCfg:         map[string]string{},
 		utils.HTTPClientOptsCfg: map[string]interface{}{
@@ -118,6 +119,7 @@ func TestHTTPCfgAsMapInterface1(t *testing.T) {
 		utils.HTTPWSURLCfg:             "",
 		utils.HTTPFreeswitchCDRsURLCfg: "/freeswitch_json",
 		utils.HTTPCDRsURLCfg:           "/cdr_http",
+		utils.HTTPPrometheusURLCfg:     "",
 		utils.HTTPUseBasicAuthCfg:      true,
 		utils.HTTPAuthUsersCfg: map[string]string{
 			"user1": "authenticated",
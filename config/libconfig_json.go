@@ -66,6 +66,7 @@ type HTTPJsonCfg struct {
 	Ws_url              *string
 	Freeswitch_cdrs_url *string
 	Http_Cdrs           *string
+	Prometheus_url      *string
 	Use_basic_auth      *bool
 	Auth_users          *map[string]string
 	Client_opts         map[string]interface{}
@@ -152,12 +153,16 @@ type CdrsJsonCfg struct {
 	Online_cdr_exports   *[]string
 	Scheduler_conns      *[]string
 	Ees_conns            *[]string
+	Dedup_fields         *[]string
+	Dedup_ttl            *string
+	Partial_record_cache *string
 }
 
 // EventReaderSJsonCfg contains the configuration of EventReaderService
 type ERsJsonCfg struct {
 	Enabled        *bool
 	Sessions_conns *[]string
+	Ees_conns      *[]string
 	Readers        *[]*EventReaderJsonCfg
 }
 
@@ -238,6 +243,7 @@ type SessionSJsonCfg struct {
 	Terminate_attempts     *int
 	Alterable_fields       *[]string
 	Min_dur_low_balance    *string
+	Backup_interval        *string
 	Scheduler_conns        *[]string
 	Stir                   *STIRJsonCfg
 	Default_usage          *map[string]string
@@ -358,8 +364,11 @@ type RadiusAgentJsonCfg struct {
 	Listen_acct         *string
 	Client_secrets      *map[string]string
 	Client_dictionaries *map[string]string
+	Client_da_addresses *map[string]string
 	Sessions_conns      *[]string
 	Timezone            *string
+	Coa_template        *string
+	Dmr_template        *string
 	Request_processors  *[]*ReqProcessorJsnCfg
 }
 
@@ -452,6 +461,7 @@ type ThresholdSJsonCfg struct {
 	Prefix_indexed_fields *[]string
 	Suffix_indexed_fields *[]string
 	Nested_fields         *bool // applies when indexed fields is not defined
+	Actions_conns         *[]string
 }
 
 // Route service config section
@@ -487,6 +497,9 @@ type LoaderJsonCfg struct {
 	Field_separator *string
 	Tp_in_dir       *string
 	Tp_out_dir      *string
+	Type            *string
+	Opts            map[string]interface{}
+	Transactional   *bool
 	Data            *[]*LoaderJsonDataType
 }
 
@@ -536,6 +549,9 @@ type DispatcherSJsonCfg struct {
 	Suffix_indexed_fields *[]string
 	Nested_fields         *bool // applies when indexed fields is not defined
 	Attributes_conns      *[]string
+	Probe_interval        *string
+	Breaker_threshold     *int
+	Breaker_timeout       *string
 }
 
 type RegistrarCJsonCfg struct {
@@ -678,6 +694,7 @@ type ActionSJsonCfg struct {
 	Thresholds_conns      *[]string
 	Stats_conns           *[]string
 	Accounts_conns        *[]string
+	Resources_conns       *[]string
 	Tenants               *[]string
 	Indexed_selects       *bool
 	String_indexed_fields *[]string
@@ -699,4 +716,5 @@ type AccountSJsonCfg struct {
 	Nested_fields         *bool // applies when indexed fields is not defined
 	Max_iterations        *int
 	Max_usage             *string
+	Reservation_ttl       *string
 }
@@ -76,6 +76,9 @@ type LoaderSCfg struct {
 	FieldSeparator string
 	TpInDir        string
 	TpOutDir       string
+	Type           string // source type: <*file_csv|*file_ndjson|*s3_csv|*s3_ndjson|*sql>
+	Opts           map[string]interface{}
+	Transactional  bool // stage all the data and store it at once
 	Data           []*LoaderDataType
 }
 
@@ -159,6 +162,22 @@ func (l *LoaderSCfg) loadFromJSONCfg(jsnCfg *LoaderJsonCfg, msgTemplates map[str
 	if jsnCfg.Tp_out_dir != nil {
 		l.TpOutDir = *jsnCfg.Tp_out_dir
 	}
+	if jsnCfg.Type != nil {
+		l.Type = *jsnCfg.Type
+	}
+	if jsnCfg.Transactional != nil {
+		l.Transactional = *jsnCfg.Transactional
+	}
+	if jsnCfg.Opts != nil {
+		opts := make(map[string]interface{}, len(l.Opts)+len(jsnCfg.Opts)) // do not alter the default options
+		for k, v := range l.Opts {
+			opts[k] = v
+		}
+		for k, v := range jsnCfg.Opts {
+			opts[k] = v
+		}
+		l.Opts = opts
+	}
 	if jsnCfg.Data != nil {
 		data := make([]*LoaderDataType, len(*jsnCfg.Data))
 		for idx, jsnLoCfg := range *jsnCfg.Data {
@@ -200,11 +219,19 @@ func (l LoaderSCfg) Clone() (cln *LoaderSCfg) {
 		FieldSeparator: l.FieldSeparator,
 		TpInDir:        l.TpInDir,
 		TpOutDir:       l.TpOutDir,
+		Type:           l.Type,
+		Transactional:  l.Transactional,
 		Data:           make([]*LoaderDataType, len(l.Data)),
 	}
 	for idx, connID := range l.CacheSConns {
 		cln.CacheSConns[idx] = connID
 	}
+	if l.Opts != nil {
+		cln.Opts = make(map[string]interface{})
+		for k, v := range l.Opts {
+			cln.Opts[k] = v
+		}
+	}
 	for idx, fld := range l.Data {
 		cln.Data[idx] = fld.Clone()
 	}
@@ -230,16 +257,23 @@ func (lData *LoaderDataType) AsMapInterface(separator string) (initialMP map[str
 // AsMapInterface returns the config as a map[string]interface{}
 func (l *LoaderSCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.IDCfg:           l.ID,
-		utils.TenantCfg:       l.Tenant.GetRule(separator),
-		utils.EnabledCfg:      l.Enabled,
-		utils.DryRunCfg:       l.DryRun,
-		utils.LockFileNameCfg: l.LockFileName,
-		utils.FieldSepCfg:     l.FieldSeparator,
-		utils.TpInDirCfg:      l.TpInDir,
-		utils.TpOutDirCfg:     l.TpOutDir,
-		utils.RunDelayCfg:     "0",
-	}
+		utils.IDCfg:            l.ID,
+		utils.TenantCfg:        l.Tenant.GetRule(separator),
+		utils.EnabledCfg:       l.Enabled,
+		utils.DryRunCfg:        l.DryRun,
+		utils.LockFileNameCfg:  l.LockFileName,
+		utils.FieldSepCfg:      l.FieldSeparator,
+		utils.TpInDirCfg:       l.TpInDir,
+		utils.TpOutDirCfg:      l.TpOutDir,
+		utils.TypeCfg:          l.Type,
+		utils.TransactionalCfg: l.Transactional,
+		utils.RunDelayCfg:      "0",
+	}
+	opts := make(map[string]interface{})
+	for k, v := range l.Opts {
+		opts[k] = v
+	}
+	initialMP[utils.OptsCfg] = opts
 	if l.Data != nil {
 		data := make([]map[string]interface{}, len(l.Data))
 		for i, item := range l.Data {
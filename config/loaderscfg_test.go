@@ -68,6 +68,9 @@ func TestLoaderSCfgloadFromJsonCfgCase1(t *testing.T) {
 			FieldSeparator: ",",
 			TpInDir:        "/var/spool/cgrates/loader/in",
 			TpOutDir:       "/var/spool/cgrates/loader/out",
+			Type:           utils.MetaFileCSV,
+			Opts:           map[string]interface{}{},
+			Transactional:  false,
 			Data: []*LoaderDataType{
 				{
 					Type:     "*attributes",
@@ -279,16 +282,19 @@ func TestLoaderCfgAsMapInterfaceCase1(t *testing.T) {
 }`
 	eMap := []map[string]interface{}{
 		{
-			utils.IDCfg:           "*default",
-			utils.EnabledCfg:      false,
-			utils.TenantCfg:       "~*req.Destination1",
-			utils.DryRunCfg:       false,
-			utils.RunDelayCfg:     "0",
-			utils.LockFileNameCfg: ".cgr.lck",
-			utils.CachesConnsCfg:  []string{utils.MetaInternal},
-			utils.FieldSepCfg:     ",",
-			utils.TpInDirCfg:      "/var/spool/cgrates/loader/in",
-			utils.TpOutDirCfg:     "/var/spool/cgrates/loader/out",
+			utils.IDCfg:            "*default",
+			utils.EnabledCfg:       false,
+			utils.TenantCfg:        "~*req.Destination1",
+			utils.DryRunCfg:        false,
+			utils.RunDelayCfg:      "0",
+			utils.LockFileNameCfg:  ".cgr.lck",
+			utils.CachesConnsCfg:   []string{utils.MetaInternal},
+			utils.FieldSepCfg:      ",",
+			utils.TpInDirCfg:       "/var/spool/cgrates/loader/in",
+			utils.TpOutDirCfg:      "/var/spool/cgrates/loader/out",
+			utils.TypeCfg:          utils.MetaFileCSV,
+			utils.OptsCfg:          map[string]interface{}{},
+			utils.TransactionalCfg: false,
 			utils.DataCfg: []map[string]interface{}{
 				{
 					utils.TypeCfg:     "*attributes",
@@ -363,16 +369,19 @@ func TestLoaderCfgAsMapInterfaceCase2(t *testing.T) {
 }`
 	eMap := []map[string]interface{}{
 		{
-			utils.IDCfg:           "*default",
-			utils.EnabledCfg:      false,
-			utils.TenantCfg:       "~*req.Destination1",
-			utils.DryRunCfg:       false,
-			utils.RunDelayCfg:     "0",
-			utils.LockFileNameCfg: ".cgr.lck",
-			utils.CachesConnsCfg:  []string{"*conn1"},
-			utils.FieldSepCfg:     ",",
-			utils.TpInDirCfg:      "/var/spool/cgrates/loader/in",
-			utils.TpOutDirCfg:     "/var/spool/cgrates/loader/out",
+			utils.IDCfg:            "*default",
+			utils.EnabledCfg:       false,
+			utils.TenantCfg:        "~*req.Destination1",
+			utils.DryRunCfg:        false,
+			utils.RunDelayCfg:      "0",
+			utils.LockFileNameCfg:  ".cgr.lck",
+			utils.CachesConnsCfg:   []string{"*conn1"},
+			utils.FieldSepCfg:      ",",
+			utils.TpInDirCfg:       "/var/spool/cgrates/loader/in",
+			utils.TpOutDirCfg:      "/var/spool/cgrates/loader/out",
+			utils.TypeCfg:          utils.MetaFileCSV,
+			utils.OptsCfg:          map[string]interface{}{},
+			utils.TransactionalCfg: false,
 			utils.DataCfg: []map[string]interface{}{
 				{
 					utils.TypeCfg:     "*attributes",
@@ -63,6 +63,7 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		ListenAcct:         "127.0.0.1:1813",
 		ClientSecrets:      map[string]string{utils.MetaDefault: "CGRateS.org"},
 		ClientDictionaries: map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
+		ClientDaAddresses:  map[string]string{},
 		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
 		RequestProcessors: []*RequestProcessor{
 			{
@@ -155,7 +156,11 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 	     "client_dictionaries": {									
 	    	"*default": "/usr/share/cgrates/",			
 	     },
+	     "client_da_addresses": {
+	    	"127.0.0.1": "127.0.0.1:3799",
+	     },
 	     "sessions_conns": ["*birpc_internal", "*conn1","*conn2"],
+	     "dmr_template": "*dmr",
          "request_processors": [
 			{
 				"id": "OutboundAUTHDryRun",
@@ -181,7 +186,12 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/",
 		},
+		utils.ClientDaAddressesCfg: map[string]string{
+			"127.0.0.1": "127.0.0.1:3799",
+		},
 		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
+		utils.CoATemplateCfg:   "",
+		utils.DMRTemplateCfg:   "*dmr",
 		utils.RequestProcessorsCfg: []map[string]interface{}{
 			{
 				utils.IDCfg:            "OutboundAUTHDryRun",
@@ -218,7 +228,10 @@ func TestRadiusAgentCfgAsMapInterface1(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		},
-		utils.SessionSConnsCfg:     []string{"*internal"},
+		utils.ClientDaAddressesCfg: map[string]string{},
+		utils.SessionSConnsCfg:     []string{rpcclient.BiRPCInternal},
+		utils.CoATemplateCfg:       "",
+		utils.DMRTemplateCfg:       "",
 		utils.RequestProcessorsCfg: []map[string]interface{}{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
@@ -112,6 +112,7 @@ type SessionSCfg struct {
 	TerminateAttempts   int
 	AlterableFields     utils.StringSet
 	MinDurLowBalance    time.Duration
+	BackupInterval      time.Duration
 	SchedulerConns      []string
 	STIRCfg             *STIRcfg
 	DefaultUsage        map[string]time.Duration
@@ -282,6 +283,11 @@ func (scfg *SessionSCfg) loadFromJSONCfg(jsnCfg *SessionSJsonCfg) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.Backup_interval != nil {
+		if scfg.BackupInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Backup_interval); err != nil {
+			return err
+		}
+	}
 	if jsnCfg.Default_usage != nil {
 		for k, v := range *jsnCfg.Default_usage {
 			if scfg.DefaultUsage[k], err = utils.ParseDurationWithNanosecs(v); err != nil {
@@ -335,6 +341,7 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.DebitIntervalCfg:       "0",
 		utils.SessionTTLCfg:          "0",
 		utils.DefaultUsageCfg:        maxComputed,
+		utils.BackupIntervalCfg:      "0",
 	}
 	if scfg.DebitInterval != 0 {
 		initialMP[utils.DebitIntervalCfg] = scfg.DebitInterval.String()
@@ -360,6 +367,9 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	if scfg.MinDurLowBalance != 0 {
 		initialMP[utils.MinDurLowBalanceCfg] = scfg.MinDurLowBalance.String()
 	}
+	if scfg.BackupInterval != 0 {
+		initialMP[utils.BackupIntervalCfg] = scfg.BackupInterval.String()
+	}
 	if scfg.ChargerSConns != nil {
 		chargerSConns := make([]string, len(scfg.ChargerSConns))
 		for i, item := range scfg.ChargerSConns {
@@ -465,6 +475,7 @@ func (scfg SessionSCfg) Clone() (cln *SessionSCfg) {
 		ChannelSyncInterval: scfg.ChannelSyncInterval,
 		TerminateAttempts:   scfg.TerminateAttempts,
 		MinDurLowBalance:    scfg.MinDurLowBalance,
+		BackupInterval:      scfg.BackupInterval,
 
 		SessionIndexes:  scfg.SessionIndexes.Clone(),
 		AlterableFields: scfg.AlterableFields.Clone(),
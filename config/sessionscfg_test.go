@@ -84,6 +84,7 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		Terminate_attempts:    utils.IntPointer(6),
 		Alterable_fields:      &[]string{},
 		Min_dur_low_balance:   utils.StringPointer("1"),
+		Backup_interval:       utils.StringPointer("-1"),
 		Scheduler_conns:       &[]string{utils.MetaInternal, "*conn1"},
 		Stir: &STIRJsonCfg{
 			Allowed_attest:      &[]string{utils.MetaAny},
@@ -114,6 +115,7 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		TerminateAttempts:   6,
 		AlterableFields:     utils.StringSet{},
 		MinDurLowBalance:    1,
+		BackupInterval:      -1,
 		SchedulerConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler), "*conn1"},
 		STIRCfg: &STIRcfg{
 			AllowedAttest:      utils.StringSet{utils.MetaAny: {}},
@@ -192,6 +194,17 @@ func TestSessionSCfgloadFromJsonCfgCase8(t *testing.T) {
 	}
 }
 
+func TestSessionSCfgloadFromJsonCfgBackupInterval(t *testing.T) {
+	cfgJSON := &SessionSJsonCfg{
+		Backup_interval: utils.StringPointer("1ss"),
+	}
+	expected := "time: unknown unit \"ss\" in duration \"1ss\""
+	jsonCfg := NewDefaultCGRConfig()
+	if err = jsonCfg.sessionSCfg.loadFromJSONCfg(cfgJSON); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+}
+
 func TestSessionSCfgloadFromJsonCfgCase9(t *testing.T) {
 	cfgJSON := &SessionSJsonCfg{
 		Session_ttl_last_usage: utils.StringPointer("1ss"),
@@ -365,6 +378,7 @@ func TestSessionSCfgAsMapInterfaceCase1(t *testing.T) {
 		utils.ChannelSyncIntervalCfg: "1s",
 		utils.TerminateAttemptsCfg:   5,
 		utils.MinDurLowBalanceCfg:    "0",
+		utils.BackupIntervalCfg:      "0",
 		utils.AlterableFieldsCfg:     []string{},
 		utils.STIRCfg: map[string]interface{}{
 			utils.AllowedAtestCfg:       []string{"*any"},
@@ -434,6 +448,7 @@ func TestSessionSCfgAsMapInterfaceCase2(t *testing.T) {
 		utils.DebitIntervalCfg:       "8s",
 		utils.StoreSCostsCfg:         true,
 		utils.MinDurLowBalanceCfg:    "1s",
+		utils.BackupIntervalCfg:      "0",
 		utils.SessionTTLCfg:          "1s",
 		utils.SessionIndexesCfg:      []string{},
 		utils.ClientProtocolCfg:      2.0,
@@ -33,6 +33,7 @@ type ThresholdSCfg struct {
 	PrefixIndexedFields *[]string
 	SuffixIndexedFields *[]string
 	NestedFields        bool
+	ActionSConns        []string
 }
 
 func (t *ThresholdSCfg) loadFromJSONCfg(jsnCfg *ThresholdSJsonCfg) (err error) {
@@ -74,6 +75,16 @@ func (t *ThresholdSCfg) loadFromJSONCfg(jsnCfg *ThresholdSJsonCfg) (err error) {
 	if jsnCfg.Nested_fields != nil {
 		t.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Actions_conns != nil {
+		t.ActionSConns = make([]string, len(*jsnCfg.Actions_conns))
+		for idx, conn := range *jsnCfg.Actions_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			t.ActionSConns[idx] = conn
+			if conn == utils.MetaInternal {
+				t.ActionSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaActions)
+			}
+		}
+	}
 	return nil
 }
 
@@ -110,6 +121,16 @@ func (t *ThresholdSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.SuffixIndexedFieldsCfg] = suffixIndexedFields
 	}
+	if t.ActionSConns != nil {
+		actionSConns := make([]string, len(t.ActionSConns))
+		for i, item := range t.ActionSConns {
+			actionSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaActions) {
+				actionSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.ActionSConnsCfg] = actionSConns
+	}
 	return
 }
 
@@ -121,6 +142,12 @@ func (t ThresholdSCfg) Clone() (cln *ThresholdSCfg) {
 		StoreInterval:  t.StoreInterval,
 		NestedFields:   t.NestedFields,
 	}
+	if t.ActionSConns != nil {
+		cln.ActionSConns = make([]string, len(t.ActionSConns))
+		for i, con := range t.ActionSConns {
+			cln.ActionSConns[i] = con
+		}
+	}
 
 	if t.StringIndexedFields != nil {
 		idx := make([]string, len(*t.StringIndexedFields))
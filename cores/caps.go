@@ -37,7 +37,7 @@ type conn interface {
 }
 
 func newCapsGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r rpc.ServerCodec) {
-	r = newCapsServerCodec(newGobServerCodec(conn), caps)
+	r = newMetricsServerCodec(newCapsServerCodec(newGobServerCodec(conn), caps))
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
@@ -55,7 +55,7 @@ func newCapsGOBCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerServic
 }
 
 func newCapsJSONCodec(conn conn, caps *engine.Caps, anz *analyzers.AnalyzerService) (r rpc.ServerCodec) {
-	r = newCapsServerCodec(jsonrpc.NewServerCodec(conn), caps)
+	r = newMetricsServerCodec(newCapsServerCodec(jsonrpc.NewServerCodec(conn), caps))
 	if anz != nil {
 		from := conn.RemoteAddr()
 		var fromstr string
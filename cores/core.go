@@ -20,6 +20,7 @@ package cores
 
 import (
 	"fmt"
+	"net/url"
 	"runtime"
 
 	"github.com/cgrates/cgrates/config"
@@ -32,14 +33,18 @@ func NewCoreService(cfg *config.CGRConfig, caps *engine.Caps, stopChan chan stru
 	if caps.IsLimited() && cfg.CoreSCfg().CapsStatsInterval != 0 {
 		st = engine.NewCapsStats(cfg.CoreSCfg().CapsStatsInterval, caps, stopChan)
 	}
-	return &CoreService{
+	cS := &CoreService{
 		cfg:       cfg,
+		caps:      caps,
 		CapsStats: st,
 	}
+	engine.Metrics.RegisterCollector(utils.CoreS, cS.collectMetrics)
+	return cS
 }
 
 type CoreService struct {
 	cfg       *config.CGRConfig
+	caps      *engine.Caps
 	CapsStats *engine.CapsStats
 }
 
@@ -50,6 +55,27 @@ func (cS *CoreService) Shutdown() {
 	return
 }
 
+// collectMetrics adds the caps usage and the runtime metrics
+func (cS *CoreService) collectMetrics(pm *utils.PrometheusMetrics, _ url.Values) {
+	memstats := new(runtime.MemStats)
+	runtime.ReadMemStats(memstats)
+	pm.Add("cgrates_goroutines", utils.PrometheusGauge,
+		"Number of active goroutines.", nil, float64(runtime.NumGoroutine()))
+	pm.Add("cgrates_memory_heap_bytes", utils.PrometheusGauge,
+		"Bytes of allocated heap objects.", nil, float64(memstats.HeapAlloc))
+	if cS.caps == nil || !cS.caps.IsLimited() {
+		return
+	}
+	pm.Add("cgrates_caps_allocated", utils.PrometheusGauge,
+		"Number of API requests actively serviced.", nil, float64(cS.caps.Allocated()))
+	if cS.CapsStats != nil {
+		pm.Add("cgrates_caps_peak", utils.PrometheusGauge,
+			"Maximum number of concurrent API requests.", nil, float64(cS.CapsStats.GetPeak()))
+		pm.Add("cgrates_caps_average", utils.PrometheusGauge,
+			"Average number of concurrent API requests.", nil, cS.CapsStats.GetAverage(cS.cfg.GeneralCfg().RoundingDecimals))
+	}
+}
+
 // Status returns the status of the engine
 func (cS *CoreService) Status(arg *utils.TenantWithOpts, reply *map[string]interface{}) (err error) {
 	memstats := new(runtime.MemStats)
@@ -64,6 +90,10 @@ func (cS *CoreService) Status(arg *utils.TenantWithOpts, reply *map[string]inter
 	}
 	response[utils.RunningSince] = utils.GetStartTime()
 	response[utils.GoVersion] = runtime.Version()
+	if cS.caps != nil && cS.caps.IsLimited() { // report the load so the dispatchers can balance the requests
+		response[utils.CapsAllocated] = cS.caps.Allocated()
+		response[utils.CapsLimit] = cS.caps.Limit()
+	}
 	*reply = response
 	return
 }
@@ -37,6 +37,7 @@ func TestNewCoreService(t *testing.T) {
 	sts := engine.NewCapsStats(cfgDflt.CoreSCfg().CapsStatsInterval, caps, stopchan)
 	expected := &CoreService{
 		cfg:       cfgDflt,
+		caps:      caps,
 		CapsStats: sts,
 	}
 
@@ -74,6 +75,8 @@ func TestCoreServiceStatus(t *testing.T) {
 		utils.ActiveGoroutines: runtime.NumGoroutine(),
 		utils.MemoryUsage:      "CHANGED_MEMORY_USAGE",
 		utils.NodeID:           cfgDflt.GeneralCfg().NodeID,
+		utils.CapsAllocated:    0,
+		utils.CapsLimit:        1,
 	}
 	if err := cores.Status(args, &reply); err != nil {
 		t.Error(err)
@@ -0,0 +1,90 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"net/http"
+	"net/rpc"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+)
+
+// newMetricsServerCodec records the API calls if the prometheus endpoint is enabled
+func newMetricsServerCodec(sc rpc.ServerCodec) rpc.ServerCodec {
+	if !engine.Metrics.RPCStatsEnabled() {
+		return sc
+	}
+	return &metricsServerCodec{
+		sc:   sc,
+		reqs: make(map[uint64]*metricsRequest),
+	}
+}
+
+type metricsRequest struct {
+	method string
+	start  time.Time
+}
+
+type metricsServerCodec struct {
+	sc   rpc.ServerCodec
+	mux  sync.Mutex
+	reqs map[uint64]*metricsRequest // indexed on request sequence
+}
+
+func (c *metricsServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	if err = c.sc.ReadRequestHeader(r); err != nil {
+		return
+	}
+	c.mux.Lock()
+	c.reqs[r.Seq] = &metricsRequest{method: r.ServiceMethod, start: time.Now()}
+	c.mux.Unlock()
+	return
+}
+
+func (c *metricsServerCodec) ReadRequestBody(x interface{}) error {
+	return c.sc.ReadRequestBody(x)
+}
+
+func (c *metricsServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	c.mux.Lock()
+	req, has := c.reqs[r.Seq]
+	delete(c.reqs, r.Seq)
+	c.mux.Unlock()
+	if has {
+		engine.Metrics.ObserveRPC(req.method, time.Since(req.start), r.Error != "")
+	}
+	return c.sc.WriteResponse(r, x)
+}
+
+func (c *metricsServerCodec) Close() error { return c.sc.Close() }
+
+// RegisterPrometheus enables the metrics endpoint in the Prometheus text format
+func (s *Server) RegisterPrometheus(pattern string) {
+	engine.Metrics.EnableRPCStats()
+	s.RegisterHttpFunc(pattern, handlePrometheus)
+}
+
+func handlePrometheus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := engine.Metrics.WriteTo(w, r.URL.Query()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
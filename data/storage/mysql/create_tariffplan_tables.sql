@@ -261,6 +261,7 @@ CREATE TABLE tp_resources (
   `stored` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `rate_window` varchar(32) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -288,6 +289,9 @@ CREATE TABLE tp_stats (
   `blocker` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `bucket_width` varchar(32) NOT NULL,
+  `window` varchar(32) NOT NULL,
+  `window_type` varchar(16) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -313,6 +317,7 @@ CREATE TABLE tp_thresholds (
   `weight` decimal(8,2) NOT NULL,
   `action_ids` varchar(64) NOT NULL,
   `async` BOOLEAN NOT NULL,
+  `action_profile_ids` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -496,6 +501,8 @@ CREATE TABLE tp_rate_profiles (
   `rate_recurrent_fee` decimal(8,4) NOT NULL,
   `rate_unit` varchar(64) NOT NULL,
   `rate_increment` varchar(64) NOT NULL,
+  `volume_usage` varchar(64) NOT NULL,
+  `volume_tiers` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
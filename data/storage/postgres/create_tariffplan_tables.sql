@@ -257,6 +257,7 @@ CREATE TABLE tp_resources (
   "stored" BOOLEAN NOT NULL,
   "weight" NUMERIC(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "rate_window" varchar(32) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_resources_idx ON tp_resources (tpid);
@@ -284,6 +285,9 @@ CREATE TABLE tp_stats (
   "blocker" BOOLEAN NOT NULL,
   "weight" decimal(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "bucket_width" varchar(32) NOT NULL,
+  "window" varchar(32) NOT NULL,
+  "window_type" varchar(16) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_stats_idx ON tp_stats (tpid);
@@ -308,6 +312,7 @@ CREATE TABLE tp_thresholds (
   "weight" decimal(8,2) NOT NULL,
   "action_ids" varchar(64) NOT NULL,
   "async" BOOLEAN NOT NULL,
+  "action_profile_ids" varchar(64) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_thresholds_idx ON tp_thresholds (tpid);
@@ -481,6 +486,8 @@ CREATE INDEX tp_routes_unique ON tp_routes  ("tpid",  "tenant", "id",
   "rate_recurrent_fee" decimal(8,4) NOT NULL,
   "rate_unit" VARCHAR(64) NOT NULL,
   "rate_increment" VARCHAR(64) NOT NULL,
+  "volume_usage" VARCHAR(64) NOT NULL,
+  "volume_tiers" VARCHAR(64) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
   );
   CREATE INDEX tp_rate_profiles_ids ON tp_rate_profiles (tpid);
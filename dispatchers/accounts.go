@@ -141,3 +141,85 @@ func (dS *DispatcherService) AccountSv1ActionRemoveBalance(args *utils.ArgsActRe
 		Opts:   args.Opts,
 	}, utils.MetaAccounts, utils.AccountSv1ActionRemoveBalance, args, reply)
 }
+
+func (dS *DispatcherService) AccountSv1ActionResetAccount(args *utils.ArgsActResetAccount, reply *string) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1ActionResetAccount, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaAccounts, utils.AccountSv1ActionResetAccount, args, reply)
+}
+
+func (dS *DispatcherService) AccountSv1ActionRolloverBalances(args *utils.ArgsActRolloverBalances, reply *string) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1ActionRolloverBalances, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaAccounts, utils.AccountSv1ActionRolloverBalances, args, reply)
+}
+
+func (dS *DispatcherService) AccountSv1ReserveAbstracts(args *utils.ArgsAccountsForEvent, reply *utils.AccountsReservation) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.CGREvent != nil && args.CGREvent.Tenant != utils.EmptyString {
+		tnt = args.CGREvent.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1ReserveAbstracts, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.MetaAccounts, utils.AccountSv1ReserveAbstracts, args, reply)
+}
+
+func (dS *DispatcherService) AccountSv1CommitReservation(args *utils.ArgsAccountsReservation, reply *utils.ExtEventCharges) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1CommitReservation, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaAccounts, utils.AccountSv1CommitReservation, args, reply)
+}
+
+func (dS *DispatcherService) AccountSv1ReleaseReservation(args *utils.ArgsAccountsReservation, reply *string) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1ReleaseReservation, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaAccounts, utils.AccountSv1ReleaseReservation, args, reply)
+}
@@ -67,3 +67,23 @@ func (dS *DispatcherService) ActionSv1ExecuteActions(args *utils.ArgActionSv1Sch
 	}
 	return dS.Dispatch(args.CGREvent, utils.MetaActions, utils.ActionSv1Ping, args, rpl)
 }
+
+func (dS *DispatcherService) ActionSv1DryRunActions(args *utils.ArgActionSv1ScheduleActions, rpl *[]*utils.ActionDiff) (err error) {
+	if args == nil {
+		args = new(utils.ArgActionSv1ScheduleActions)
+	}
+	if args.CGREvent == nil {
+		args.CGREvent = new(utils.CGREvent)
+	}
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.CGREvent != nil && args.CGREvent.Tenant != utils.EmptyString {
+		tnt = args.CGREvent.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ActionSv1DryRunActions, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.MetaActions, utils.ActionSv1DryRunActions, args, rpl)
+}
@@ -189,6 +189,7 @@ func testDspChcPrecacheStatus(t *testing.T) {
 		utils.CacheDispatcherProfiles:           utils.MetaReady,
 		utils.CacheDispatcherHosts:              utils.MetaReady,
 		utils.CacheDiameterMessages:             utils.MetaReady,
+		utils.CacheRadiusPackets:                utils.MetaReady,
 		utils.CacheAttributeFilterIndexes:       utils.MetaReady,
 		utils.CacheResourceFilterIndexes:        utils.MetaReady,
 		utils.CacheStatFilterIndexes:            utils.MetaReady,
@@ -220,6 +221,8 @@ func testDspChcPrecacheStatus(t *testing.T) {
 		utils.CacheAccountProfiles:              utils.MetaReady,
 
 		utils.CacheAccounts:              utils.MetaReady,
+		utils.CacheSessionsBackup:        utils.MetaReady,
+		utils.CacheRerateJobs:            utils.MetaReady,
 		utils.CacheVersions:              utils.MetaReady,
 		utils.CacheTBLTPTimings:          utils.MetaReady,
 		utils.CacheTBLTPDestinations:     utils.MetaReady,
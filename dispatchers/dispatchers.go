@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -35,10 +36,11 @@ func NewDispatcherService(dm *engine.DataManager,
 	cfg *config.CGRConfig, fltrS *engine.FilterS,
 	connMgr *engine.ConnManager) *DispatcherService {
 	return &DispatcherService{
-		dm:      dm,
-		cfg:     cfg,
-		fltrS:   fltrS,
-		connMgr: connMgr,
+		dm:        dm,
+		cfg:       cfg,
+		fltrS:     fltrS,
+		connMgr:   connMgr,
+		stopProbe: make(chan struct{}),
 	}
 }
 
@@ -49,14 +51,89 @@ type DispatcherService struct {
 	cfg     *config.CGRConfig
 	fltrS   *engine.FilterS
 	connMgr *engine.ConnManager
+
+	stopProbe chan struct{}
 }
 
 // Shutdown is called to shutdown the service
 func (dS *DispatcherService) Shutdown() {
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown initialized", utils.DispatcherS))
+	close(dS.stopProbe)
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown complete", utils.DispatcherS))
 }
 
+// StartLoop starts the gorutine probing the hosts
+func (dS *DispatcherService) StartLoop() {
+	if dS.cfg.DispatcherSCfg().ProbeInterval <= 0 {
+		return
+	}
+	go dS.runProbe()
+}
+
+// runProbe will regularly ping the DispatcherHosts updating their health
+func (dS *DispatcherService) runProbe() {
+	probeInterval := dS.cfg.DispatcherSCfg().ProbeInterval
+	for {
+		dS.probeHosts(probeInterval)
+		select {
+		case <-dS.stopProbe:
+			return
+		case <-time.After(probeInterval):
+		}
+	}
+}
+
+// probeHosts pings in parallel all the DispatcherHosts from dataDB
+func (dS *DispatcherService) probeHosts(timeout time.Duration) {
+	keys, err := dS.dm.DataDB().GetKeysForPrefix(utils.DispatcherHostPrefix)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed querying the hosts for probing, error: %s",
+			utils.DispatcherS, err.Error()))
+		return
+	}
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		tntID := utils.NewTenantID(key[len(utils.DispatcherHostPrefix):])
+		dH, err := dS.dm.GetDispatcherHost(tntID.Tenant, tntID.ID, true, true, utils.NonTransactional)
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed retrieving host <%s> for probing, error: %s",
+				utils.DispatcherS, tntID.TenantID(), err.Error()))
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			dS.probeHost(dH, timeout)
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+}
+
+// probeHost calls CoreSv1.Status on the host
+// a host not replying within timeout is considered failed
+func (dS *DispatcherService) probeHost(dH *engine.DispatcherHost, timeout time.Duration) {
+	var status map[string]interface{}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- dH.Call(utils.CoreSv1Status, &utils.TenantWithOpts{Tenant: dH.Tenant}, &status)
+	}()
+	select {
+	case err := <-errChan:
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> probing host <%s> failed, error: %s",
+				utils.DispatcherS, dH.TenantID(), err.Error()))
+			dspHealth.failure(dH.TenantID())
+			return
+		}
+		dspHealth.setLoad(dH.TenantID(), capsLoad(status))
+		dspHealth.success(dH.TenantID())
+	case <-time.After(timeout):
+		utils.Logger.Warning(fmt.Sprintf("<%s> probing host <%s> failed, error: %s",
+			utils.DispatcherS, dH.TenantID(), rpcclient.ErrReplyTimeout.Error()))
+		dspHealth.failure(dH.TenantID())
+	}
+}
+
 func (dS *DispatcherService) authorizeEvent(ev *utils.CGREvent,
 	reply *engine.AttrSProcessEventReply) (err error) {
 	if err = dS.connMgr.Call(dS.cfg.DispatcherSCfg().AttributeSConns, nil,
@@ -194,7 +271,7 @@ func (dS *DispatcherService) Dispatch(ev *utils.CGREvent, subsys string,
 	if errCh := engine.Cache.Set(utils.CacheDispatchers, tntID, d, nil, true, utils.EmptyString); errCh != nil {
 		return utils.NewErrDispatcherS(errCh)
 	}
-	return d.Dispatch(utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsys, serviceMethod, args, reply)
+	return d.Dispatch(ev, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsys, serviceMethod, args, reply)
 }
 
 func (dS *DispatcherService) V1GetProfileForEvent(ev *utils.CGREvent,
@@ -0,0 +1,99 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatchers
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ringPoint is one virtual node of a host on the hash ring
+type ringPoint struct {
+	hash   uint64
+	hostID string
+}
+
+// hashRing distributes the keys over the hosts using consistent hashing
+// adding or removing a host moves only the keys owned by its virtual nodes
+type hashRing struct {
+	points  []ringPoint // sorted on hash
+	nrHosts int
+}
+
+// newHashRing builds the ring placing vNodes virtual nodes for each unit of host weight
+// the hosts without weight receive the virtual nodes of one unit
+func newHashRing(hosts engine.DispatcherHostProfiles, vNodes int) (hr *hashRing) {
+	hr = &hashRing{nrHosts: len(hosts)}
+	for _, host := range hosts {
+		weight := host.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		hostVNodes := int(math.Round(weight * float64(vNodes)))
+		if hostVNodes < 1 {
+			hostVNodes = 1
+		}
+		for i := 0; i < hostVNodes; i++ {
+			hr.points = append(hr.points, ringPoint{
+				hash:   hashKey(host.ID + utils.InInFieldSep + strconv.Itoa(i)),
+				hostID: host.ID,
+			})
+		}
+	}
+	sort.Slice(hr.points, func(i, j int) bool {
+		if hr.points[i].hash == hr.points[j].hash { // make the ring independent of the hosts order
+			return hr.points[i].hostID < hr.points[j].hostID
+		}
+		return hr.points[i].hash < hr.points[j].hash
+	})
+	return
+}
+
+// hostIDs returns the distinct hosts met walking the ring clockwise from the key
+// the first one owns the key while the rest are used for failover
+func (hr *hashRing) hostIDs(key string) (hostIDs engine.DispatcherHostIDs) {
+	if len(hr.points) == 0 {
+		return
+	}
+	hash := hashKey(key)
+	idx := sort.Search(len(hr.points), func(i int) bool { return hr.points[i].hash >= hash })
+	hostIDs = make(engine.DispatcherHostIDs, 0, hr.nrHosts)
+	added := make(utils.StringSet)
+	for i := 0; i < len(hr.points) && len(hostIDs) < hr.nrHosts; i++ {
+		pt := hr.points[(idx+i)%len(hr.points)]
+		if added.Has(pt.hostID) {
+			continue
+		}
+		added.Add(pt.hostID)
+		hostIDs = append(hostIDs, pt.hostID)
+	}
+	return
+}
+
+// hashKey returns the position of the key on the ring
+func hashKey(key string) uint64 {
+	sum := sha1.Sum([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
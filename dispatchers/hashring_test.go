@@ -0,0 +1,202 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatchers
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestHashRingHostIDs(t *testing.T) {
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "DSP_1"},
+		{ID: "DSP_2"},
+		{ID: "DSP_3"},
+	}
+	hr := newHashRing(hosts, utils.DefaultVNodes)
+	rply := hr.hostIDs("1001")
+	if len(rply) != 3 {
+		t.Fatalf("Expected all the hosts for failover, received: %q", rply)
+	}
+	if !utils.NewStringSet(rply).Has("DSP_1") ||
+		!utils.NewStringSet(rply).Has("DSP_2") ||
+		!utils.NewStringSet(rply).Has("DSP_3") {
+		t.Errorf("Expected distinct hosts, received: %q", rply)
+	}
+	// the order of the hosts in the profile should not matter
+	hr2 := newHashRing(engine.DispatcherHostProfiles{hosts[2], hosts[0], hosts[1]}, utils.DefaultVNodes)
+	if rply2 := hr2.hostIDs("1001"); !reflect.DeepEqual(rply, rply2) {
+		t.Errorf("Expected: %q ,received: %q", rply, rply2)
+	}
+	if rply := new(hashRing).hostIDs("1001"); len(rply) != 0 {
+		t.Errorf("Expected no hosts, received: %q", rply)
+	}
+}
+
+func TestHashRingAddHost(t *testing.T) {
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "DSP_1"},
+		{ID: "DSP_2"},
+		{ID: "DSP_3"},
+	}
+	hr := newHashRing(hosts, utils.DefaultVNodes)
+	hr2 := newHashRing(append(hosts, &engine.DispatcherHostProfile{ID: "DSP_4"}), utils.DefaultVNodes)
+	nrKeys := 10000
+	var moved int
+	for i := 0; i < nrKeys; i++ {
+		key := fmt.Sprintf("acnt%d", i)
+		owner, owner2 := hr.hostIDs(key)[0], hr2.hostIDs(key)[0]
+		if owner == owner2 {
+			continue
+		}
+		if owner2 != "DSP_4" {
+			t.Fatalf("Key %q moved from %q to %q instead of the new host", key, owner, owner2)
+		}
+		moved++
+	}
+	// about 1/4 of the keys should move to the new host
+	if moved < nrKeys/8 || moved > nrKeys*3/8 {
+		t.Errorf("Expected about %d keys moved, received: %d", nrKeys/4, moved)
+	}
+}
+
+func TestHashRingWeight(t *testing.T) {
+	hr := newHashRing(engine.DispatcherHostProfiles{
+		{ID: "DSP_1", Weight: 1},
+		{ID: "DSP_2", Weight: 3},
+	}, utils.DefaultVNodes)
+	owned := make(map[string]int)
+	nrKeys := 10000
+	for i := 0; i < nrKeys; i++ {
+		owned[hr.hostIDs(fmt.Sprintf("acnt%d", i))[0]]++
+	}
+	// the heavier host should receive about 3/4 of the keys
+	if owned["DSP_2"] < nrKeys*5/8 || owned["DSP_2"] > nrKeys*7/8 {
+		t.Errorf("Unexpected distribution: %+v", owned)
+	}
+}
+
+func TestNewDispatcherHashRing(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_HR",
+		Strategy: utils.MetaHashRing,
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "DSP_HR1", Weight: 20},
+			{ID: "DSP_HR2", Weight: 10},
+		},
+	}
+	if _, err := newDispatcher(nil, pfl); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.MetaHashField).Error() {
+		t.Errorf("Expected %v, received: %v", utils.NewErrMandatoryIeMissing(utils.MetaHashField), err)
+	}
+	pfl.StrategyParams = map[string]interface{}{
+		utils.MetaHashField:    "~*req.Account",
+		utils.MetaVirtualNodes: "10",
+	}
+	d, err := newDispatcher(nil, pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hrD, canCast := d.(*HashRingDispatcher)
+	if !canCast {
+		t.Fatalf("Expected *HashRingDispatcher, received: %T", d)
+	}
+	if hrD.vNodes != 10 {
+		t.Errorf("Expected: %v ,received: %v", 10, hrD.vNodes)
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+		},
+	}
+	exp := hrD.ring.hostIDs("1001")
+	if rply := hrD.hostIDsForEvent(ev); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %q ,received: %q", exp, rply)
+	}
+	// the events without the hash field keep the weight order
+	exp = engine.DispatcherHostIDs{"DSP_HR1", "DSP_HR2"}
+	if rply := hrD.hostIDsForEvent(&utils.CGREvent{Tenant: "cgrates.org"}); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %q ,received: %q", exp, rply)
+	}
+}
+
+func TestHashRingDispatcherSetProfile(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_HR",
+		Strategy: utils.MetaHashRing,
+		StrategyParams: map[string]interface{}{
+			utils.MetaHashField: "~*req.Account",
+		},
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "DSP_HR1", Weight: 20},
+			{ID: "DSP_HR2", Weight: 10},
+		},
+	}
+	d, err := newDispatcher(nil, pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hrD := d.(*HashRingDispatcher)
+	if hrD.vNodes != utils.DefaultVNodes {
+		t.Errorf("Expected: %v ,received: %v", utils.DefaultVNodes, hrD.vNodes)
+	}
+	hrD.SetProfile(&engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_HR",
+		Strategy: utils.MetaHashRing,
+		StrategyParams: map[string]interface{}{
+			utils.MetaHashField:    "~*req.Subject",
+			utils.MetaVirtualNodes: 5,
+		},
+		Hosts: pfl.Hosts,
+	})
+	if hrD.vNodes != 5 {
+		t.Errorf("Expected: %v ,received: %v", 5, hrD.vNodes)
+	} else if len(hrD.ring.points) != 150 { // vNodes for each unit of weight
+		t.Errorf("Expected %v points, received: %v", 150, len(hrD.ring.points))
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event: map[string]interface{}{
+			utils.Subject: "1002",
+		},
+	}
+	exp := hrD.ring.hostIDs("1002")
+	if rply := hrD.hostIDsForEvent(ev); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %q ,received: %q", exp, rply)
+	}
+	// invalid parameters keep the previous ones
+	hrD.SetProfile(&engine.DispatcherProfile{
+		Tenant:         "cgrates.org",
+		ID:             "DSP_HR",
+		Strategy:       utils.MetaHashRing,
+		StrategyParams: map[string]interface{}{utils.MetaVirtualNodes: 7},
+		Hosts:          pfl.Hosts,
+	})
+	if hrD.vNodes != 5 || hrD.hashFld.GetRule(utils.InfieldSep) != "~*req.Subject" {
+		t.Errorf("Expected the previous parameters, received: %v %q", hrD.vNodes, hrD.hashFld.GetRule(utils.InfieldSep))
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatchers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// the states of the host circuit breaker
+const (
+	breakerClosed   = iota // the host receives traffic
+	breakerOpen            // the host is excluded until the breaker timeout expires
+	breakerHalfOpen        // a single request is let through to test the host
+)
+
+// dspHealth is shared by all the dispatchers since the hosts are shared between profiles
+var dspHealth = newHostsHealth()
+
+func newHostsHealth() *hostsHealth {
+	return &hostsHealth{hosts: make(map[string]*hostHealth)}
+}
+
+// hostsHealth keeps the health of the DispatcherHosts indexed on host tenantID
+type hostsHealth struct {
+	sync.RWMutex
+	hosts map[string]*hostHealth
+}
+
+// hostHealth is the circuit breaker together with the load reported by one host
+type hostHealth struct {
+	sync.Mutex
+	state    int
+	failures int // consecutive failures
+	openedAt time.Time
+	load     float64 // caps usage received on the last probe
+}
+
+// getHost returns the health of the host, creating it on first use
+func (hH *hostsHealth) getHost(tntID string) (h *hostHealth) {
+	hH.RLock()
+	h, has := hH.hosts[tntID]
+	hH.RUnlock()
+	if has {
+		return
+	}
+	hH.Lock()
+	if h, has = hH.hosts[tntID]; !has {
+		h = new(hostHealth)
+		hH.hosts[tntID] = h
+	}
+	hH.Unlock()
+	return
+}
+
+// allow returns true if the host can receive the request
+// an expired open breaker is moved to half-open letting this request through
+func (hH *hostsHealth) allow(tntID string) bool {
+	dspCfg := config.CgrConfig().DispatcherSCfg()
+	if dspCfg.BreakerThreshold == 0 {
+		return true
+	}
+	h := hH.getHost(tntID)
+	h.Lock()
+	defer h.Unlock()
+	switch h.state {
+	case breakerOpen:
+		if time.Since(h.openedAt) < dspCfg.BreakerTimeout {
+			return false
+		}
+		h.state = breakerHalfOpen
+	case breakerHalfOpen: // the retry did not finish yet
+		return false
+	}
+	return true
+}
+
+// available returns false only for the hosts with the breaker open
+// used when the result of the request can not be attributed to the host
+func (hH *hostsHealth) available(tntID string) bool {
+	dspCfg := config.CgrConfig().DispatcherSCfg()
+	if dspCfg.BreakerThreshold == 0 {
+		return true
+	}
+	h := hH.getHost(tntID)
+	h.Lock()
+	defer h.Unlock()
+	return h.state != breakerOpen ||
+		time.Since(h.openedAt) >= dspCfg.BreakerTimeout
+}
+
+// report updates the breaker based on the error returned by the host
+// only the network errors are considered failures of the host
+func (hH *hostsHealth) report(tntID string, err error) {
+	if rpcclient.IsNetworkError(err) {
+		hH.failure(tntID)
+		return
+	}
+	hH.success(tntID)
+}
+
+// success closes the breaker of the host
+func (hH *hostsHealth) success(tntID string) {
+	h := hH.getHost(tntID)
+	h.Lock()
+	if h.state != breakerClosed {
+		utils.Logger.Info(fmt.Sprintf("<%s> host <%s> recovered, dispatching to it",
+			utils.DispatcherS, tntID))
+	}
+	h.state = breakerClosed
+	h.failures = 0
+	h.Unlock()
+}
+
+// failure counts the failure and opens the breaker once the threshold is reached
+func (hH *hostsHealth) failure(tntID string) {
+	dspCfg := config.CgrConfig().DispatcherSCfg()
+	if dspCfg.BreakerThreshold == 0 {
+		return
+	}
+	h := hH.getHost(tntID)
+	h.Lock()
+	h.failures++
+	if h.state == breakerHalfOpen ||
+		h.failures >= dspCfg.BreakerThreshold {
+		if h.state != breakerOpen {
+			utils.Logger.Warning(fmt.Sprintf("<%s> host <%s> failed %d times, excluding it for %s",
+				utils.DispatcherS, tntID, h.failures, dspCfg.BreakerTimeout))
+		}
+		h.state = breakerOpen
+		h.openedAt = time.Now()
+	}
+	h.Unlock()
+}
+
+// setLoad stores the load reported by the host
+func (hH *hostsHealth) setLoad(tntID string, load float64) {
+	h := hH.getHost(tntID)
+	h.Lock()
+	h.load = load
+	h.Unlock()
+}
+
+// getLoad returns the last load reported by the host
+func (hH *hostsHealth) getLoad(tntID string) (load float64) {
+	h := hH.getHost(tntID)
+	h.Lock()
+	load = h.load
+	h.Unlock()
+	return
+}
+
+// sortByLoad orders the hostIDs ascending on the reported load
+// keeping the weight order for the hosts with the same load
+func (hH *hostsHealth) sortByLoad(tnt string, hostIDs engine.DispatcherHostIDs) {
+	loads := make(map[string]float64, len(hostIDs))
+	for _, hostID := range hostIDs {
+		loads[hostID] = hH.getLoad(utils.ConcatenatedKey(tnt, hostID))
+	}
+	sort.SliceStable(hostIDs, func(i, j int) bool {
+		return loads[hostIDs[i]] < loads[hostIDs[j]]
+	})
+}
+
+// capsLoad computes the load of the host out of the CoreSv1.Status reply
+// the hosts without caps limit are considered idle
+func capsLoad(status map[string]interface{}) float64 {
+	lmt, err := utils.IfaceAsTInt64(status[utils.CapsLimit])
+	if err != nil || lmt <= 0 {
+		return 0
+	}
+	allocated, err := utils.IfaceAsTInt64(status[utils.CapsAllocated])
+	if err != nil {
+		return 0
+	}
+	return float64(allocated) / float64(lmt)
+}
@@ -0,0 +1,148 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatchers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestHostsHealthBreaker(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DispatcherSCfg().BreakerThreshold = 2
+	cfg.DispatcherSCfg().BreakerTimeout = 10 * time.Millisecond
+	config.SetCgrConfig(cfg)
+	defer config.SetCgrConfig(config.NewDefaultCGRConfig())
+
+	hH := newHostsHealth()
+	tntID := "cgrates.org:DSP_1"
+	if !hH.allow(tntID) {
+		t.Fatal("Expected the host to be allowed")
+	}
+	hH.report(tntID, utils.ErrNotFound) // not a network error
+	hH.report(tntID, rpcclient.ErrDisconnected)
+	if !hH.allow(tntID) {
+		t.Fatal("Expected the host to be allowed after one failure")
+	}
+	hH.report(tntID, rpcclient.ErrDisconnected)
+	if hH.allow(tntID) || hH.available(tntID) {
+		t.Fatal("Expected the breaker to be open")
+	}
+	time.Sleep(15 * time.Millisecond)
+	if !hH.available(tntID) {
+		t.Error("Expected the host to be available after the timeout")
+	}
+	if !hH.allow(tntID) {
+		t.Fatal("Expected the half-open retry to be allowed")
+	}
+	if hH.allow(tntID) {
+		t.Fatal("Expected a single half-open retry")
+	}
+	hH.report(tntID, rpcclient.ErrDisconnected)
+	if hH.allow(tntID) {
+		t.Fatal("Expected the breaker to be open after the failed retry")
+	}
+	time.Sleep(15 * time.Millisecond)
+	if !hH.allow(tntID) {
+		t.Fatal("Expected the half-open retry to be allowed")
+	}
+	hH.report(tntID, nil)
+	if !hH.allow(tntID) || !hH.allow(tntID) {
+		t.Error("Expected the breaker to be closed")
+	}
+}
+
+func TestHostsHealthBreakerDisabled(t *testing.T) {
+	hH := newHostsHealth()
+	tntID := "cgrates.org:DSP_1"
+	for i := 0; i < 5; i++ {
+		hH.report(tntID, rpcclient.ErrDisconnected)
+	}
+	if !hH.allow(tntID) || !hH.available(tntID) {
+		t.Error("Expected the host to be allowed with the breaker disabled")
+	}
+}
+
+func TestHostsHealthSortByLoad(t *testing.T) {
+	hH := newHostsHealth()
+	hH.setLoad("cgrates.org:DSP_1", 0.5)
+	hH.setLoad("cgrates.org:DSP_2", 0.8)
+	hH.setLoad("cgrates.org:DSP_4", 0.5)
+	hostIDs := engine.DispatcherHostIDs{"DSP_1", "DSP_2", "DSP_3", "DSP_4"}
+	hH.sortByLoad("cgrates.org", hostIDs)
+	exp := engine.DispatcherHostIDs{"DSP_3", "DSP_1", "DSP_4", "DSP_2"}
+	if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q ,received: %q", exp, hostIDs)
+	}
+}
+
+func TestCapsLoad(t *testing.T) {
+	if load := capsLoad(map[string]interface{}{
+		utils.CapsAllocated: 5,
+		utils.CapsLimit:     20,
+	}); load != 0.25 {
+		t.Errorf("Expected: %v ,received: %v", 0.25, load)
+	}
+	if load := capsLoad(map[string]interface{}{ // as received over JSON
+		utils.CapsAllocated: 10.,
+		utils.CapsLimit:     20.,
+	}); load != 0.5 {
+		t.Errorf("Expected: %v ,received: %v", 0.5, load)
+	}
+	if load := capsLoad(map[string]interface{}{
+		utils.NodeID: "node1",
+	}); load != 0 {
+		t.Errorf("Expected: %v ,received: %v", 0, load)
+	}
+}
+
+func TestNewDispatcherLeastLoaded(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_LL",
+		Strategy: utils.MetaLeastLoaded,
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "DSP_LL1", Weight: 20},
+			{ID: "DSP_LL2", Weight: 10},
+		},
+	}
+	d, err := newDispatcher(nil, pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, canCast := d.(*LeastLoadedDispatcher); !canCast {
+		t.Fatalf("Expected *LeastLoadedDispatcher, received: %T", d)
+	}
+	exp := engine.DispatcherHostIDs{"DSP_LL1", "DSP_LL2"}
+	if rply := d.HostIDs(); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %q ,received: %q", exp, rply)
+	}
+	dspHealth.setLoad("cgrates.org:DSP_LL1", 0.9)
+	dspHealth.setLoad("cgrates.org:DSP_LL2", 0.1)
+	exp = engine.DispatcherHostIDs{"DSP_LL2", "DSP_LL1"}
+	if rply := d.HostIDs(); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %q ,received: %q", exp, rply)
+	}
+}
@@ -47,7 +47,8 @@ type Dispatcher interface {
 	// HostIDs returns the ordered list of host IDs
 	HostIDs() (hostIDs engine.DispatcherHostIDs)
 	// Dispatch is used to send the method over the connections given
-	Dispatch(routeID string, subsystem,
+	// the event is used by the strategies choosing the hosts based on its fields
+	Dispatch(ev *utils.CGREvent, routeID string, subsystem,
 		serviceMethod string, args interface{}, reply interface{}) (err error)
 }
 
@@ -95,6 +96,15 @@ func newDispatcher(dm *engine.DataManager, pfl *engine.DispatcherProfile) (d Dis
 			hosts:    hosts,
 			strategy: strDsp,
 		}
+	case utils.MetaLeastLoaded:
+		d = &LeastLoadedDispatcher{
+			dm:       dm,
+			tnt:      pfl.Tenant,
+			hosts:    hosts,
+			strategy: new(singleResultstrategyDispatcher), // keep the order given by the load
+		}
+	case utils.MetaHashRing:
+		d, err = newHashRingDispatcher(dm, pfl.Tenant, hosts, pfl.StrategyParams)
 	case rpcclient.PoolBroadcast,
 		rpcclient.PoolBroadcastSync,
 		rpcclient.PoolBroadcastAsync:
@@ -137,7 +147,7 @@ func (wd *WeightDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
 }
 
 // Dispatch used to implement Dispatcher interface
-func (wd *WeightDispatcher) Dispatch(routeID string, subsystem,
+func (wd *WeightDispatcher) Dispatch(_ *utils.CGREvent, routeID string, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	return wd.strategy.dispatch(wd.dm, routeID, subsystem, wd.tnt, wd.HostIDs(),
 		serviceMethod, args, reply)
@@ -171,7 +181,7 @@ func (d *RandomDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
 }
 
 // Dispatch used to implement Dispatcher interface
-func (d *RandomDispatcher) Dispatch(routeID string, subsystem,
+func (d *RandomDispatcher) Dispatch(_ *utils.CGREvent, routeID string, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
 		serviceMethod, args, reply)
@@ -209,12 +219,146 @@ func (d *RoundRobinDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
 }
 
 // Dispatch used to implement Dispatcher interface
-func (d *RoundRobinDispatcher) Dispatch(routeID string, subsystem,
+func (d *RoundRobinDispatcher) Dispatch(_ *utils.CGREvent, routeID string, subsystem,
+	serviceMethod string, args interface{}, reply interface{}) (err error) {
+	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
+		serviceMethod, args, reply)
+}
+
+// LeastLoadedDispatcher selects the connections in the order of the caps usage reported by the hosts
+type LeastLoadedDispatcher struct {
+	sync.RWMutex
+	dm       *engine.DataManager
+	tnt      string
+	hosts    engine.DispatcherHostProfiles
+	strategy strategyDispatcher
+}
+
+// SetProfile used to implement Dispatcher interface
+func (d *LeastLoadedDispatcher) SetProfile(pfl *engine.DispatcherProfile) {
+	d.Lock()
+	pfl.Hosts.Sort()
+	d.hosts = pfl.Hosts.Clone()
+	d.Unlock()
+	return
+}
+
+// HostIDs used to implement Dispatcher interface
+func (d *LeastLoadedDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
+	d.RLock()
+	hostIDs = d.hosts.HostIDs()
+	d.RUnlock()
+	dspHealth.sortByLoad(d.tnt, hostIDs)
+	return
+}
+
+// Dispatch used to implement Dispatcher interface
+func (d *LeastLoadedDispatcher) Dispatch(_ *utils.CGREvent, routeID string, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
 		serviceMethod, args, reply)
 }
 
+func newHashRingDispatcher(dm *engine.DataManager, tnt string, hosts engine.DispatcherHostProfiles,
+	params map[string]interface{}) (d *HashRingDispatcher, err error) {
+	d = &HashRingDispatcher{
+		dm:       dm,
+		tnt:      tnt,
+		hosts:    hosts,
+		strategy: new(singleResultstrategyDispatcher), // keep the order given by the ring
+	}
+	if d.hashFld, d.vNodes, err = parseHashRingParams(params); err != nil {
+		return nil, err
+	}
+	d.ring = newHashRing(hosts, d.vNodes)
+	return
+}
+
+// parseHashRingParams returns the hash field and the virtual nodes out of the profile StrategyParams
+func parseHashRingParams(params map[string]interface{}) (hashFld config.RSRParsers, vNodes int, err error) {
+	hashFldVal, has := params[utils.MetaHashField]
+	if !has {
+		return nil, 0, utils.NewErrMandatoryIeMissing(utils.MetaHashField)
+	}
+	if hashFld, err = config.NewRSRParsers(utils.IfaceAsString(hashFldVal),
+		config.CgrConfig().GeneralCfg().RSRSep); err != nil {
+		return
+	}
+	vNodes = utils.DefaultVNodes
+	if vNodesVal, has := params[utils.MetaVirtualNodes]; has {
+		var vN int64
+		if vN, err = utils.IfaceAsTInt64(vNodesVal); err != nil {
+			return
+		}
+		vNodes = int(vN)
+	}
+	return
+}
+
+// HashRingDispatcher selects the connections using consistent hashing over an event field
+// so the events with the same value are sticky to the same host
+type HashRingDispatcher struct {
+	sync.RWMutex
+	dm       *engine.DataManager
+	tnt      string
+	hosts    engine.DispatcherHostProfiles
+	hashFld  config.RSRParsers
+	vNodes   int // virtual nodes for each unit of host weight
+	ring     *hashRing
+	strategy strategyDispatcher
+}
+
+// SetProfile used to implement Dispatcher interface
+// the StrategyParams are parsed again, on errors the previous ones are kept
+func (d *HashRingDispatcher) SetProfile(pfl *engine.DispatcherProfile) {
+	d.Lock()
+	if hashFld, vNodes, err := parseHashRingParams(pfl.StrategyParams); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> keeping the previous hash ring parameters of profile <%s>, error: %s",
+			utils.DispatcherS, pfl.TenantID(), err.Error()))
+	} else {
+		d.hashFld, d.vNodes = hashFld, vNodes
+	}
+	pfl.Hosts.Sort()
+	d.hosts = pfl.Hosts.Clone()
+	d.ring = newHashRing(d.hosts, d.vNodes)
+	d.Unlock()
+	return
+}
+
+// HostIDs used to implement Dispatcher interface
+func (d *HashRingDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
+	d.RLock()
+	hostIDs = d.hosts.HostIDs()
+	d.RUnlock()
+	return
+}
+
+// hostIDsForEvent returns the hosts in the ring order starting with the owner of the event
+// the events without the hash field are dispatched in the weight order
+func (d *HashRingDispatcher) hostIDsForEvent(ev *utils.CGREvent) (hostIDs engine.DispatcherHostIDs) {
+	var key string
+	if ev != nil {
+		key, _ = d.hashFld.ParseDataProvider(utils.MapStorage{
+			utils.MetaReq:  ev.Event,
+			utils.MetaOpts: ev.Opts,
+		})
+	}
+	if key == utils.EmptyString {
+		return d.HostIDs()
+	}
+	d.RLock()
+	hostIDs = d.ring.hostIDs(key)
+	d.RUnlock()
+	return
+}
+
+// Dispatch used to implement Dispatcher interface
+func (d *HashRingDispatcher) Dispatch(ev *utils.CGREvent, routeID string, subsystem,
+	serviceMethod string, args interface{}, reply interface{}) (err error) {
+	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.hostIDsForEvent(ev),
+		serviceMethod, args, reply)
+}
+
 type singleResultstrategyDispatcher struct{}
 
 func (*singleResultstrategyDispatcher) dispatch(dm *engine.DataManager, routeID string, subsystem, tnt string,
@@ -227,8 +371,12 @@ func (*singleResultstrategyDispatcher) dispatch(dm *engine.DataManager, routeID
 		if x, ok := engine.Cache.Get(utils.CacheDispatcherRoutes,
 			routeID); ok && x != nil {
 			dH = x.(*engine.DispatcherHost)
-			if err = dH.Call(serviceMethod, args, reply); !rpcclient.IsNetworkError(err) {
-				return
+			if dspHealth.allow(dH.TenantID()) {
+				err = dH.Call(serviceMethod, args, reply)
+				dspHealth.report(dH.TenantID(), err)
+				if !rpcclient.IsNetworkError(err) {
+					return
+				}
 			}
 		}
 	}
@@ -244,8 +392,13 @@ func (*singleResultstrategyDispatcher) dispatch(dm *engine.DataManager, routeID
 			err = utils.NewErrDispatcherS(err)
 			return
 		}
+		if !dspHealth.allow(dH.TenantID()) { // the circuit of the host is open
+			continue
+		}
 		called = true
-		if err = dH.Call(serviceMethod, args, reply); rpcclient.IsNetworkError(err) {
+		err = dH.Call(serviceMethod, args, reply)
+		dspHealth.report(dH.TenantID(), err)
+		if rpcclient.IsNetworkError(err) {
 			continue
 		}
 		if routeID != utils.EmptyString { // cache the discovered route
@@ -282,6 +435,9 @@ func (b *broadcastStrategyDispatcher) dispatch(dm *engine.DataManager, routeID s
 			}
 			return utils.NewErrDispatcherS(err)
 		}
+		if !dspHealth.available(dH.TenantID()) { // the circuit of the host is open
+			continue
+		}
 		hasHosts = true
 		pool.AddClient(dH)
 	}
@@ -365,11 +521,14 @@ func (ld *loadStrategyDispatcher) dispatch(dm *engine.DataManager, routeID strin
 		if x, ok := engine.Cache.Get(utils.CacheDispatcherRoutes,
 			routeID); ok && x != nil {
 			dH = x.(*engine.DispatcherHost)
-			lM.incrementLoad(dH.ID, ld.tntID)
-			err = dH.Call(serviceMethod, args, reply)
-			lM.decrementLoad(dH.ID, ld.tntID) // call ended
-			if !rpcclient.IsNetworkError(err) {
-				return
+			if dspHealth.allow(dH.TenantID()) {
+				lM.incrementLoad(dH.ID, ld.tntID)
+				err = dH.Call(serviceMethod, args, reply)
+				lM.decrementLoad(dH.ID, ld.tntID) // call ended
+				dspHealth.report(dH.TenantID(), err)
+				if !rpcclient.IsNetworkError(err) {
+					return
+				}
 			}
 		}
 	}
@@ -385,10 +544,14 @@ func (ld *loadStrategyDispatcher) dispatch(dm *engine.DataManager, routeID strin
 			err = utils.NewErrDispatcherS(err)
 			return
 		}
+		if !dspHealth.allow(dH.TenantID()) { // the circuit of the host is open
+			continue
+		}
 		called = true
 		lM.incrementLoad(hostID, ld.tntID)
 		err = dH.Call(serviceMethod, args, reply)
 		lM.decrementLoad(hostID, ld.tntID) // call ended
+		dspHealth.report(dH.TenantID(), err)
 		if rpcclient.IsNetworkError(err) {
 			continue
 		}
@@ -21,6 +21,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatchers
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -221,7 +222,7 @@ func testDspResTestAuthKey3(t *testing.T) {
 		Units: 17,
 	}
 	if err := dispEngine.RPC.Call(utils.ResourceSv1AuthorizeResources,
-		&argsRU, &reply); err == nil || err.Error() != utils.ErrResourceUnauthorized.Error() {
+		&argsRU, &reply); !errors.Is(utils.CastRPCErr(err), utils.ErrResourceUnauthorized) {
 		t.Error(err)
 	}
 
@@ -219,6 +219,13 @@ func (eeS *EventExporterS) V1ProcessEvent(cgrEv *utils.CGREventWithEeIDs, rply *
 					fmt.Sprintf("<%s> with id <%s>, error: <%s>",
 						utils.EventExporterS, ee.ID(), err.Error()))
 				withErr = true
+				engine.Metrics.IncrementCounter("cgrates_ees_events_failed_total",
+					"Number of events failed to be exported by EEs.",
+					map[string]string{"exporter": ee.ID()})
+			} else {
+				engine.Metrics.IncrementCounter("cgrates_ees_events_exported_total",
+					"Number of events exported by EEs.",
+					map[string]string{"exporter": ee.ID()})
 			}
 			if evict {
 				ee.OnEvicted("", nil) // so we can close ie the file
@@ -24,7 +24,9 @@ import (
 	"fmt"
 	"net/url"
 	"runtime"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -85,6 +87,8 @@ func init() {
 	gob.Register(new(StatSum))
 	gob.Register(new(StatAverage))
 	gob.Register(new(StatDistinct))
+	gob.Register(new(StatPercentile))
+	gob.Register(new(StatHistogram))
 
 	gob.Register(new(HTTPPosterRequest))
 
@@ -93,6 +97,7 @@ func init() {
 	gob.Register(map[string]interface{}{})
 	gob.Register(map[string][]map[string]interface{}{})
 	gob.Register(map[string]string{})
+	gob.Register(map[string]int64{})
 	gob.Register(time.Duration(0))
 	gob.Register(time.Time{})
 	gob.Register(url.Values{})
@@ -136,10 +141,15 @@ func NewCacheS(cfg *config.CGRConfig, dm *DataManager, cpS *CapsStats) (c *Cache
 		dm:      dm,
 		pcItems: make(map[string]chan struct{}),
 		tCache:  ltcache.NewTransCache(tCache),
+		hits:    make(map[string]*cacheHits),
 	}
 	for cacheID := range cfg.CacheCfg().Partitions {
 		c.pcItems[cacheID] = make(chan struct{})
 	}
+	for cacheID := range tCache {
+		c.hits[cacheID] = new(cacheHits)
+	}
+	Metrics.RegisterCollector(utils.CacheS, c.collectMetrics)
 	return
 }
 
@@ -149,6 +159,13 @@ type CacheS struct {
 	dm      *DataManager
 	pcItems map[string]chan struct{} // signal precaching
 	tCache  *ltcache.TransCache
+	hits    map[string]*cacheHits // read-only after creation, counters updated atomically
+}
+
+// cacheHits counts the Get queries on one partition
+type cacheHits struct {
+	hits   uint64
+	misses uint64
 }
 
 // Set is an exported method from TransCache
@@ -172,8 +189,39 @@ func (chS *CacheS) HasItem(chID, itmID string) (has bool) {
 }
 
 // Get is an exported method from TransCache
-func (chS *CacheS) Get(chID, itmID string) (interface{}, bool) {
-	return chS.tCache.Get(chID, itmID)
+func (chS *CacheS) Get(chID, itmID string) (itm interface{}, has bool) {
+	itm, has = chS.tCache.Get(chID, itmID)
+	if cH, hasCh := chS.hits[chID]; hasCh {
+		if has {
+			atomic.AddUint64(&cH.hits, 1)
+		} else {
+			atomic.AddUint64(&cH.misses, 1)
+		}
+	}
+	return
+}
+
+// collectMetrics adds the items, hits and misses per cache partition
+func (chS *CacheS) collectMetrics(pm *utils.PrometheusMetrics, _ url.Values) {
+	cs := chS.tCache.GetCacheStats(nil)
+	cacheIDs := make([]string, 0, len(chS.hits))
+	for cacheID := range chS.hits {
+		cacheIDs = append(cacheIDs, cacheID)
+	}
+	sort.Strings(cacheIDs)
+	for _, cacheID := range cacheIDs {
+		lbls := map[string]string{"partition": cacheID}
+		if st, has := cs[cacheID]; has {
+			pm.Add("cgrates_cache_items", utils.PrometheusGauge,
+				"Number of items in the cache partition.", lbls, float64(st.Items))
+		}
+		pm.Add("cgrates_cache_hits_total", utils.PrometheusCounter,
+			"Number of cache queries finding the item.", lbls,
+			float64(atomic.LoadUint64(&chS.hits[cacheID].hits)))
+		pm.Add("cgrates_cache_misses_total", utils.PrometheusCounter,
+			"Number of cache queries not finding the item.", lbls,
+			float64(atomic.LoadUint64(&chS.hits[cacheID].misses)))
+	}
 }
 
 // GetItemIDs is an exported method from TransCache
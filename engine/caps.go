@@ -46,6 +46,11 @@ func (cR *Caps) IsLimited() bool {
 	return cap(cR.aReqs) != 0
 }
 
+// Limit returns the maximum number of requests serviced concurrently
+func (cR *Caps) Limit() int {
+	return cap(cR.aReqs)
+}
+
 // Allocated returns the number of requests actively serviced
 func (cR *Caps) Allocated() int {
 	return len(cR.aReqs)
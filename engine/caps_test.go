@@ -51,6 +51,9 @@ func TestNewCaps(t *testing.T) {
 		t.Errorf("Expected: %v ,received: %v", utils.ErrMaxConcurentRPCExceededNoCaps, err)
 	}
 	cs = NewCaps(1, utils.MetaBusy)
+	if lmt := cs.Limit(); lmt != 1 {
+		t.Errorf("Expected: %v ,received: %v", 1, lmt)
+	}
 	if err := cs.Allocate(); err != nil {
 		t.Error(err)
 	}
@@ -20,11 +20,13 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
 	"github.com/cgrates/rpcclient"
 )
 
@@ -90,7 +92,7 @@ func (cdrS *CDRServer) fsCdrHandler(w http.ResponseWriter, r *http.Request) {
 func NewCDRServer(cgrCfg *config.CGRConfig, storDBChan chan StorDB, dm *DataManager, filterS *FilterS,
 	connMgr *ConnManager) *CDRServer {
 	cdrDb := <-storDBChan
-	return &CDRServer{
+	cdrS := &CDRServer{
 		cgrCfg:     cgrCfg,
 		cdrDb:      cdrDb,
 		dm:         dm,
@@ -99,6 +101,15 @@ func NewCDRServer(cgrCfg *config.CGRConfig, storDBChan chan StorDB, dm *DataMana
 		connMgr:    connMgr,
 		storDBChan: storDBChan,
 	}
+	if len(cgrCfg.CdrsCfg().DedupFields) != 0 {
+		cdrS.dedupCache = ltcache.NewCache(ltcache.UnlimitedCaching,
+			cgrCfg.CdrsCfg().DedupTTL, true, nil) // static TTL so the window starts with the first event
+	}
+	if cgrCfg.CdrsCfg().PartialCache > 0 {
+		cdrS.partialCache = ltcache.NewCache(ltcache.UnlimitedCaching,
+			cgrCfg.CdrsCfg().PartialCache, false, cdrS.processExpiredPartials)
+	}
+	return cdrS
 }
 
 // CDRServer stores and rates CDRs
@@ -110,6 +121,14 @@ type CDRServer struct {
 	filterS    *FilterS
 	connMgr    *ConnManager
 	storDBChan chan StorDB
+
+	rerateJobs map[string]*rerateJob // asynchronous re-rating jobs, indexed on ID
+	rerateMux  sync.RWMutex
+
+	dedupCache   *ltcache.Cache // keys of the events received within the dedup window
+	dedupMux     sync.Mutex
+	partialCache *ltcache.Cache // legs of the partial CDRs waiting to be merged
+	partialMux   sync.Mutex
 }
 
 // ListenAndServe listen for storbd reload
@@ -446,6 +465,23 @@ func (cdrS *CDRServer) eeSProcessEvent(cgrEv *utils.CGREventWithEeIDs) (err erro
 // processEvent processes a CGREvent based on arguments
 // in case of partially executed, both error and evs will be returned
 func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
+	chrgS, attrS, refund, ralS, store, reRate, export, thdS, stS bool) (evs []*utils.EventWithFlags, err error) {
+	if refund || reRate { // the CDRs were already received
+		return cdrS.processCompleteEvent(ev, chrgS, attrS, refund,
+			ralS, store, reRate, export, thdS, stS)
+	}
+	if ev = cdrS.mergePartialEvent(ev, func(cgrEv *utils.CGREvent) (err error) {
+		_, err = cdrS.processNewEvent(cgrEv, chrgS, attrS, ralS, store, export, thdS, stS)
+		return
+	}); ev == nil { // waiting for the remaining legs
+		return
+	}
+	return cdrS.processNewEvent(ev, chrgS, attrS, ralS, store, export, thdS, stS)
+}
+
+// processCompleteEvent processes a CGREvent after the partial legs were merged
+// in case of partially executed, both error and evs will be returned
+func (cdrS *CDRServer) processCompleteEvent(ev *utils.CGREvent,
 	chrgS, attrS, refund, ralS, store, reRate, export, thdS, stS bool) (evs []*utils.EventWithFlags, err error) {
 	if attrS {
 		if err = cdrS.attrSProcessEvent(ev); err != nil {
@@ -1016,52 +1052,72 @@ type ArgRateCDRs struct {
 	Opts   map[string]interface{}
 }
 
-// V1RateCDRs is used for re-/rate CDRs which are already stored within StorDB
-// FixMe: add RPC caching
-func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
-	var cdrFltr *utils.CDRsFilter
-	if cdrFltr, err = arg.RPCCDRsFilter.AsCDRsFilter(cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
-		return utils.NewErrServerError(err)
-	}
-	var cdrs []*CDR
-	if cdrs, _, err = cdrS.cdrDb.GetCDRs(cdrFltr, false); err != nil {
-		return
+// rateCDRsFlags are the processing options used when re-/rating stored CDRs
+type rateCDRsFlags struct {
+	store  bool
+	export bool
+	thdS   bool
+	statS  bool
+	chrgS  bool
+	attrS  bool
+}
+
+// newRateCDRsFlags applies the flags over the defaults coming from configuration
+func (cdrS *CDRServer) newRateCDRsFlags(flags []string) (rf *rateCDRsFlags, err error) {
+	flgs := utils.FlagsWithParamsFromSlice(flags)
+	rf = &rateCDRsFlags{
+		store:  cdrS.cgrCfg.CdrsCfg().StoreCdrs,
+		export: len(cdrS.cgrCfg.CdrsCfg().OnlineCDRExports) != 0 || len(cdrS.cgrCfg.CdrsCfg().EEsConns) != 0,
+		thdS:   len(cdrS.cgrCfg.CdrsCfg().ThresholdSConns) != 0,
+		statS:  len(cdrS.cgrCfg.CdrsCfg().StatSConns) != 0,
+		chrgS:  len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) != 0,
+		attrS:  len(cdrS.cgrCfg.CdrsCfg().AttributeSConns) != 0,
 	}
-	flgs := utils.FlagsWithParamsFromSlice(arg.Flags)
-	store := cdrS.cgrCfg.CdrsCfg().StoreCdrs
 	if flgs.Has(utils.MetaStore) {
-		store = flgs.GetBool(utils.MetaStore)
+		rf.store = flgs.GetBool(utils.MetaStore)
 	}
-	export := len(cdrS.cgrCfg.CdrsCfg().OnlineCDRExports) != 0 || len(cdrS.cgrCfg.CdrsCfg().EEsConns) != 0
 	if flgs.Has(utils.MetaExport) {
-		export = flgs.GetBool(utils.MetaExport)
+		rf.export = flgs.GetBool(utils.MetaExport)
 	}
-	thdS := len(cdrS.cgrCfg.CdrsCfg().ThresholdSConns) != 0
 	if flgs.Has(utils.MetaThresholds) {
-		thdS = flgs.GetBool(utils.MetaThresholds)
+		rf.thdS = flgs.GetBool(utils.MetaThresholds)
 	}
-	statS := len(cdrS.cgrCfg.CdrsCfg().StatSConns) != 0
 	if flgs.Has(utils.MetaStats) {
-		statS = flgs.GetBool(utils.MetaStats)
+		rf.statS = flgs.GetBool(utils.MetaStats)
 	}
-	chrgS := len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) != 0
 	if flgs.Has(utils.MetaChargers) {
-		chrgS = flgs.GetBool(utils.MetaChargers)
+		rf.chrgS = flgs.GetBool(utils.MetaChargers)
 	}
-	attrS := len(cdrS.cgrCfg.CdrsCfg().AttributeSConns) != 0
 	if flgs.Has(utils.MetaAttributes) {
-		attrS = flgs.GetBool(utils.MetaAttributes)
+		rf.attrS = flgs.GetBool(utils.MetaAttributes)
 	}
+	if rf.chrgS && len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) == 0 {
+		return nil, utils.NewErrNotConnected(utils.ChargerS)
+	}
+	return
+}
 
-	if chrgS && len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) == 0 {
-		return utils.NewErrNotConnected(utils.ChargerS)
+// V1RateCDRs is used for re-/rate CDRs which are already stored within StorDB
+// FixMe: add RPC caching
+func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
+	var cdrFltr *utils.CDRsFilter
+	if cdrFltr, err = arg.RPCCDRsFilter.AsCDRsFilter(cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	var cdrs []*CDR
+	if cdrs, _, err = cdrS.cdrDb.GetCDRs(cdrFltr, false); err != nil {
+		return
+	}
+	var rf *rateCDRsFlags
+	if rf, err = cdrS.newRateCDRsFlags(arg.Flags); err != nil {
+		return
 	}
 	for _, cdr := range cdrs {
 		cdr.Cost = -1 // the cost will be recalculated
 		cgrEv := cdr.AsCGREvent()
 		cgrEv.Opts = arg.Opts
-		if _, err = cdrS.processEvent(cgrEv, chrgS, attrS, false,
-			true, store, true, export, thdS, statS); err != nil {
+		if _, err = cdrS.processEvent(cgrEv, rf.chrgS, rf.attrS, false,
+			true, rf.store, true, rf.export, rf.thdS, rf.statS); err != nil {
 			return utils.NewErrServerError(err)
 		}
 	}
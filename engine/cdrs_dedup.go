@@ -0,0 +1,201 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// partialEvents are the legs of a CDR waiting to be merged
+type partialEvents struct {
+	evs     []*utils.CGREvent
+	process func(*utils.CGREvent) error // processes the merged event if the cache expires
+}
+
+// merge composes one event out of the legs, ordered by AnswerTime or SetupTime
+// the fields of the later legs overwrite the ones of the earlier legs
+func (pEvs *partialEvents) merge(tmz string) (cgrEv *utils.CGREvent) {
+	evTimes := make(map[*utils.CGREvent]time.Time, len(pEvs.evs))
+	for _, ev := range pEvs.evs {
+		evTimes[ev] = partialEventTime(ev, tmz)
+	}
+	sort.SliceStable(pEvs.evs, func(i, j int) bool {
+		return evTimes[pEvs.evs[i]].Before(evTimes[pEvs.evs[j]])
+	})
+	cgrEv = &utils.CGREvent{
+		Tenant: pEvs.evs[0].Tenant,
+		ID:     utils.UUIDSha1Prefix(),
+		Time:   pEvs.evs[0].Time,
+		Event:  make(map[string]interface{}),
+		Opts:   make(map[string]interface{}),
+	}
+	for _, ev := range pEvs.evs {
+		for key, val := range ev.Event {
+			cgrEv.Event[key] = val
+		}
+		for key, val := range ev.Opts {
+			cgrEv.Opts[key] = val
+		}
+	}
+	return
+}
+
+// partialEventTime returns the time the leg is ordered on,
+// the AnswerTime if present, otherwise the SetupTime
+func partialEventTime(ev *utils.CGREvent, tmz string) (evTime time.Time) {
+	var err error
+	if evTime, err = ev.FieldAsTime(utils.AnswerTime, tmz); err != nil || evTime.IsZero() {
+		evTime, _ = ev.FieldAsTime(utils.SetupTime, tmz)
+	}
+	return
+}
+
+// partialKey returns the key used to group the legs of the same CDR
+func partialKey(ev *utils.CGREvent) string {
+	me := MapEvent(ev.Event)
+	return utils.ConcatenatedKey(ev.Tenant,
+		utils.Sha1(me.GetStringIgnoreErrors(utils.OriginID),
+			me.GetStringIgnoreErrors(utils.OriginHost)))
+}
+
+// mergePartialEvent caches the partial legs of a CDR, returning nil while waiting for the remaining ones
+// when the last leg arrives, the merged event is returned so it can be processed
+func (cdrS *CDRServer) mergePartialEvent(ev *utils.CGREvent,
+	process func(*utils.CGREvent) error) (cgrEv *utils.CGREvent) {
+	if cdrS.partialCache == nil {
+		return ev
+	}
+	partial := MapEvent(ev.Event).GetBoolOrDefault(utils.Partial, false)
+	key := partialKey(ev)
+	cdrS.partialMux.Lock()
+	defer cdrS.partialMux.Unlock()
+	var pEvs *partialEvents
+	if val, has := cdrS.partialCache.Get(key); has {
+		pEvs = val.(*partialEvents)
+	} else if !partial { // complete CDR
+		return ev
+	} else {
+		pEvs = new(partialEvents)
+	}
+	pEvs.evs = append(pEvs.evs, ev.Clone())
+	pEvs.process = process
+	if partial {
+		cdrS.partialCache.Set(key, pEvs, nil)
+		return
+	}
+	cgrEv = pEvs.merge(cdrS.cgrCfg.GeneralCfg().DefaultTimezone)
+	cgrEv.ID = ev.ID
+	cdrS.partialCache.Set(key, nil, nil) // nil value so the merged event is not processed twice
+	cdrS.partialCache.Remove(key)
+	return
+}
+
+// processExpiredPartials is called by the partial cache when the remaining legs did not arrive in time
+func (cdrS *CDRServer) processExpiredPartials(key string, value interface{}) {
+	if value == nil {
+		return
+	}
+	pEvs := value.(*partialEvents)
+	cgrEv := pEvs.merge(cdrS.cgrCfg.GeneralCfg().DefaultTimezone)
+	go func() { // the cache is locked while evicting
+		if err := pEvs.process(cgrEv); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> processing expired partial event %+v",
+					utils.CDRs, err.Error(), utils.ToJSON(cgrEv)))
+		}
+	}()
+}
+
+// dedupKey returns the key built out of the configured dedup fields
+func (cdrS *CDRServer) dedupKey(ev *utils.CGREvent) string {
+	me := MapEvent(ev.Event)
+	vals := make([]string, len(cdrS.cgrCfg.CdrsCfg().DedupFields)+1)
+	vals[0] = ev.Tenant
+	for i, fld := range cdrS.cgrCfg.CdrsCfg().DedupFields {
+		vals[i+1] = me.GetStringIgnoreErrors(fld)
+	}
+	return utils.ConcatenatedKey(vals...)
+}
+
+// dedupEvent is the processing of the first event received with a dedup key
+type dedupEvent struct {
+	done chan struct{} // closed once the event was processed
+	err  error
+}
+
+// isDuplicate checks if an event with the same dedup key was received within the dedup window,
+// otherwise the key is recorded and the returned dedupEvent needs to be finished by the caller
+// while the first event is in progress the duplicates wait for its result, if it fails the
+// key is released and one of them is processed instead
+func (cdrS *CDRServer) isDuplicate(ev *utils.CGREvent) (dEv *dedupEvent, dup bool) {
+	if cdrS.dedupCache == nil {
+		return
+	}
+	key := cdrS.dedupKey(ev)
+	for {
+		cdrS.dedupMux.Lock()
+		if x, has := cdrS.dedupCache.Get(key); has {
+			cdrS.dedupMux.Unlock()
+			prevEv := x.(*dedupEvent)
+			<-prevEv.done
+			if prevEv.err == nil {
+				return nil, true
+			}
+			continue
+		}
+		dEv = &dedupEvent{done: make(chan struct{})}
+		cdrS.dedupCache.Set(key, dEv, nil)
+		cdrS.dedupMux.Unlock()
+		return
+	}
+}
+
+// finishDedup records the result of the first event, releasing the dedup key on errors
+func (cdrS *CDRServer) finishDedup(ev *utils.CGREvent, dEv *dedupEvent, err error) {
+	if err != nil {
+		cdrS.dedupMux.Lock()
+		cdrS.dedupCache.Remove(cdrS.dedupKey(ev))
+		cdrS.dedupMux.Unlock()
+	}
+	dEv.err = err
+	close(dEv.done)
+}
+
+// processNewEvent drops the duplicated events before processing them
+// the dedup key is released on errors so the event can be retried
+func (cdrS *CDRServer) processNewEvent(ev *utils.CGREvent,
+	chrgS, attrS, ralS, store, export, thdS, stS bool) (evs []*utils.EventWithFlags, err error) {
+	dEv, dup := cdrS.isDuplicate(ev)
+	if dup {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> dropping duplicated event %+v",
+				utils.CDRs, utils.ToJSON(ev)))
+		return
+	}
+	evs, err = cdrS.processCompleteEvent(ev, chrgS, attrS, false,
+		ralS, store, false, export, thdS, stS)
+	if dEv != nil {
+		cdrS.finishDedup(ev, dEv, err)
+	}
+	return
+}
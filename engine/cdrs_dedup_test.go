@@ -0,0 +1,200 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func testDedupCDRServer(cfg *config.CGRConfig) *CDRServer {
+	storDBChan := make(chan StorDB, 1)
+	storDBChan <- NewInternalDB(nil, nil, false)
+	return NewCDRServer(cfg, storDBChan, nil, nil, NewConnManager(cfg, nil))
+}
+
+func testDedupEvent(tnt, originID string, partial bool, usage time.Duration) *utils.CGREvent {
+	return &utils.CGREvent{
+		Tenant: tnt,
+		ID:     utils.GenUUID(),
+		Event: map[string]interface{}{
+			utils.OriginID:     originID,
+			utils.OriginHost:   "127.0.0.1",
+			utils.RunID:        utils.MetaDefault,
+			utils.ToR:          utils.MetaVoice,
+			utils.RequestType:  utils.MetaPostpaid,
+			utils.AccountField: "1001",
+			utils.Destination:  "1002",
+			utils.AnswerTime:   time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC).Add(usage),
+			utils.Usage:        usage,
+			utils.Partial:      partial,
+		},
+	}
+}
+
+func TestCDRsDedupEvents(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().DedupFields = []string{utils.OriginID, utils.OriginHost}
+	cfg.CdrsCfg().DedupTTL = time.Minute
+	cdrS := testDedupCDRServer(cfg)
+	tnt := "dedup.cgrates.org"
+	for i := 0; i < 2; i++ {
+		if _, err := cdrS.processEvent(testDedupEvent(tnt, "dedup1", false, time.Minute),
+			false, false, false, false, true, false, false, false, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, cnt, err := cdrS.cdrDb.GetCDRs(&utils.CDRsFilter{Tenants: []string{tnt}, Count: true}, false); err != nil {
+		t.Error(err)
+	} else if cnt != 1 {
+		t.Errorf("Expected 1 CDR, received: %d", cnt)
+	}
+	// different OriginID is not a duplicate
+	if _, err := cdrS.processEvent(testDedupEvent(tnt, "dedup2", false, time.Minute),
+		false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, cnt, err := cdrS.cdrDb.GetCDRs(&utils.CDRsFilter{Tenants: []string{tnt}, Count: true}, false); err != nil {
+		t.Error(err)
+	} else if cnt != 2 {
+		t.Errorf("Expected 2 CDRs, received: %d", cnt)
+	}
+}
+
+func TestCDRsMergePartialEvents(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().PartialCache = time.Minute
+	cdrS := testDedupCDRServer(cfg)
+	tnt := "partial.cgrates.org"
+	ev := testDedupEvent(tnt, "partial1", true, time.Minute)
+	ev.Event["Leg1"] = "val1"
+	if evs, err := cdrS.processEvent(ev,
+		false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	} else if evs != nil {
+		t.Errorf("Expected the partial event to be cached, received: %s", utils.ToJSON(evs))
+	}
+	if _, _, err := cdrS.cdrDb.GetCDRs(&utils.CDRsFilter{Tenants: []string{tnt}}, false); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	ev = testDedupEvent(tnt, "partial1", false, 2*time.Minute)
+	ev.Event["Leg2"] = "val2"
+	if evs, err := cdrS.processEvent(ev,
+		false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	} else if len(evs) != 1 {
+		t.Errorf("Expected one merged event, received: %s", utils.ToJSON(evs))
+	}
+	cdrs, _, err := cdrS.cdrDb.GetCDRs(&utils.CDRsFilter{Tenants: []string{tnt}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cdrs) != 1 {
+		t.Fatalf("Expected 1 CDR, received: %s", utils.ToJSON(cdrs))
+	}
+	if cdrs[0].Usage != 2*time.Minute || cdrs[0].Partial ||
+		cdrs[0].ExtraFields["Leg1"] != "val1" || cdrs[0].ExtraFields["Leg2"] != "val2" {
+		t.Errorf("Unexpected merged CDR: %s", utils.ToJSON(cdrs[0]))
+	}
+}
+
+func TestCDRsMergePartialEventsExpired(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().PartialCache = 10 * time.Millisecond
+	cdrS := testDedupCDRServer(cfg)
+	tnt := "partialExp.cgrates.org"
+	if _, err := cdrS.processEvent(testDedupEvent(tnt, "partialExp1", true, time.Minute),
+		false, false, false, false, true, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		time.Sleep(10 * time.Millisecond)
+		if cdrs, _, err := cdrS.cdrDb.GetCDRs(&utils.CDRsFilter{Tenants: []string{tnt}}, false); err == nil {
+			if len(cdrs) != 1 || !cdrs[0].Partial {
+				t.Errorf("Unexpected CDRs: %s", utils.ToJSON(cdrs))
+			}
+			return
+		}
+	}
+	t.Error("The expired partial event was not processed")
+}
+
+func TestPartialEventsMergeMixedTimes(t *testing.T) {
+	// the legs without AnswerTime are ordered on SetupTime against the AnswerTime of the others
+	leg1 := testDedupEvent("cgrates.org", "mixed1", true, time.Minute) // answered at 10:01
+	leg1.Event[utils.SetupTime] = time.Date(2021, 1, 1, 9, 0, 0, 0, time.UTC)
+	leg1.Event[utils.Usage] = time.Minute
+	leg2 := testDedupEvent("cgrates.org", "mixed1", true, 0)
+	delete(leg2.Event, utils.AnswerTime)
+	leg2.Event[utils.SetupTime] = time.Date(2021, 1, 1, 10, 2, 0, 0, time.UTC)
+	leg2.Event[utils.Usage] = 2 * time.Minute
+	leg3 := testDedupEvent("cgrates.org", "mixed1", true, 0)
+	leg3.Event[utils.AnswerTime] = utils.EmptyString
+	leg3.Event[utils.SetupTime] = time.Date(2021, 1, 1, 9, 30, 0, 0, time.UTC)
+	leg3.Event[utils.Usage] = 3 * time.Minute
+	for _, evs := range [][]*utils.CGREvent{{leg1, leg2, leg3}, {leg2, leg3, leg1}, {leg3, leg2, leg1}} {
+		pEvs := &partialEvents{evs: evs}
+		if merged := pEvs.merge(utils.EmptyString); merged.Event[utils.Usage] != 2*time.Minute {
+			t.Errorf("Expected the usage of the latest leg, received: %s", utils.ToJSON(merged))
+		}
+	}
+}
+
+func TestCDRsDedupInFlight(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().DedupFields = []string{utils.OriginID, utils.OriginHost}
+	cfg.CdrsCfg().DedupTTL = time.Minute
+	cdrS := testDedupCDRServer(cfg)
+	ev := testDedupEvent("dedup.cgrates.org", "inFlight1", false, time.Minute)
+	dEv, dup := cdrS.isDuplicate(ev)
+	if dup || dEv == nil {
+		t.Fatal("Expected the first event to be processed")
+	}
+	type dedupRply struct {
+		dEv *dedupEvent
+		dup bool
+	}
+	rplyChan := make(chan dedupRply, 1)
+	go func() {
+		dEv, dup := cdrS.isDuplicate(ev)
+		rplyChan <- dedupRply{dEv, dup}
+	}()
+	select {
+	case <-rplyChan:
+		t.Fatal("Expected the duplicate to wait for the first event")
+	case <-time.After(10 * time.Millisecond):
+	}
+	cdrS.finishDedup(ev, dEv, utils.ErrNotFound)
+	var rply dedupRply
+	select {
+	case rply = <-rplyChan:
+	case <-time.After(time.Second):
+		t.Fatal("The duplicate is still waiting")
+	}
+	if rply.dup || rply.dEv == nil {
+		t.Fatal("Expected the duplicate to be processed after the first event failed")
+	}
+	cdrS.finishDedup(ev, rply.dEv, nil)
+	if _, dup = cdrS.isDuplicate(ev); !dup {
+		t.Error("Expected duplicate after the event was processed")
+	}
+}
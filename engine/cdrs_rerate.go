@@ -0,0 +1,449 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const rerateDefaultPageSize = 1000
+
+// ArgStartRerate starts (or resumes) an asynchronous re-rating job
+type ArgStartRerate struct {
+	ArgRateCDRs
+	JobID     string  // resume the job with this ID if it was stopped, otherwise it is used as ID for the new job
+	PageSize  int     // number of CDRs retrieved from StorDB at once
+	RateLimit float64 // maximum number of CDRs re-rated per second, 0 for unlimited
+}
+
+// RerateAccountDelta sums up the cost changes for one account
+type RerateAccountDelta struct {
+	CDRs         int64
+	PreviousCost float64
+	Cost         float64
+	Delta        float64
+}
+
+// RerateJob is the status of a re-rating job
+type RerateJob struct {
+	Tenant      string
+	ID          string
+	Status      string
+	Processed   int64 // CDRs re-rated successfully
+	Failed      int64 // CDRs which could not be re-rated
+	LastOrderID int64 // checkpoint, the job resumes after this CDR
+	StartTime   time.Time
+	EndTime     time.Time
+	Error       string
+	Accounts    map[string]*RerateAccountDelta // indexed on tenant:account
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (rj *RerateJob) TenantID() string {
+	return utils.ConcatenatedKey(rj.Tenant, rj.ID)
+}
+
+// StoredRerateJob is the checkpoint of a re-rating job kept in DataDB so it can be resumed after a restart
+type StoredRerateJob struct {
+	RerateJob
+	Filter *utils.CDRsFilter
+	Flags  []string
+	Opts   map[string]interface{}
+}
+
+// rerateJob is the internal representation of a running job
+type rerateJob struct {
+	sync.RWMutex
+	RerateJob
+	fltr      *utils.CDRsFilter // OrderIDEnd is fixed at start so new CDRs are not included
+	flags     []string          // the flags as received, kept for the checkpoint
+	flgs      *rateCDRsFlags
+	opts      map[string]interface{}
+	pageSize  int
+	rateLimit float64
+	cancel    chan struct{}
+	done      chan struct{}
+}
+
+// status returns a copy of the job status
+func (job *rerateJob) status() (rj *RerateJob) {
+	job.RLock()
+	rj = new(RerateJob)
+	*rj = job.RerateJob
+	rj.Accounts = make(map[string]*RerateAccountDelta)
+	for acnt, dlt := range job.Accounts {
+		rj.Accounts[acnt] = &RerateAccountDelta{
+			CDRs:         dlt.CDRs,
+			PreviousCost: dlt.PreviousCost,
+			Cost:         dlt.Cost,
+			Delta:        dlt.Delta,
+		}
+	}
+	job.RUnlock()
+	return
+}
+
+// asStored returns the checkpoint of the job
+func (job *rerateJob) asStored() *StoredRerateJob {
+	return &StoredRerateJob{
+		RerateJob: *job.status(),
+		Filter:    job.fltr,
+		Flags:     job.flags,
+		Opts:      job.opts,
+	}
+}
+
+// addCost records the cost change for one account
+func (job *rerateJob) addCost(tnt, acnt string, prevCost, cost float64) {
+	acntID := utils.ConcatenatedKey(tnt, acnt)
+	dlt, has := job.Accounts[acntID]
+	if !has {
+		dlt = new(RerateAccountDelta)
+		job.Accounts[acntID] = dlt
+	}
+	dlt.PreviousCost += prevCost
+	dlt.Cost += cost
+	dlt.Delta = dlt.Cost - dlt.PreviousCost
+}
+
+// finish marks the end of the job
+func (job *rerateJob) finish(status string, err error) {
+	job.Lock()
+	job.Status = status
+	job.EndTime = time.Now()
+	if err != nil {
+		job.Error = err.Error()
+	}
+	job.Unlock()
+}
+
+// newRerateJobFromStored rebuilds a stopped job out of its checkpoint
+func (cdrS *CDRServer) newRerateJobFromStored(sJob *StoredRerateJob) (job *rerateJob, err error) {
+	job = &rerateJob{
+		RerateJob: sJob.RerateJob,
+		fltr:      sJob.Filter,
+		flags:     sJob.Flags,
+		opts:      sJob.Opts,
+	}
+	if job.flgs, err = cdrS.newRateCDRsFlags(sJob.Flags); err != nil {
+		return
+	}
+	job.flgs.store = true
+	if job.Status == utils.MetaRunning { // the engine stopped while running, it can be resumed with V1StartRerate
+		job.Status = utils.MetaCancelled
+	}
+	if job.Accounts == nil {
+		job.Accounts = make(map[string]*RerateAccountDelta)
+	}
+	return
+}
+
+// storeRerateJob checkpoints the job into DataDB
+func (cdrS *CDRServer) storeRerateJob(job *rerateJob) {
+	if cdrS.dm == nil { // no DataDB to resume from
+		return
+	}
+	if err := cdrS.dm.SetRerateJob(job.asStored()); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed storing the checkpoint of rerate job <%s>, error: <%s>",
+				utils.CDRs, job.TenantID(), err.Error()))
+	}
+}
+
+// removeRerateJob removes the checkpoint of a completed job out of DataDB since there is nothing left to resume
+func (cdrS *CDRServer) removeRerateJob(job *rerateJob) {
+	if cdrS.dm == nil {
+		return
+	}
+	if err := cdrS.dm.RemoveRerateJob(job.TenantID()); err != nil && err != utils.ErrNotFound {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed removing the checkpoint of rerate job <%s>, error: <%s>",
+				utils.CDRs, job.TenantID(), err.Error()))
+	}
+}
+
+// getRerateJob returns the job out of the memory or, after a restart, out of its DataDB checkpoint
+// the caller locks the rerateMux
+func (cdrS *CDRServer) getRerateJob(tntID string) (job *rerateJob, err error) {
+	if job, has := cdrS.rerateJobs[tntID]; has {
+		return job, nil
+	}
+	if cdrS.dm == nil {
+		return nil, utils.ErrNotFound
+	}
+	var sJob *StoredRerateJob
+	if sJob, err = cdrS.dm.GetRerateJob(tntID); err != nil {
+		return
+	}
+	return cdrS.newRerateJobFromStored(sJob)
+}
+
+// V1StartRerate starts an asynchronous re-rating job, replying with the job ID.
+// The rerated CDRs are always stored back so the previous cost can be refunded.
+// Resuming a stopped job keeps its filters, only PageSize and RateLimit are updated.
+// The jobs are checkpointed into DataDB after each CDR so the cancelled, failed or
+// interrupted by a restart ones can be resumed, the checkpoint is removed once the job completes.
+func (cdrS *CDRServer) V1StartRerate(args *ArgStartRerate, reply *string) (err error) {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	cdrS.rerateMux.Lock()
+	defer cdrS.rerateMux.Unlock()
+	if cdrS.rerateJobs == nil {
+		cdrS.rerateJobs = make(map[string]*rerateJob)
+	}
+	var job *rerateJob
+	if args.JobID != utils.EmptyString {
+		if job, err = cdrS.getRerateJob(utils.ConcatenatedKey(tnt, args.JobID)); err != nil && err != utils.ErrNotFound {
+			return utils.NewErrServerError(err)
+		}
+		err = nil
+	}
+	if job != nil {
+		cdrS.rerateJobs[job.TenantID()] = job
+		job.Lock()
+		if job.Status == utils.MetaRunning {
+			job.Unlock()
+			return utils.ErrExists
+		}
+		job.Status = utils.MetaRunning
+		job.pageSize = args.PageSize
+		if job.pageSize <= 0 {
+			job.pageSize = rerateDefaultPageSize
+		}
+		job.rateLimit = args.RateLimit
+		job.EndTime = time.Time{}
+		job.Error = utils.EmptyString
+		job.cancel = make(chan struct{})
+		job.done = make(chan struct{})
+		job.Unlock()
+		go cdrS.runRerateJob(job)
+		*reply = job.ID
+		return
+	}
+	var fltr *utils.CDRsFilter
+	if fltr, err = args.RPCCDRsFilter.AsCDRsFilter(cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	var flgs *rateCDRsFlags
+	if flgs, err = cdrS.newRateCDRsFlags(args.Flags); err != nil {
+		return
+	}
+	flgs.store = true
+	if fltr.OrderIDEnd == nil { // limit the job to the CDRs already stored
+		lastFltr := *fltr
+		lastFltr.OrderBy = utils.OrderID + utils.InfieldSep + "desc"
+		lastFltr.Paginator = utils.Paginator{Limit: utils.IntPointer(1)}
+		var cdrs []*CDR
+		if cdrs, _, err = cdrS.cdrDb.GetCDRs(&lastFltr, false); err != nil {
+			return
+		}
+		fltr.OrderIDEnd = utils.Int64Pointer(cdrs[0].OrderID + 1)
+	}
+	fltr.OrderBy = utils.OrderID
+	job = &rerateJob{
+		RerateJob: RerateJob{
+			Tenant:    tnt,
+			ID:        args.JobID,
+			Status:    utils.MetaRunning,
+			StartTime: time.Now(),
+			Accounts:  make(map[string]*RerateAccountDelta),
+		},
+		fltr:      fltr,
+		flags:     args.Flags,
+		flgs:      flgs,
+		opts:      args.Opts,
+		pageSize:  args.PageSize,
+		rateLimit: args.RateLimit,
+		cancel:    make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if job.ID == utils.EmptyString {
+		job.ID = utils.GenUUID()
+	}
+	if fltr.OrderIDStart != nil {
+		job.LastOrderID = *fltr.OrderIDStart - 1
+	}
+	if job.pageSize <= 0 {
+		job.pageSize = rerateDefaultPageSize
+	}
+	cdrS.rerateJobs[job.TenantID()] = job
+	go cdrS.runRerateJob(job)
+	*reply = job.ID
+	return
+}
+
+// V1RerateStatus returns the progress of a re-rating job
+func (cdrS *CDRServer) V1RerateStatus(args *utils.TenantIDWithOpts, reply *RerateJob) (err error) {
+	if args.TenantID == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	cdrS.rerateMux.RLock()
+	job, err := cdrS.getRerateJob(utils.ConcatenatedKey(tnt, args.ID))
+	cdrS.rerateMux.RUnlock()
+	if err != nil {
+		return
+	}
+	*reply = *job.status()
+	return
+}
+
+// V1CancelRerate stops a running re-rating job after the CDR in progress,
+// the job can be resumed later with V1StartRerate
+func (cdrS *CDRServer) V1CancelRerate(args *utils.TenantIDWithOpts, reply *string) (err error) {
+	if args.TenantID == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	cdrS.rerateMux.RLock()
+	job, has := cdrS.rerateJobs[utils.ConcatenatedKey(tnt, args.ID)]
+	cdrS.rerateMux.RUnlock()
+	if !has {
+		return utils.ErrNotFound
+	}
+	job.Lock()
+	if job.Status != utils.MetaRunning {
+		status := job.Status
+		job.Unlock()
+		return fmt.Errorf("rerate job <%s> is %s", job.ID, status)
+	}
+	close(job.cancel)
+	done := job.done
+	job.Unlock()
+	<-done
+	*reply = utils.OK
+	return
+}
+
+// runRerateJob processes the CDRs page by page, starting after the job checkpoint
+func (cdrS *CDRServer) runRerateJob(job *rerateJob) {
+	defer func() {
+		if job.status().Status == utils.MetaCompleted {
+			cdrS.removeRerateJob(job)
+		} else {
+			cdrS.storeRerateJob(job)
+		}
+		close(job.done)
+	}()
+	var throttle <-chan time.Time
+	if job.rateLimit > 0 {
+		tkr := time.NewTicker(time.Duration(float64(time.Second) / job.rateLimit))
+		defer tkr.Stop()
+		throttle = tkr.C
+	}
+	for {
+		fltr := *job.fltr
+		job.RLock()
+		fltr.OrderIDStart = utils.Int64Pointer(job.LastOrderID + 1)
+		job.RUnlock()
+		fltr.Paginator = utils.Paginator{Limit: utils.IntPointer(job.pageSize)}
+		cdrs, _, err := cdrS.cdrDb.GetCDRs(&fltr, false)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				job.finish(utils.MetaCompleted, nil)
+				return
+			}
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> rerate job <%s> failed querying CDRs, error: <%s>",
+					utils.CDRs, job.ID, err.Error()))
+			job.finish(utils.MetaFailed, err)
+			return
+		}
+		for _, cdr := range cdrs {
+			if throttle != nil {
+				select {
+				case <-job.cancel:
+					job.finish(utils.MetaCancelled, nil)
+					return
+				case <-throttle:
+				}
+			} else {
+				select {
+				case <-job.cancel:
+					job.finish(utils.MetaCancelled, nil)
+					return
+				default:
+				}
+			}
+			cdrS.rerateCDR(job, cdr)
+			cdrS.storeRerateJob(job) // after each CDR so a restart does not debit it twice
+		}
+		if len(cdrs) < job.pageSize {
+			job.finish(utils.MetaCompleted, nil)
+			return
+		}
+	}
+}
+
+// rerateCDR refunds and re-debits one CDR, updating the job checkpoint and cost deltas
+func (cdrS *CDRServer) rerateCDR(job *rerateJob, cdr *CDR) {
+	prevCost := cdr.Cost
+	tnt, acnt, orderID := cdr.Tenant, cdr.Account, cdr.OrderID
+	cdr.Cost = -1 // the cost will be recalculated
+	cgrEv := cdr.AsCGREvent()
+	cgrEv.Opts = job.opts
+	var evs []*utils.EventWithFlags
+	_, err := cdrS.guard.Guard(func() (_ interface{}, err error) {
+		evs, err = cdrS.processEvent(cgrEv, job.flgs.chrgS, job.flgs.attrS, false,
+			true, job.flgs.store, true, job.flgs.export, job.flgs.thdS, job.flgs.statS)
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout,
+		utils.MetaCDRs+cdr.CGRID+cdr.RunID+cdr.OriginID)
+	job.Lock()
+	defer job.Unlock()
+	job.LastOrderID = orderID
+	if len(evs) == 0 { // partially executed after rating still returns the events
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> rerate job <%s> failed processing CDR with OrderID %d, error: <%v>",
+				utils.CDRs, job.ID, orderID, err))
+		job.Failed++
+		return
+	}
+	job.Processed++
+	if prevCost < 0 { // was not rated before
+		prevCost = 0
+	}
+	job.addCost(tnt, acnt, prevCost, 0)
+	job.Accounts[utils.ConcatenatedKey(tnt, acnt)].CDRs++
+	for _, ev := range evs {
+		me := MapEvent(ev.Event)
+		cost, errCost := me.GetFloat64(utils.Cost)
+		if errCost != nil || cost < 0 {
+			continue
+		}
+		evAcnt := me.GetStringIgnoreErrors(utils.AccountField)
+		if evAcnt == utils.EmptyString {
+			evAcnt = acnt
+		}
+		job.addCost(tnt, evAcnt, 0, cost)
+	}
+}
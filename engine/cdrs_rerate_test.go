@@ -0,0 +1,233 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+type rerateRALsMock struct {
+	sync.Mutex
+	debits int
+}
+
+func (rM *rerateRALsMock) Call(serviceMethod string, args, rply interface{}) error {
+	switch serviceMethod {
+	case utils.ResponderDebit:
+		rM.Lock()
+		rM.debits++
+		rM.Unlock()
+		*rply.(*CallCost) = CallCost{Cost: 2}
+		return nil
+	case utils.ResponderRefundIncrements:
+		return nil
+	}
+	return rpcclient.ErrUnsupporteServiceMethod
+}
+
+func testRerateCDRServer(t *testing.T, prfx string) (cdrS *CDRServer, mock *rerateRALsMock) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().RaterConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs)}
+	mock = new(rerateRALsMock)
+	internalChan := make(chan rpcclient.ClientConnector, 1)
+	internalChan <- mock
+	storDBChan := make(chan StorDB, 1)
+	storDBChan <- NewInternalDB(nil, nil, false)
+	cdrS = NewCDRServer(cfg, storDBChan, nil, nil, NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs): internalChan,
+	}))
+	for i, acnt := range []string{"1001", "1001", "1002", "1001", "1002"} {
+		cdr := &CDR{
+			CGRID:       utils.Sha1(prfx, utils.IfaceAsString(i)),
+			RunID:       utils.MetaDefault,
+			OriginID:    prfx + utils.IfaceAsString(i),
+			ToR:         utils.MetaVoice,
+			RequestType: utils.MetaPostpaid,
+			Tenant:      prfx + ".cgrates.org",
+			Category:    utils.Call,
+			Account:     acnt,
+			Subject:     acnt,
+			Destination: "1003",
+			AnswerTime:  time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC),
+			Usage:       time.Minute,
+			Cost:        1,
+		}
+		if err := cdrS.cdrDb.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return
+}
+
+func testRerateWait(t *testing.T, cdrS *CDRServer, jobID string) (rj *RerateJob) {
+	rj = new(RerateJob)
+	for i := 0; i < 100; i++ {
+		if err := cdrS.V1RerateStatus(&utils.TenantIDWithOpts{
+			TenantID: &utils.TenantID{ID: jobID}}, rj); err != nil {
+			t.Fatal(err)
+		}
+		if rj.Status != utils.MetaRunning {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("rerate job still running: %s", utils.ToJSON(rj))
+	return
+}
+
+func TestCDRsRerateJob(t *testing.T) {
+	cdrS, mock := testRerateCDRServer(t, "rerate")
+	args := &ArgStartRerate{
+		ArgRateCDRs: ArgRateCDRs{
+			RPCCDRsFilter: utils.RPCCDRsFilter{Tenants: []string{"rerate.cgrates.org"}},
+		},
+		JobID:    "RERATE_1",
+		PageSize: 2,
+	}
+	var reply string
+	if err := cdrS.V1StartRerate(args, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != "RERATE_1" {
+		t.Errorf("Expected job ID RERATE_1, received: %s", reply)
+	}
+	rj := testRerateWait(t, cdrS, reply)
+	if rj.Status != utils.MetaCompleted || rj.Processed != 5 || rj.Failed != 0 {
+		t.Errorf("Unexpected job status: %s", utils.ToJSON(rj))
+	}
+	eAcnts := map[string]*RerateAccountDelta{
+		"rerate.cgrates.org:1001": {CDRs: 3, PreviousCost: 3, Cost: 6, Delta: 3},
+		"rerate.cgrates.org:1002": {CDRs: 2, PreviousCost: 2, Cost: 4, Delta: 2},
+	}
+	if !reflect.DeepEqual(eAcnts, rj.Accounts) {
+		t.Errorf("Expected %s, received: %s", utils.ToJSON(eAcnts), utils.ToJSON(rj.Accounts))
+	}
+	if mock.debits != 5 {
+		t.Errorf("Expected 5 debits, received: %d", mock.debits)
+	}
+	cdrs, _, err := cdrS.cdrDb.GetCDRs(&utils.CDRsFilter{
+		Tenants: []string{"rerate.cgrates.org"}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cdr := range cdrs {
+		if cdr.Cost != 2 {
+			t.Errorf("Expected cost 2, received: %s", utils.ToJSON(cdr))
+		}
+	}
+	if err := cdrS.V1CancelRerate(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{ID: reply}}, &reply); err == nil {
+		t.Error("Expected error canceling a completed job")
+	}
+	if err := cdrS.V1RerateStatus(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{ID: "RERATE_UNKNOWN"}}, rj); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := cdrS.V1RerateStatus(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{Tenant: "itsyscom.com", ID: "RERATE_1"}}, rj); err != utils.ErrNotFound {
+		t.Errorf("Expected %v for the job of another tenant, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestCDRsRerateJobCancelResume(t *testing.T) {
+	cdrS, _ := testRerateCDRServer(t, "rerateResume")
+	args := &ArgStartRerate{
+		ArgRateCDRs: ArgRateCDRs{
+			RPCCDRsFilter: utils.RPCCDRsFilter{Tenants: []string{"rerate.cgrates.org"}},
+		},
+		RateLimit: 0.1, // first CDR after 10s so we can cancel it
+	}
+	var jobID string
+	if err := cdrS.V1StartRerate(args, &jobID); err != nil {
+		t.Fatal(err)
+	}
+	var reply string
+	if err := cdrS.V1StartRerate(&ArgStartRerate{JobID: jobID}, &reply); err != utils.ErrExists {
+		t.Errorf("Expected %v, received: %v", utils.ErrExists, err)
+	}
+	if err := cdrS.V1CancelRerate(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{ID: jobID}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	rj := testRerateWait(t, cdrS, jobID)
+	if rj.Status != utils.MetaCancelled || rj.Processed != 0 {
+		t.Errorf("Unexpected job status: %s", utils.ToJSON(rj))
+	}
+	if err := cdrS.V1StartRerate(&ArgStartRerate{JobID: jobID}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if rj = testRerateWait(t, cdrS, jobID); rj.Status != utils.MetaCompleted || rj.Processed != 5 {
+		t.Errorf("Unexpected job status: %s", utils.ToJSON(rj))
+	}
+}
+
+func TestCDRsRerateJobResumeFromDataDB(t *testing.T) {
+	cdrS, _ := testRerateCDRServer(t, "rerateStored")
+	cdrS.dm = NewDataManager(NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	args := &ArgStartRerate{
+		ArgRateCDRs: ArgRateCDRs{
+			RPCCDRsFilter: utils.RPCCDRsFilter{Tenants: []string{"rerateStored.cgrates.org"}},
+		},
+		JobID:     "RERATE_STORED",
+		RateLimit: 0.1,
+	}
+	var reply string
+	if err := cdrS.V1StartRerate(args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := cdrS.V1CancelRerate(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{ID: reply}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	sJob, err := cdrS.dm.GetRerateJob("cgrates.org:RERATE_STORED")
+	if err != nil {
+		t.Fatal(err)
+	} else if sJob.Status != utils.MetaCancelled ||
+		len(sJob.Filter.Tenants) != 1 || sJob.Filter.Tenants[0] != "rerateStored.cgrates.org" {
+		t.Errorf("Unexpected checkpoint: %s", utils.ToJSON(sJob))
+	}
+	// the engine was restarted while the job was running
+	sJob.Status = utils.MetaRunning
+	if err = cdrS.dm.SetRerateJob(sJob); err != nil {
+		t.Fatal(err)
+	}
+	cdrS.rerateJobs = nil
+	var rj RerateJob
+	if err := cdrS.V1RerateStatus(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{ID: "RERATE_STORED"}}, &rj); err != nil {
+		t.Fatal(err)
+	} else if rj.Status != utils.MetaCancelled {
+		t.Errorf("Expected %s, received: %s", utils.MetaCancelled, utils.ToJSON(rj))
+	}
+	if err := cdrS.V1StartRerate(&ArgStartRerate{JobID: "RERATE_STORED"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if rj := testRerateWait(t, cdrS, "RERATE_STORED"); rj.Status != utils.MetaCompleted || rj.Processed != 5 {
+		t.Errorf("Unexpected job status: %s", utils.ToJSON(rj))
+	}
+	if _, err = cdrS.dm.GetRerateJob("cgrates.org:RERATE_STORED"); err != utils.ErrNotFound {
+		t.Errorf("Expected the checkpoint of the completed job removed, received: %v", err)
+	}
+}
@@ -418,6 +418,30 @@ func (dbM *DataDBMock) RemoveAccountProfileDrv(string, string) error {
 	return utils.ErrNotImplemented
 }
 
+func (dbM *DataDBMock) GetSessionsBackupDrv(string) ([]*StoredSession, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetSessionBackupDrv(string, *StoredSession) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveSessionsBackupDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetRerateJobDrv(string) (*StoredRerateJob, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetRerateJobDrv(*StoredRerateJob) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveRerateJobDrv(string) error {
+	return utils.ErrNotImplemented
+}
+
 func (dbM *DataDBMock) SetVersions(vrs Versions, overwrite bool) (err error) {
 	return utils.ErrNotImplemented
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cgrates/baningo"
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/ltcache"
 )
@@ -747,6 +748,9 @@ func (dm *DataManager) SetStatQueue(sq *StatQueue, metrics []*MetricWithFilters,
 				}
 				if needsRemove {
 					delete(sq.SQMetrics, sqMetricID)
+					for _, bkt := range sq.SQBuckets {
+						delete(bkt.Metrics, sqMetricID)
+					}
 				}
 			}
 
@@ -3685,6 +3689,26 @@ func (dm *DataManager) SetAccountProfile(ap *utils.AccountProfile, withIndex boo
 	return
 }
 
+// SetProvisionedAccountProfile stores the AccountProfile received over the APIs or the loaders
+// keeping the reservations held by AccountS, under the same lock used by AccountS
+func (dm *DataManager) SetProvisionedAccountProfile(ap *utils.AccountProfile, withIndex bool) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	refID := guardian.Guardian.GuardIDs(utils.EmptyString, config.CgrConfig().GeneralCfg().LockingTimeout,
+		utils.ConcatenatedKey(utils.CacheAccountProfiles, ap.Tenant, ap.ID))
+	defer guardian.Guardian.UnguardIDs(refID)
+	var oldAp *utils.AccountProfile
+	if oldAp, err = dm.GetAccountProfile(ap.Tenant, ap.ID); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		oldAp = new(utils.AccountProfile)
+	}
+	ap.KeepReservations(oldAp)
+	return dm.SetAccountProfile(ap, withIndex)
+}
+
 func (dm *DataManager) RemoveAccountProfile(tenant, id string,
 	transactionID string, withIndex bool) (err error) {
 	if dm == nil {
@@ -3718,3 +3742,52 @@ func (dm *DataManager) RemoveAccountProfile(tenant, id string,
 	}
 	return
 }
+
+// GetSessionsBackup returns the sessions backed up by the given node
+func (dm *DataManager) GetSessionsBackup(nodeID string) (sessions []*StoredSession, err error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.GetSessionsBackupDrv(nodeID)
+}
+
+// SetSessionBackup checkpoints one active session for the given node
+func (dm *DataManager) SetSessionBackup(nodeID string, storedSession *StoredSession) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.SetSessionBackupDrv(nodeID, storedSession)
+}
+
+// RemoveSessionsBackup removes the backup of one session or,
+// with empty cgrID, all the sessions backed up by the given node
+func (dm *DataManager) RemoveSessionsBackup(nodeID, cgrID string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.RemoveSessionsBackupDrv(nodeID, cgrID)
+}
+
+// GetRerateJob returns the checkpoint of the rerate job
+func (dm *DataManager) GetRerateJob(tntID string) (job *StoredRerateJob, err error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.GetRerateJobDrv(tntID)
+}
+
+// SetRerateJob checkpoints the rerate job so it can be resumed after a restart
+func (dm *DataManager) SetRerateJob(job *StoredRerateJob) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.SetRerateJobDrv(job)
+}
+
+// RemoveRerateJob removes the checkpoint of the rerate job
+func (dm *DataManager) RemoveRerateJob(tntID string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.RemoveRerateJobDrv(tntID)
+}
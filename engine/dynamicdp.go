@@ -37,6 +37,12 @@ func newDynamicDP(resConns, stsConns, apiConns []string,
 	}
 }
 
+// NewDynamicDP returns a DataProvider able to query the subsystems connected to FilterS
+func NewDynamicDP(cfg *config.CGRConfig, tenant string, initialDP utils.DataProvider) utils.DataProvider {
+	return newDynamicDP(cfg.FilterSCfg().ResourceSConns, cfg.FilterSCfg().StatSConns,
+		cfg.FilterSCfg().ApierSConns, tenant, initialDP)
+}
+
 type dynamicDP struct {
 	resConns  []string
 	stsConns  []string
@@ -62,7 +62,8 @@ func AddFailedPost(expPath, format, module string, ev interface{}, opts map[stri
 	key := utils.ConcatenatedKey(expPath, format, module)
 	// also in case of amqp,amqpv1,s3,sqs and kafka also separe them after queue id
 	if qID := utils.FirstNonEmpty(utils.IfaceAsString(opts[utils.QueueID]),
-		utils.IfaceAsString(opts[utils.KafkaTopic])); len(qID) != 0 {
+		utils.IfaceAsString(opts[utils.KafkaTopic]),
+		utils.IfaceAsString(opts[utils.NatsSubject])); len(qID) != 0 {
 		key = utils.ConcatenatedKey(key, qID)
 	}
 	var failedPost *ExportEvents
@@ -188,6 +189,10 @@ func (expEv *ExportEvents) ReplayFailedPosts(attempts int) (failedEvents *Export
 	case utils.MetaS3jsonMap:
 		pstr = NewS3Poster(expEv.Path, attempts, expEv.Opts)
 		keyFunc = utils.UUIDSha1Prefix
+	case utils.MetaNatsjsonMap:
+		if pstr, err = NewNatsPoster(expEv.Path, attempts, expEv.Opts); err != nil {
+			return expEv, err
+		}
 	}
 	for _, ev := range expEv.Events {
 		if err = pstr.Post(ev.([]byte), keyFunc()); err != nil {
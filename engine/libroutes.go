@@ -35,10 +35,11 @@ type SortedRoute struct {
 
 // SortedRoutes is returned as part of GetRoutes call
 type SortedRoutes struct {
-	ProfileID string         // Profile matched
-	Sorting   string         // Sorting algorithm
-	Count     int            // number of routes returned
-	Routes    []*SortedRoute // list of route IDs and SortingData data
+	ProfileID      string            // Profile matched
+	Sorting        string            // Sorting algorithm
+	Count          int               // number of routes returned
+	Routes         []*SortedRoute    // list of route IDs and SortingData data
+	ExcludedRoutes map[string]string // reasons for the routes excluded by the sorting algorithm, map[routeID]reason
 }
 
 // RouteIDs returns a list of route IDs
@@ -224,6 +225,7 @@ func NewRouteSortDispatcher(lcrS *RouteService) (rsd RouteSortDispatcher) {
 	rsd[utils.MetaReas] = NewResourceAscendetSorter(lcrS)
 	rsd[utils.MetaReds] = NewResourceDescendentSorter(lcrS)
 	rsd[utils.MetaLoad] = NewLoadDistributionSorter(lcrS)
+	rsd[utils.MetaLCQOS] = NewLeastCostQOSSorter(lcrS)
 	return
 }
 
@@ -0,0 +1,48 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"time"
+)
+
+// StoredSession is the representation of an active session as backed up into DataDB
+type StoredSession struct {
+	CGRID         string
+	Tenant        string
+	ResourceID    string
+	ClientConnID  string
+	EventStart    MapEvent
+	DebitInterval time.Duration
+	Chargeable    bool
+	SRuns         []*StoredSRun
+	OptsStart     MapEvent
+	UpdatedAt     time.Time // last time the session was checkpointed
+}
+
+// StoredSRun is the representation of a session run as backed up into DataDB
+type StoredSRun struct {
+	Event         MapEvent
+	CD            *CallDescriptor
+	EventCost     *EventCost
+	ExtraDuration time.Duration
+	LastUsage     time.Duration
+	TotalUsage    time.Duration
+	NextAutoDebit *time.Time // debit loop position
+}
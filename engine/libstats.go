@@ -21,6 +21,7 @@ package engine
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -41,7 +42,10 @@ type StatQueueProfile struct {
 	Stored             bool
 	Blocker            bool // blocker flag to stop processing on filters matched
 	Weight             float64
-	ThresholdIDs       []string // list of thresholds to be checked after changes
+	ThresholdIDs       []string      // list of thresholds to be checked after changes
+	BucketWidth        time.Duration // width of the buckets, windowing is disabled if 0
+	Window             time.Duration // period covered by the buckets
+	WindowType         string        // <*sliding|*tumbling>
 }
 
 // StatQueueProfileWithOpts is used in replicatorV1 for dispatcher
@@ -60,6 +64,32 @@ func (sqp *StatQueueProfile) TenantID() string {
 	return utils.ConcatenatedKey(sqp.Tenant, sqp.ID)
 }
 
+// CheckStatWindowType returns an error for the unknown window types
+func CheckStatWindowType(windowType string) error {
+	switch windowType {
+	case utils.EmptyString, utils.MetaSliding, utils.MetaTumbling:
+		return nil
+	}
+	return fmt.Errorf("unsupported window type: <%s>", windowType)
+}
+
+// windowed returns true if the events are aggregated into buckets
+func (sqp *StatQueueProfile) windowed() bool {
+	return sqp.BucketWidth > 0
+}
+
+// bucketExpiry returns the time when the bucket starting at bucketStart leaves the window
+func (sqp *StatQueueProfile) bucketExpiry(bucketStart time.Time) *time.Time {
+	window := sqp.Window
+	if window < sqp.BucketWidth {
+		window = sqp.BucketWidth
+	}
+	if sqp.WindowType == utils.MetaTumbling {
+		return utils.TimePointer(bucketStart.Truncate(window).Add(window))
+	}
+	return utils.TimePointer(bucketStart.Add(window))
+}
+
 type MetricWithFilters struct {
 	FilterIDs []string
 	MetricID  string
@@ -85,6 +115,22 @@ func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err
 		}
 		sSQ.SQMetrics[metricID] = marshaled
 	}
+	if len(sq.SQBuckets) != 0 {
+		sSQ.SQBuckets = make([]*StoredStatBucket, len(sq.SQBuckets))
+		for i, bkt := range sq.SQBuckets {
+			sSQ.SQBuckets[i] = &StoredStatBucket{
+				StartTime: bkt.StartTime,
+				Metrics:   make(map[string][]byte, len(bkt.Metrics)),
+			}
+			for metricID, metric := range bkt.Metrics {
+				marshaled, err := metric.Marshal(ms)
+				if err != nil {
+					return nil, err
+				}
+				sSQ.SQBuckets[i].Metrics[metricID] = marshaled
+			}
+		}
+	}
 	return
 }
 
@@ -94,9 +140,16 @@ type StoredStatQueue struct {
 	ID         string
 	SQItems    []SQItem
 	SQMetrics  map[string][]byte
+	SQBuckets  []*StoredStatBucket
 	Compressed bool
 }
 
+// StoredStatBucket differs from StatBucket due to serialization of Metrics
+type StoredStatBucket struct {
+	StartTime time.Time
+	Metrics   map[string][]byte
+}
+
 type StatQueueWithOpts struct {
 	*StatQueue
 	Opts map[string]interface{}
@@ -130,6 +183,24 @@ func (ssq *StoredStatQueue) AsStatQueue(ms Marshaler) (sq *StatQueue, err error)
 			sq.SQMetrics[metricID] = metric
 		}
 	}
+	if len(ssq.SQBuckets) != 0 {
+		sq.SQBuckets = make([]*StatBucket, len(ssq.SQBuckets))
+		for i, sBkt := range ssq.SQBuckets {
+			sq.SQBuckets[i] = &StatBucket{
+				StartTime: sBkt.StartTime,
+				Metrics:   make(map[string]StatMetric, len(sBkt.Metrics)),
+			}
+			for metricID, marshaled := range sBkt.Metrics {
+				if metric, err := NewStatMetric(metricID, 0, []string{}); err != nil {
+					return nil, err
+				} else if err := metric.LoadMarshaled(ms, marshaled); err != nil {
+					return nil, err
+				} else {
+					sq.SQBuckets[i].Metrics[metricID] = metric
+				}
+			}
+		}
+	}
 	if ssq.Compressed {
 		sq.Expand()
 	}
@@ -141,6 +212,23 @@ type SQItem struct {
 	ExpiryTime *time.Time // Used to auto-expire events
 }
 
+// StatBucket pre-aggregates the metrics of the events received within BucketWidth
+type StatBucket struct {
+	StartTime time.Time
+	Metrics   map[string]StatMetric
+}
+
+// ID returns the identifier of the bucket, used as EventID within the queue
+func (sb *StatBucket) ID() string {
+	return utils.MetaBucket + utils.InInFieldSep + strconv.FormatInt(sb.StartTime.UnixNano(), 10)
+}
+
+// StatBucketMetrics is the reply of StatSv1.GetQueueBucketMetrics
+type StatBucketMetrics struct {
+	StartTime time.Time
+	Metrics   map[string]float64
+}
+
 func NewStatQueue(tnt, id string, metrics []*MetricWithFilters, minItems int) (sq *StatQueue, err error) {
 	sq = &StatQueue{
 		Tenant:    tnt,
@@ -164,6 +252,7 @@ type StatQueue struct {
 	ID        string
 	SQItems   []SQItem
 	SQMetrics map[string]StatMetric
+	SQBuckets []*StatBucket // ordered by StartTime, populated for windowed queues
 	sqPrfl    *StatQueueProfile
 	dirty     *bool          // needs save
 	ttl       *time.Duration // timeToLeave, picked on each init
@@ -191,6 +280,9 @@ func (sq *StatQueue) ProcessEvent(tnt, evID string, filterS *FilterS, evNm utils
 	if _, err = sq.remExpired(); err != nil {
 		return
 	}
+	if sq.sqPrfl.windowed() {
+		return sq.addBucketEvent(tnt, filterS, evNm)
+	}
 	if err = sq.remOnQueueLength(); err != nil {
 		return
 	}
@@ -222,7 +314,13 @@ func (sq *StatQueue) remExpired() (removed int, err error) {
 		if item.ExpiryTime.After(time.Now()) {
 			break
 		}
-		if err = sq.remEventWithID(item.EventID); err != nil {
+		if len(sq.SQBuckets) != 0 &&
+			sq.SQBuckets[0].ID() == item.EventID {
+			err = sq.remBucket()
+		} else {
+			err = sq.remEventWithID(item.EventID)
+		}
+		if err != nil {
 			return
 		}
 		expIdx = utils.IntPointer(i)
@@ -250,6 +348,92 @@ func (sq *StatQueue) remOnQueueLength() (err error) {
 	return
 }
 
+// remBucket removes the oldest bucket together with its events from metrics
+func (sq *StatQueue) remBucket() (err error) {
+	bktID := sq.SQBuckets[0].ID()
+	for metricID, metric := range sq.SQMetrics {
+		for err == nil {
+			err = metric.RemEvent(bktID)
+		}
+		if err.Error() != utils.ErrNotFound.Error() {
+			utils.Logger.Warning(fmt.Sprintf("<StatQueue> metricID: %s, remove bucket: %s, error: %s", metricID, bktID, err.Error()))
+			return
+		}
+		err = nil
+	}
+	sq.SQBuckets = sq.SQBuckets[1:]
+	return
+}
+
+// addBucketEvent computes metrics for an event within the current bucket
+func (sq *StatQueue) addBucketEvent(tnt string, filterS *FilterS, evNm utils.MapStorage) (err error) {
+	now := time.Now()
+	bktStart := now.Truncate(sq.sqPrfl.BucketWidth)
+	var bkt *StatBucket
+	if len(sq.SQBuckets) != 0 &&
+		sq.SQBuckets[len(sq.SQBuckets)-1].StartTime.Equal(bktStart) {
+		bkt = sq.SQBuckets[len(sq.SQBuckets)-1]
+	} else {
+		bkt = &StatBucket{
+			StartTime: bktStart,
+			Metrics:   make(map[string]StatMetric),
+		}
+		sq.SQBuckets = append(sq.SQBuckets, bkt)
+		sq.SQItems = append(sq.SQItems, SQItem{
+			EventID:    bkt.ID(),
+			ExpiryTime: sq.sqPrfl.bucketExpiry(bktStart),
+		})
+	}
+	bktID := bkt.ID()
+	var pass bool
+	dDP := newDynamicDP(config.CgrConfig().FilterSCfg().ResourceSConns, config.CgrConfig().FilterSCfg().StatSConns,
+		config.CgrConfig().FilterSCfg().ApierSConns, tnt, utils.MapStorage{utils.MetaReq: evNm[utils.MetaReq]})
+	for metricID, metric := range sq.SQMetrics {
+		if pass, err = filterS.Pass(tnt, metric.GetFilterIDs(),
+			evNm); err != nil {
+			return
+		} else if !pass {
+			continue
+		}
+		bktMetric, has := bkt.Metrics[metricID]
+		if !has {
+			if bktMetric, err = NewStatMetric(metricID,
+				metric.GetMinItems(), metric.GetFilterIDs()); err != nil {
+				return
+			}
+			bkt.Metrics[metricID] = bktMetric
+		}
+		if err = metric.AddEvent(bktID, dDP); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<StatQueue> metricID: %s, add bucket: %s, error: %s",
+				metricID, bktID, err.Error()))
+			return
+		}
+		if err = bktMetric.AddEvent(bktID, dDP); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// BucketMetrics returns the float64 values of the metrics for each bucket
+func (sq *StatQueue) BucketMetrics(roundingDecimal int) (bktMetrics []*StatBucketMetrics) {
+	bktMetrics = make([]*StatBucketMetrics, len(sq.SQBuckets))
+	for i, bkt := range sq.SQBuckets {
+		bktMetrics[i] = &StatBucketMetrics{
+			StartTime: bkt.StartTime,
+			Metrics:   make(map[string]float64, len(sq.SQMetrics)),
+		}
+		for metricID := range sq.SQMetrics {
+			val := utils.StatsNA
+			if metric, has := bkt.Metrics[metricID]; has {
+				val = metric.GetFloat64Value(roundingDecimal)
+			}
+			bktMetrics[i].Metrics[metricID] = val
+		}
+	}
+	return
+}
+
 // addStatEvent computes metrics for an event
 func (sq *StatQueue) addStatEvent(tnt, evID string, filterS *FilterS, evNm utils.MapStorage) (err error) {
 	var expTime *time.Time
@@ -278,7 +462,8 @@ func (sq *StatQueue) addStatEvent(tnt, evID string, filterS *FilterS, evNm utils
 }
 
 func (sq *StatQueue) Compress(maxQL int64, roundDec int) bool {
-	if int64(len(sq.SQItems)) < maxQL || maxQL == 0 {
+	if int64(len(sq.SQItems)) < maxQL || maxQL == 0 ||
+		len(sq.SQBuckets) != 0 { // buckets are already aggregated
 		return false
 	}
 	var newSQItems []SQItem
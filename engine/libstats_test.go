@@ -18,6 +18,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -693,3 +694,94 @@ func TestStatRemoveExpiredQueue(t *testing.T) {
 		t.Errorf("Expecting: 2, received: %+v", len(sq.SQItems))
 	}
 }
+
+func TestStatWindowedBuckets(t *testing.T) {
+	sq, err := NewStatQueue("cgrates.org", "SQ_WINDOW", []*MetricWithFilters{
+		{MetricID: utils.MetaSum + utils.HashtagSep + "~*req.Usage"},
+		{MetricID: utils.MetaAverage + utils.HashtagSep + "~*req.Usage"},
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sq.sqPrfl = &StatQueueProfile{
+		BucketWidth: 100 * time.Millisecond,
+		Window:      200 * time.Millisecond,
+		WindowType:  utils.MetaSliding,
+	}
+	sumID := utils.MetaSum + utils.HashtagSep + "~*req.Usage"
+	avgID := utils.MetaAverage + utils.HashtagSep + "~*req.Usage"
+	// start right after a bucket boundary so the events land in the same bucket
+	time.Sleep(time.Until(time.Now().Truncate(100 * time.Millisecond).Add(105 * time.Millisecond)))
+	for i, usage := range []float64{10, 20, 30} {
+		if err := sq.ProcessEvent("cgrates.org", fmt.Sprintf("EV_%d", i), nil,
+			utils.MapStorage{utils.MetaReq: map[string]interface{}{"Usage": usage}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(sq.SQBuckets) != 1 || len(sq.SQItems) != 1 {
+		t.Fatalf("expecting 1 bucket, received: %s", utils.ToJSON(sq.SQItems))
+	}
+	if val := sq.SQMetrics[sumID].GetFloat64Value(2); val != 60 {
+		t.Errorf("expecting: 60, received: %v", val)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := sq.ProcessEvent("cgrates.org", "EV_3", nil,
+		utils.MapStorage{utils.MetaReq: map[string]interface{}{"Usage": 40.}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(sq.SQBuckets) != 2 {
+		t.Fatalf("expecting 2 buckets, received: %d", len(sq.SQBuckets))
+	}
+	if val := sq.SQMetrics[avgID].GetFloat64Value(2); val != 25 {
+		t.Errorf("expecting: 25, received: %v", val)
+	}
+	bktMetrics := sq.BucketMetrics(2)
+	if len(bktMetrics) != 2 ||
+		bktMetrics[0].Metrics[sumID] != 60 || bktMetrics[0].Metrics[avgID] != 20 ||
+		bktMetrics[1].Metrics[sumID] != 40 || bktMetrics[1].Metrics[avgID] != 40 {
+		t.Errorf("received: %s", utils.ToJSON(bktMetrics))
+	}
+	// the first bucket leaves the window
+	time.Sleep(150 * time.Millisecond)
+	if _, err := sq.remExpired(); err != nil {
+		t.Fatal(err)
+	}
+	if len(sq.SQBuckets) != 1 || len(sq.SQItems) != 1 {
+		t.Fatalf("expecting 1 bucket, received: %s", utils.ToJSON(sq.SQItems))
+	}
+	if val := sq.SQMetrics[sumID].GetFloat64Value(2); val != 40 {
+		t.Errorf("expecting: 40, received: %v", val)
+	}
+	if sq.Compress(1, 2) {
+		t.Error("expecting windowed queue not to compress")
+	}
+	ssq, err := NewStoredStatQueue(sq, &jMarshaler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcv, err := ssq.AsStatQueue(&jMarshaler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sq.BucketMetrics(2), rcv.BucketMetrics(2)) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(sq.BucketMetrics(2)), utils.ToJSON(rcv.BucketMetrics(2)))
+	}
+	if !reflect.DeepEqual(sq.SQItems, rcv.SQItems) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(sq.SQItems), utils.ToJSON(rcv.SQItems))
+	}
+}
+
+func TestStatQueueProfileBucketExpiry(t *testing.T) {
+	bktStart := time.Date(2021, 1, 1, 10, 7, 0, 0, time.UTC)
+	sqp := &StatQueueProfile{
+		BucketWidth: time.Minute,
+		Window:      15 * time.Minute,
+	}
+	if rcv := sqp.bucketExpiry(bktStart); !rcv.Equal(time.Date(2021, 1, 1, 10, 22, 0, 0, time.UTC)) {
+		t.Errorf("received: %v", rcv)
+	}
+	sqp.WindowType = utils.MetaTumbling
+	if rcv := sqp.bucketExpiry(bktStart); !rcv.Equal(time.Date(2021, 1, 1, 10, 15, 0, 0, time.UTC)) {
+		t.Errorf("received: %v", rcv)
+	}
+}
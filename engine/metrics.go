@@ -0,0 +1,182 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"io"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// Metrics is the registry used by the prometheus endpoint
+var Metrics = NewMetricsRegistry()
+
+// MetricsCollector adds the metrics of a subsystem
+// params are the query parameters of the scrape request
+type MetricsCollector func(pm *utils.PrometheusMetrics, params url.Values)
+
+// NewMetricsRegistry returns an empty MetricsRegistry
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{
+		collectors: make(map[string]MetricsCollector),
+		rpcStats:   make(map[string]*rpcMethodStats),
+		counters:   make(map[string]*metricsCounter),
+	}
+}
+
+// MetricsRegistry keeps the RPC statistics and the collectors registered by the subsystems
+type MetricsRegistry struct {
+	cMux       sync.RWMutex
+	collectors map[string]MetricsCollector // indexed on subsystem name
+	rMux       sync.RWMutex
+	rpcEnabled bool                       // record the API calls
+	rpcStats   map[string]*rpcMethodStats // indexed on API method
+	cntMux     sync.Mutex
+	counters   map[string]*metricsCounter // indexed on name and labels
+}
+
+type metricsCounter struct {
+	name   string
+	help   string
+	labels map[string]string
+	value  float64
+}
+
+type rpcMethodStats struct {
+	calls    uint64
+	errors   uint64
+	duration time.Duration
+}
+
+// RegisterCollector adds or replaces the collector for the subsystem
+func (mR *MetricsRegistry) RegisterCollector(subsys string, mc MetricsCollector) {
+	mR.cMux.Lock()
+	mR.collectors[subsys] = mc
+	mR.cMux.Unlock()
+}
+
+// UnregisterCollector removes the collector of the subsystem
+func (mR *MetricsRegistry) UnregisterCollector(subsys string) {
+	mR.cMux.Lock()
+	delete(mR.collectors, subsys)
+	mR.cMux.Unlock()
+}
+
+// EnableRPCStats starts recording the API calls
+func (mR *MetricsRegistry) EnableRPCStats() {
+	mR.rMux.Lock()
+	mR.rpcEnabled = true
+	mR.rMux.Unlock()
+}
+
+// RPCStatsEnabled returns true if the API calls should be recorded
+func (mR *MetricsRegistry) RPCStatsEnabled() (enabled bool) {
+	mR.rMux.RLock()
+	enabled = mR.rpcEnabled
+	mR.rMux.RUnlock()
+	return
+}
+
+// ObserveRPC records one API call
+func (mR *MetricsRegistry) ObserveRPC(method string, dur time.Duration, failed bool) {
+	mR.rMux.Lock()
+	st, has := mR.rpcStats[method]
+	if !has {
+		st = new(rpcMethodStats)
+		mR.rpcStats[method] = st
+	}
+	st.calls++
+	st.duration += dur
+	if failed {
+		st.errors++
+	}
+	mR.rMux.Unlock()
+}
+
+// IncrementCounter increments the counter identified by name and labels
+func (mR *MetricsRegistry) IncrementCounter(name, help string, labels map[string]string) {
+	lblKeys := make([]string, 0, len(labels))
+	for k, v := range labels {
+		lblKeys = append(lblKeys, k+utils.AttrValueSep+v)
+	}
+	sort.Strings(lblKeys)
+	cntKey := utils.ConcatenatedKey(append([]string{name}, lblKeys...)...)
+	mR.cntMux.Lock()
+	cnt, has := mR.counters[cntKey]
+	if !has {
+		cnt = &metricsCounter{name: name, help: help, labels: labels}
+		mR.counters[cntKey] = cnt
+	}
+	cnt.value++
+	mR.cntMux.Unlock()
+}
+
+// Collect gathers the RPC statistics together with the metrics of the registered collectors
+func (mR *MetricsRegistry) Collect(params url.Values) (pm *utils.PrometheusMetrics) {
+	pm = utils.NewPrometheusMetrics()
+	mR.rMux.Lock()
+	methods := make([]string, 0, len(mR.rpcStats))
+	for method := range mR.rpcStats {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		st := mR.rpcStats[method]
+		lbls := map[string]string{"method": method}
+		pm.Add("cgrates_rpc_requests_total", utils.PrometheusCounter,
+			"Number of API requests served.", lbls, float64(st.calls))
+		pm.Add("cgrates_rpc_errors_total", utils.PrometheusCounter,
+			"Number of API requests ending with error.", lbls, float64(st.errors))
+		pm.AddSummary("cgrates_rpc_request_duration_seconds",
+			"Time spent serving the API requests.", lbls, st.duration.Seconds(), st.calls)
+	}
+	mR.rMux.Unlock()
+	mR.cntMux.Lock()
+	cntKeys := make([]string, 0, len(mR.counters))
+	for cntKey := range mR.counters {
+		cntKeys = append(cntKeys, cntKey)
+	}
+	sort.Strings(cntKeys)
+	for _, cntKey := range cntKeys {
+		cnt := mR.counters[cntKey]
+		pm.Add(cnt.name, utils.PrometheusCounter, cnt.help, cnt.labels, cnt.value)
+	}
+	mR.cntMux.Unlock()
+	mR.cMux.RLock()
+	subsystems := make([]string, 0, len(mR.collectors))
+	for subsys := range mR.collectors {
+		subsystems = append(subsystems, subsys)
+	}
+	sort.Strings(subsystems) // keep the samples order stable between scrapes
+	for _, subsys := range subsystems {
+		mR.collectors[subsys](pm, params)
+	}
+	mR.cMux.RUnlock()
+	return
+}
+
+// WriteTo writes all the metrics in the Prometheus text format
+func (mR *MetricsRegistry) WriteTo(w io.Writer, params url.Values) (err error) {
+	_, err = mR.Collect(params).WriteTo(w)
+	return
+}
@@ -0,0 +1,108 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestMetricsRegistry(t *testing.T) {
+	mR := NewMetricsRegistry()
+	if mR.RPCStatsEnabled() {
+		t.Error("Expected the RPC stats to be disabled by default")
+	}
+	mR.EnableRPCStats()
+	if !mR.RPCStatsEnabled() {
+		t.Error("Expected the RPC stats to be enabled")
+	}
+	mR.ObserveRPC(utils.CoreSv1Status, 100*time.Millisecond, false)
+	mR.ObserveRPC(utils.CoreSv1Status, 300*time.Millisecond, true)
+	mR.IncrementCounter("cgrates_ers_events_processed_total", "Number of events processed by ERs.",
+		map[string]string{"reader": "file_reader"})
+	mR.IncrementCounter("cgrates_ers_events_processed_total", "Number of events processed by ERs.",
+		map[string]string{"reader": "file_reader"})
+	var rcvParams url.Values
+	mR.RegisterCollector(utils.StatS, func(pm *utils.PrometheusMetrics, params url.Values) {
+		rcvParams = params
+		pm.Add("cgrates_stat_metric", utils.PrometheusGauge, "Value of the StatQueue metric.",
+			map[string]string{"metric": utils.MetaTCC}, 10)
+	})
+	mR.RegisterCollector(utils.SessionS, func(pm *utils.PrometheusMetrics, _ url.Values) {
+		pm.Add("cgrates_sessions_active", utils.PrometheusGauge, "Number of active sessions.", nil, 3)
+	})
+	mR.UnregisterCollector(utils.SessionS)
+	exp := `# HELP cgrates_ers_events_processed_total Number of events processed by ERs.
+# TYPE cgrates_ers_events_processed_total counter
+cgrates_ers_events_processed_total{reader="file_reader"} 2
+# HELP cgrates_rpc_errors_total Number of API requests ending with error.
+# TYPE cgrates_rpc_errors_total counter
+cgrates_rpc_errors_total{method="CoreSv1.Status"} 1
+# HELP cgrates_rpc_request_duration_seconds Time spent serving the API requests.
+# TYPE cgrates_rpc_request_duration_seconds summary
+cgrates_rpc_request_duration_seconds_sum{method="CoreSv1.Status"} 0.4
+cgrates_rpc_request_duration_seconds_count{method="CoreSv1.Status"} 2
+# HELP cgrates_rpc_requests_total Number of API requests served.
+# TYPE cgrates_rpc_requests_total counter
+cgrates_rpc_requests_total{method="CoreSv1.Status"} 2
+# HELP cgrates_stat_metric Value of the StatQueue metric.
+# TYPE cgrates_stat_metric gauge
+cgrates_stat_metric{metric="*tcc"} 10
+`
+	params := url.Values{utils.PrometheusStatIDs: []string{"SQ_1"}}
+	var buf bytes.Buffer
+	if err := mR.WriteTo(&buf, params); err != nil {
+		t.Error(err)
+	} else if buf.String() != exp {
+		t.Errorf("Expected \n%s\n, received \n%s", exp, buf.String())
+	}
+	if rcvParams.Get(utils.PrometheusStatIDs) != "SQ_1" {
+		t.Errorf("Unexpected params: %+v", rcvParams)
+	}
+}
+
+func TestCacheSCollectMetrics(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	chS := NewCacheS(cfg, nil, nil)
+	chS.Set(utils.CacheRateProfiles, "cgrates.org:RP1", nil, nil, true, utils.NonTransactional)
+	chS.Get(utils.CacheRateProfiles, "cgrates.org:RP1")
+	chS.Get(utils.CacheRateProfiles, "cgrates.org:RP2")
+	chS.Get(utils.CacheRateProfiles, "cgrates.org:RP2")
+	pm := utils.NewPrometheusMetrics()
+	chS.collectMetrics(pm, nil)
+	var buf bytes.Buffer
+	if _, err := pm.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, exp := range []string{
+		`cgrates_cache_items{partition="*rate_profiles"} 1`,
+		`cgrates_cache_hits_total{partition="*rate_profiles"} 1`,
+		`cgrates_cache_misses_total{partition="*rate_profiles"} 2`,
+	} {
+		if !strings.Contains(buf.String(), exp+"\n") {
+			t.Errorf("Expected <%s> in \n%s", exp, buf.String())
+		}
+	}
+}
@@ -1047,7 +1047,7 @@ type ResourceMdls []*ResourceMdl
 func (tps ResourceMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.UsageTTL, utils.Limit, utils.AllocationMessage, utils.Blocker, utils.Stored,
-		utils.Weight, utils.ThresholdIDs}
+		utils.Weight, utils.ThresholdIDs, utils.RateWindow}
 }
 
 func (tps ResourceMdls) AsTPResources() (result []*utils.TPResourceProfile) {
@@ -1078,6 +1078,9 @@ func (tps ResourceMdls) AsTPResources() (result []*utils.TPResourceProfile) {
 		if tp.AllocationMessage != utils.EmptyString {
 			rl.AllocationMessage = tp.AllocationMessage
 		}
+		if tp.RateWindow != utils.EmptyString {
+			rl.RateWindow = tp.RateWindow
+		}
 		rl.Blocker = tp.Blocker
 		rl.Stored = tp.Stored
 		if len(tp.ActivationInterval) != 0 {
@@ -1131,6 +1134,7 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls ResourceMdls) {
 			Weight:            rl.Weight,
 			Limit:             rl.Limit,
 			AllocationMessage: rl.AllocationMessage,
+			RateWindow:        rl.RateWindow,
 		}
 		if rl.ActivationInterval != nil {
 			if rl.ActivationInterval.ActivationTime != utils.EmptyString {
@@ -1161,6 +1165,7 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls ResourceMdls) {
 			mdl.Weight = rl.Weight
 			mdl.Limit = rl.Limit
 			mdl.AllocationMessage = rl.AllocationMessage
+			mdl.RateWindow = rl.RateWindow
 			if rl.ActivationInterval != nil {
 				if rl.ActivationInterval.ActivationTime != utils.EmptyString {
 					mdl.ActivationInterval = rl.ActivationInterval.ActivationTime
@@ -1198,6 +1203,11 @@ func APItoResource(tpRL *utils.TPResourceProfile, timezone string) (rp *Resource
 			return nil, err
 		}
 	}
+	if tpRL.RateWindow != utils.EmptyString {
+		if rp.RateWindow, err = utils.ParseDurationWithNanosecs(tpRL.RateWindow); err != nil {
+			return nil, err
+		}
+	}
 	for i, fltr := range tpRL.FilterIDs {
 		rp.FilterIDs[i] = fltr
 	}
@@ -1233,6 +1243,9 @@ func ResourceProfileToAPI(rp *ResourceProfile) (tpRL *utils.TPResourceProfile) {
 	if rp.UsageTTL != time.Duration(0) {
 		tpRL.UsageTTL = rp.UsageTTL.String()
 	}
+	if rp.RateWindow != time.Duration(0) {
+		tpRL.RateWindow = rp.RateWindow.String()
+	}
 	for i, fli := range rp.FilterIDs {
 		tpRL.FilterIDs[i] = fli
 	}
@@ -1257,7 +1270,8 @@ type StatMdls []*StatMdl
 func (tps StatMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs,
+		utils.BucketWidth, utils.Window, utils.WindowType}
 }
 
 func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
@@ -1279,6 +1293,9 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 				MinItems:    model.MinItems,
 				TTL:         model.TTL,
 				QueueLength: model.QueueLength,
+				BucketWidth: model.BucketWidth,
+				Window:      model.Window,
+				WindowType:  model.WindowType,
 			}
 		}
 		if model.Blocker {
@@ -1299,6 +1316,15 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 		if model.QueueLength != 0 {
 			st.QueueLength = model.QueueLength
 		}
+		if model.BucketWidth != utils.EmptyString {
+			st.BucketWidth = model.BucketWidth
+		}
+		if model.Window != utils.EmptyString {
+			st.Window = model.Window
+		}
+		if model.WindowType != utils.EmptyString {
+			st.WindowType = model.WindowType
+		}
 		if model.ThresholdIDs != utils.EmptyString {
 			if _, has := thresholdMap[key.TenantID()]; !has {
 				thresholdMap[key.TenantID()] = make(utils.StringSet)
@@ -1391,6 +1417,9 @@ func APItoModelStats(st *utils.TPStatProfile) (mdls StatMdls) {
 					}
 					mdl.ThresholdIDs += val
 				}
+				mdl.BucketWidth = st.BucketWidth
+				mdl.Window = st.Window
+				mdl.WindowType = st.WindowType
 			}
 			for i, val := range metric.FilterIDs {
 				if i != 0 {
@@ -1417,12 +1446,26 @@ func APItoStats(tpST *utils.TPStatProfile, timezone string) (st *StatQueueProfil
 		Blocker:      tpST.Blocker,
 		Weight:       tpST.Weight,
 		ThresholdIDs: make([]string, len(tpST.ThresholdIDs)),
+		WindowType:   tpST.WindowType,
+	}
+	if err = CheckStatWindowType(st.WindowType); err != nil {
+		return nil, err
 	}
 	if tpST.TTL != utils.EmptyString {
 		if st.TTL, err = utils.ParseDurationWithNanosecs(tpST.TTL); err != nil {
 			return nil, err
 		}
 	}
+	if tpST.BucketWidth != utils.EmptyString {
+		if st.BucketWidth, err = utils.ParseDurationWithNanosecs(tpST.BucketWidth); err != nil {
+			return nil, err
+		}
+	}
+	if tpST.Window != utils.EmptyString {
+		if st.Window, err = utils.ParseDurationWithNanosecs(tpST.Window); err != nil {
+			return nil, err
+		}
+	}
 	for i, metric := range tpST.Metrics {
 		st.Metrics[i] = &MetricWithFilters{
 			MetricID:  metric.MetricID,
@@ -1456,6 +1499,7 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 		Weight:             st.Weight,
 		MinItems:           st.MinItems,
 		ThresholdIDs:       make([]string, len(st.ThresholdIDs)),
+		WindowType:         st.WindowType,
 	}
 	for i, metric := range st.Metrics {
 		tpST.Metrics[i] = &utils.MetricWithFilters{
@@ -1472,6 +1516,12 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 	if st.TTL != time.Duration(0) {
 		tpST.TTL = st.TTL.String()
 	}
+	if st.BucketWidth != time.Duration(0) {
+		tpST.BucketWidth = st.BucketWidth.String()
+	}
+	if st.Window != time.Duration(0) {
+		tpST.Window = st.Window.String()
+	}
 	for i, fli := range st.FilterIDs {
 		tpST.FilterIDs[i] = fli
 	}
@@ -1496,13 +1546,14 @@ type ThresholdMdls []*ThresholdMdl
 func (tps ThresholdMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.MaxHits, utils.MinHits, utils.MinSleep,
-		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async}
+		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async, utils.ActionProfileIDs}
 }
 
 func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 	mst := make(map[string]*utils.TPThresholdProfile)
 	filterMap := make(map[string]utils.StringSet)
 	actionMap := make(map[string]utils.StringSet)
+	actPrfMap := make(map[string]utils.StringSet)
 	for _, tp := range tps {
 		tenID := (&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()
 		th, found := mst[tenID]
@@ -1524,6 +1575,12 @@ func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 			}
 			actionMap[tenID].AddSlice(strings.Split(tp.ActionIDs, utils.InfieldSep))
 		}
+		if tp.ActionProfileIDs != utils.EmptyString {
+			if _, has := actPrfMap[tenID]; !has {
+				actPrfMap[tenID] = make(utils.StringSet)
+			}
+			actPrfMap[tenID].AddSlice(strings.Split(tp.ActionProfileIDs, utils.InfieldSep))
+		}
 		if tp.Weight != 0 {
 			th.Weight = tp.Weight
 		}
@@ -1552,6 +1609,9 @@ func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 		result[i] = th
 		result[i].FilterIDs = filterMap[tntID].AsSlice()
 		result[i].ActionIDs = actionMap[tntID].AsSlice()
+		if apIDs, has := actPrfMap[tntID]; has {
+			result[i].ActionProfileIDs = apIDs.AsSlice()
+		}
 		i++
 	}
 	return
@@ -1559,7 +1619,7 @@ func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 
 func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls ThresholdMdls) {
 	if th != nil {
-		if len(th.ActionIDs) == 0 {
+		if len(th.ActionIDs) == 0 && len(th.ActionProfileIDs) == 0 {
 			return
 		}
 		min := len(th.FilterIDs)
@@ -1631,6 +1691,31 @@ func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls ThresholdMdls) {
 				mdls = append(mdls, mdl)
 			}
 		}
+		if len(th.ActionProfileIDs) != 0 {
+			if len(th.ActionIDs) == 0 { // no model holds the profile fields yet
+				mdl := &ThresholdMdl{
+					Tpid:     th.TPid,
+					Tenant:   th.Tenant,
+					ID:       th.ID,
+					Blocker:  th.Blocker,
+					Weight:   th.Weight,
+					MaxHits:  th.MaxHits,
+					MinHits:  th.MinHits,
+					MinSleep: th.MinSleep,
+					Async:    th.Async,
+				}
+				if th.ActivationInterval != nil {
+					if th.ActivationInterval.ActivationTime != utils.EmptyString {
+						mdl.ActivationInterval = th.ActivationInterval.ActivationTime
+					}
+					if th.ActivationInterval.ExpiryTime != utils.EmptyString {
+						mdl.ActivationInterval += utils.InfieldSep + th.ActivationInterval.ExpiryTime
+					}
+				}
+				mdls = append(ThresholdMdls{mdl}, mdls...)
+			}
+			mdls[0].ActionProfileIDs = strings.Join(th.ActionProfileIDs, utils.InfieldSep)
+		}
 	}
 	return
 }
@@ -1656,6 +1741,12 @@ func APItoThresholdProfile(tpTH *utils.TPThresholdProfile, timezone string) (th
 		th.ActionIDs[i] = ati
 
 	}
+	if len(tpTH.ActionProfileIDs) != 0 {
+		th.ActionProfileIDs = make([]string, len(tpTH.ActionProfileIDs))
+		for i, apID := range tpTH.ActionProfileIDs {
+			th.ActionProfileIDs[i] = apID
+		}
+	}
 	for i, fli := range tpTH.FilterIDs {
 		th.FilterIDs[i] = fli
 	}
@@ -1689,6 +1780,12 @@ func ThresholdProfileToAPI(th *ThresholdProfile) (tpTH *utils.TPThresholdProfile
 	for i, fli := range th.ActionIDs {
 		tpTH.ActionIDs[i] = fli
 	}
+	if len(th.ActionProfileIDs) != 0 {
+		tpTH.ActionProfileIDs = make([]string, len(th.ActionProfileIDs))
+		for i, apID := range th.ActionProfileIDs {
+			tpTH.ActionProfileIDs[i] = apID
+		}
+	}
 
 	if th.ActivationInterval != nil {
 		if !th.ActivationInterval.ActivationTime.IsZero() {
@@ -2900,6 +2997,7 @@ func (tps RateProfileMdls) CSVHeader() (result []string) {
 		utils.MaxCost, utils.MaxCostStrategy, utils.RateID,
 		utils.RateFilterIDs, utils.RateActivationStart, utils.RateWeight, utils.RateBlocker,
 		utils.RateIntervalStart, utils.RateFixedFee, utils.RateRecurrentFee, utils.RateUnit, utils.RateIncrement,
+		utils.VolumeUsage, utils.VolumeTiers,
 	}
 }
 
@@ -2972,6 +3070,20 @@ func (tps RateProfileMdls) AsTPRateProfile() (result []*utils.TPRateProfile) {
 		if tp.MaxCostStrategy != utils.EmptyString {
 			rPrf.MaxCostStrategy = tp.MaxCostStrategy
 		}
+		if tp.VolumeUsage != utils.EmptyString {
+			rPrf.VolumeUsage = tp.VolumeUsage
+		}
+		if tp.VolumeTiers != utils.EmptyString {
+			rPrf.VolumeTiers = make([]*utils.TPVolumeTier, 0)
+			for _, vTier := range strings.Split(tp.VolumeTiers, utils.InfieldSep) {
+				vTierSplt := strings.Split(vTier, utils.ConcatenatedKeySep)
+				tpVTier := &utils.TPVolumeTier{VolumeStart: vTierSplt[0]}
+				if len(vTierSplt) == 2 {
+					tpVTier.Factor, _ = strconv.ParseFloat(vTierSplt[1], 64)
+				}
+				rPrf.VolumeTiers = append(rPrf.VolumeTiers, tpVTier)
+			}
+		}
 		if tp.ActivationInterval != utils.EmptyString {
 			rPrf.ActivationInterval = new(utils.TPActivationInterval)
 			aiSplt := strings.Split(tp.ActivationInterval, utils.InfieldSep)
@@ -3033,6 +3145,14 @@ func APItoModelTPRateProfile(tPrf *utils.TPRateProfile) (mdls RateProfileMdls) {
 				mdl.MinCost = tPrf.MinCost
 				mdl.MaxCost = tPrf.MaxCost
 				mdl.MaxCostStrategy = tPrf.MaxCostStrategy
+				mdl.VolumeUsage = tPrf.VolumeUsage
+				for i, vTier := range tPrf.VolumeTiers {
+					if i != 0 {
+						mdl.VolumeTiers += utils.InfieldSep
+					}
+					mdl.VolumeTiers += vTier.VolumeStart + utils.ConcatenatedKeySep +
+						strconv.FormatFloat(vTier.Factor, 'f', -1, 64)
+				}
 			}
 			mdl.RateID = rate.ID
 			if j == 0 {
@@ -3066,6 +3186,7 @@ func APItoRateProfile(tpRp *utils.TPRateProfile, timezone string) (rp *RateProfi
 		ID:              tpRp.ID,
 		FilterIDs:       make([]string, len(tpRp.FilterIDs)),
 		MaxCostStrategy: tpRp.MaxCostStrategy,
+		VolumeUsage:     tpRp.VolumeUsage,
 		Rates:           make(map[string]*Rate),
 		MinCost:         utils.NewDecimalFromFloat64(tpRp.MinCost),
 		MaxCost:         utils.NewDecimalFromFloat64(tpRp.MaxCost),
@@ -3085,6 +3206,15 @@ func APItoRateProfile(tpRp *utils.TPRateProfile, timezone string) (rp *RateProfi
 			return nil, err
 		}
 	}
+	if len(tpRp.VolumeTiers) != 0 {
+		rp.VolumeTiers = make([]*VolumeTier, len(tpRp.VolumeTiers))
+		for i, vTier := range tpRp.VolumeTiers {
+			rp.VolumeTiers[i] = &VolumeTier{Factor: utils.NewDecimalFromFloat64(vTier.Factor)}
+			if rp.VolumeTiers[i].VolumeStart, err = utils.ParseDurationWithNanosecs(vTier.VolumeStart); err != nil {
+				return nil, err
+			}
+		}
+	}
 	for key, rate := range tpRp.Rates {
 		rp.Rates[key] = &Rate{
 			ID:              rate.ID,
@@ -3126,6 +3256,7 @@ func RateProfileToAPI(rp *RateProfile) (tpRp *utils.TPRateProfile) {
 		ActivationInterval: new(utils.TPActivationInterval),
 		Weights:            rp.Weights.String(";", "&"),
 		MaxCostStrategy:    rp.MaxCostStrategy,
+		VolumeUsage:        rp.VolumeUsage,
 		Rates:              make(map[string]*utils.TPRate),
 	}
 	if rp.MinCost != nil {
@@ -3138,6 +3269,16 @@ func RateProfileToAPI(rp *RateProfile) (tpRp *utils.TPRateProfile) {
 		maxCostF, _ := rp.MaxCost.Float64()
 		tpRp.MaxCost = maxCostF
 	}
+	if len(rp.VolumeTiers) != 0 {
+		tpRp.VolumeTiers = make([]*utils.TPVolumeTier, len(rp.VolumeTiers))
+		for i, vTier := range rp.VolumeTiers {
+			tpRp.VolumeTiers[i] = &utils.TPVolumeTier{VolumeStart: vTier.VolumeStart.String()}
+			if vTier.Factor != nil {
+				//there should not be an invalid value of converting from Decimal into float64
+				tpRp.VolumeTiers[i].Factor, _ = vTier.Factor.Float64()
+			}
+		}
+	}
 
 	for key, rate := range rp.Rates {
 		tpRp.Rates[key] = &utils.TPRate{
@@ -2268,7 +2268,7 @@ func TestAPItoModelAccountActions(t *testing.T) {
 func TestCSVHeader(t *testing.T) {
 	var tps ResourceMdls
 	eOut := []string{
-		"#Tenant", "ID", "FilterIDs", "ActivationInterval", "UsageTTL", "Limit", "AllocationMessage", "Blocker", "Stored", "Weight", "ThresholdIDs",
+		"#Tenant", "ID", "FilterIDs", "ActivationInterval", "UsageTTL", "Limit", "AllocationMessage", "Blocker", "Stored", "Weight", "ThresholdIDs", "RateWindow",
 	}
 	if rcv := tps.CSVHeader(); !reflect.DeepEqual(eOut, rcv) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eOut), utils.ToJSON(rcv))
@@ -2468,6 +2468,56 @@ func TestAPItoModelTPThreshold5(t *testing.T) {
 	}
 }
 
+func TestAPItoModelTPThresholdActionProfileIDs(t *testing.T) {
+	th := &utils.TPThresholdProfile{
+		TPid:      "TP1",
+		Tenant:    "cgrates.org",
+		ID:        "TH_1",
+		FilterIDs: []string{"FilterID1"},
+		ActivationInterval: &utils.TPActivationInterval{
+			ActivationTime: "2014-07-14T14:35:00Z",
+		},
+		MaxHits:          12,
+		MinHits:          10,
+		MinSleep:         "1s",
+		Weight:           20.0,
+		ActionProfileIDs: []string{"AP_1", "AP_2"},
+		Async:            true,
+	}
+	exp := ThresholdMdls{
+		{
+			Tpid:               "TP1",
+			Tenant:             "cgrates.org",
+			ID:                 "TH_1",
+			ActivationInterval: "2014-07-14T14:35:00Z",
+			MaxHits:            12,
+			MinHits:            10,
+			MinSleep:           "1s",
+			Weight:             20.0,
+			Async:              true,
+			ActionProfileIDs:   "AP_1;AP_2",
+		},
+		{
+			Tpid:      "TP1",
+			Tenant:    "cgrates.org",
+			ID:        "TH_1",
+			FilterIDs: "FilterID1",
+		},
+	}
+	rcv := APItoModelTPThreshold(th)
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v,\nReceived: %+v", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	tpThs := rcv.AsTPThreshold()
+	if len(tpThs) != 1 {
+		t.Fatalf("Expecting 1 threshold, received: %+v", utils.ToJSON(tpThs))
+	}
+	sort.Strings(tpThs[0].ActionProfileIDs)
+	if !reflect.DeepEqual(th.ActionProfileIDs, tpThs[0].ActionProfileIDs) {
+		t.Errorf("Expecting: %+v,\nReceived: %+v", th.ActionProfileIDs, tpThs[0].ActionProfileIDs)
+	}
+}
+
 func TestAPItoTPThreshold(t *testing.T) {
 	tps := &utils.TPThresholdProfile{
 		TPid:               testTPID,
@@ -5056,6 +5106,49 @@ func TestAPItoModelResourceCase2(t *testing.T) {
 	}
 }
 
+func TestAPItoModelResourceRateWindow(t *testing.T) {
+	tpRL := &utils.TPResourceProfile{
+		Tenant:     "cgrates.org",
+		TPid:       testTPID,
+		ID:         "RES_RATE",
+		FilterIDs:  []string{"FLTR_1", "FLTR_2"},
+		UsageTTL:   "1s",
+		Weight:     10,
+		Limit:      "5",
+		RateWindow: "1m0s",
+	}
+	expMdls := ResourceMdls{
+		{
+			Tenant:     "cgrates.org",
+			Tpid:       testTPID,
+			ID:         "RES_RATE",
+			FilterIDs:  "FLTR_1",
+			UsageTTL:   "1s",
+			Weight:     10,
+			Limit:      "5",
+			RateWindow: "1m0s",
+		},
+		{
+			Tenant:    "cgrates.org",
+			Tpid:      testTPID,
+			ID:        "RES_RATE",
+			FilterIDs: "FLTR_2",
+		},
+	}
+	mdls := APItoModelResource(tpRL)
+	if !reflect.DeepEqual(mdls, expMdls) {
+		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expMdls), utils.ToJSON(mdls))
+	}
+	if rcv := mdls.AsTPResources(); len(rcv) != 1 || rcv[0].RateWindow != tpRL.RateWindow {
+		t.Errorf("Expecting RateWindow <%s>, received <%+v>", tpRL.RateWindow, utils.ToJSON(rcv))
+	}
+	if rp, err := APItoResource(tpRL, utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if rp.RateWindow != time.Minute {
+		t.Errorf("Expecting RateWindow <%v>, received <%v>", time.Minute, rp.RateWindow)
+	}
+}
+
 func TestAPItoModelResourceCase3(t *testing.T) {
 	testStruct := &utils.TPResourceProfile{
 		Tenant:    "cgrates.org",
@@ -5226,7 +5319,8 @@ func TestRateProfileMdlsCSVHeader(t *testing.T) {
 		utils.ActivationIntervalString, utils.Weight, utils.ConnectFee, utils.MinCost,
 		utils.MaxCost, utils.MaxCostStrategy, utils.RateID,
 		utils.RateFilterIDs, utils.RateActivationStart, utils.RateWeight, utils.RateBlocker,
-		utils.RateIntervalStart, utils.RateFixedFee, utils.RateRecurrentFee, utils.RateUnit, utils.RateIncrement}
+		utils.RateIntervalStart, utils.RateFixedFee, utils.RateRecurrentFee, utils.RateUnit, utils.RateIncrement,
+		utils.VolumeUsage, utils.VolumeTiers}
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", expected, result)
 	}
@@ -5582,7 +5676,7 @@ func ModelHelpersTestStatMdlsCSVHeader(t *testing.T) {
 	}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.UsageTTL, utils.Limit, utils.AllocationMessage, utils.Blocker, utils.Stored,
-		utils.Weight, utils.ThresholdIDs}
+		utils.Weight, utils.ThresholdIDs, utils.RateWindow}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
@@ -5598,7 +5692,7 @@ func TestThresholdMdlsCSVHeader(t *testing.T) {
 	}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.MaxHits, utils.MinHits, utils.MinSleep,
-		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async}
+		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async, utils.ActionProfileIDs}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
@@ -6535,6 +6629,91 @@ func TestStatMdlsAsTPStatsCase2(t *testing.T) {
 	}
 }
 
+func TestModelHelpersStatsWindow(t *testing.T) {
+	mdls := StatMdls{
+		{
+			Tpid:        "TPS1",
+			Tenant:      "cgrates.org",
+			ID:          "Stat1",
+			MetricIDs:   "*tcc",
+			BucketWidth: "1m",
+			Window:      "1h",
+			WindowType:  utils.MetaTumbling,
+		},
+	}
+	tpSts := mdls.AsTPStats()
+	if len(tpSts) != 1 {
+		t.Fatalf("Expecting one profile, received: %s", utils.ToJSON(tpSts))
+	}
+	if tpSts[0].BucketWidth != "1m" || tpSts[0].Window != "1h" ||
+		tpSts[0].WindowType != utils.MetaTumbling {
+		t.Errorf("Unexpected window: %s", utils.ToJSON(tpSts[0]))
+	}
+	if rcv := APItoModelStats(tpSts[0]); !reflect.DeepEqual(mdls, rcv) {
+		t.Errorf("Expecting: %s,\n Received: %s", utils.ToJSON(mdls), utils.ToJSON(rcv))
+	}
+	sqp, err := APItoStats(tpSts[0], "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqp.BucketWidth != time.Minute || sqp.Window != time.Hour ||
+		sqp.WindowType != utils.MetaTumbling {
+		t.Errorf("Unexpected window: %s", utils.ToJSON(sqp))
+	}
+	tpSts[0].WindowType = "*hopping"
+	if _, err = APItoStats(tpSts[0], ""); err == nil ||
+		err.Error() != "unsupported window type: <*hopping>" {
+		t.Errorf("Expecting <unsupported window type: <*hopping>>, received: <%v>", err)
+	}
+}
+
+func TestModelHelpersRateProfileVolumeTiers(t *testing.T) {
+	mdls := RateProfileMdls{
+		{
+			Tpid:              "TP1",
+			Tenant:            "cgrates.org",
+			ID:                "RP1",
+			RateID:            "RT_ALWAYS",
+			RateIntervalStart: "0s",
+			RateRecurrentFee:  0.2,
+			RateUnit:          "1m",
+			RateIncrement:     "1m",
+			VolumeUsage:       "~*stats.SQ_MONTHLY.*tcd",
+			VolumeTiers:       "0s:1;10h:0.8",
+		},
+	}
+	tpRps := mdls.AsTPRateProfile()
+	if len(tpRps) != 1 {
+		t.Fatalf("Expecting one profile, received: %s", utils.ToJSON(tpRps))
+	}
+	expTiers := []*utils.TPVolumeTier{
+		{VolumeStart: "0s", Factor: 1},
+		{VolumeStart: "10h", Factor: 0.8},
+	}
+	if tpRps[0].VolumeUsage != "~*stats.SQ_MONTHLY.*tcd" ||
+		!reflect.DeepEqual(expTiers, tpRps[0].VolumeTiers) {
+		t.Errorf("Unexpected volume tiers: %s", utils.ToJSON(tpRps[0]))
+	}
+	if rcv := APItoModelTPRateProfile(tpRps[0]); !reflect.DeepEqual(mdls, rcv) {
+		t.Errorf("Expecting: %s,\n Received: %s", utils.ToJSON(mdls), utils.ToJSON(rcv))
+	}
+	rp, err := APItoRateProfile(tpRps[0], "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []*VolumeTier{
+		{VolumeStart: 0, Factor: utils.NewDecimalFromFloat64(1)},
+		{VolumeStart: 10 * time.Hour, Factor: utils.NewDecimalFromFloat64(0.8)},
+	}
+	if rp.VolumeUsage != "~*stats.SQ_MONTHLY.*tcd" || !reflect.DeepEqual(exp, rp.VolumeTiers) {
+		t.Errorf("Unexpected volume tiers: %s", utils.ToJSON(rp))
+	}
+	expTiers[1].VolumeStart = "10h0m0s"
+	if rcv := RateProfileToAPI(rp); !reflect.DeepEqual(expTiers, rcv.VolumeTiers) {
+		t.Errorf("Expecting: %s,\n Received: %s", utils.ToJSON(expTiers), utils.ToJSON(rcv.VolumeTiers))
+	}
+}
+
 func TestStatMdlsCSVHeader(t *testing.T) {
 	testStruct := StatMdls{{
 		PK:                 0,
@@ -6556,7 +6735,8 @@ func TestStatMdlsCSVHeader(t *testing.T) {
 	}}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs,
+		utils.BucketWidth, utils.Window, utils.WindowType}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
@@ -253,6 +253,7 @@ type ResourceMdl struct {
 	Stored             bool    `index:"8" re:""`
 	Weight             float64 `index:"9" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"10" re:""`
+	RateWindow         string  `index:"11" re:""`
 	CreatedAt          time.Time
 }
 
@@ -276,6 +277,9 @@ type StatMdl struct {
 	Blocker            bool    `index:"10" re:""`
 	Weight             float64 `index:"11" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"12" re:""`
+	BucketWidth        string  `index:"13" re:""`
+	Window             string  `index:"14" re:""`
+	WindowType         string  `index:"15" re:""`
 	CreatedAt          time.Time
 }
 
@@ -297,6 +301,7 @@ type ThresholdMdl struct {
 	Weight             float64 `index:"8" re:"\d+\.?\d*"`
 	ActionIDs          string  `index:"9" re:""`
 	Async              bool    `index:"10" re:""`
+	ActionProfileIDs   string  `index:"11" re:""`
 	CreatedAt          time.Time
 }
 
@@ -531,6 +536,8 @@ type RateProfileMdl struct {
 	RateRecurrentFee    float64 `index:"15" re:"\d+\.?\d*"`
 	RateUnit            string  `index:"16" re:""`
 	RateIncrement       string  `index:"17" re:""`
+	VolumeUsage         string  `index:"18" re:""`
+	VolumeTiers         string  `index:"19" re:""`
 
 	CreatedAt time.Time
 }
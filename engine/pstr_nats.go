@@ -0,0 +1,143 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/nats-io/nats.go"
+)
+
+// NewNatsPoster creates a poster for nats
+// the subject can be templated out of the posted event fields, ie: "cgrates.cdrs.;~*req.Account"
+func NewNatsPoster(dialURL string, attempts int, opts map[string]interface{}) (pstr *NatsPoster, err error) {
+	pstr = &NatsPoster{
+		dialURL:  dialURL,
+		attempts: attempts,
+	}
+	err = pstr.parseOpts(opts)
+	return
+}
+
+// NatsPoster is a poster for nats core or JetStream
+type NatsPoster struct {
+	sync.Mutex // protect connection
+	dialURL    string
+	subject    config.RSRParsers
+	dynSubject bool // the subject needs the event to be parsed
+	jetStream  bool
+	attempts   int
+	conn       *nats.Conn
+	js         nats.JetStreamContext
+}
+
+func (pstr *NatsPoster) parseOpts(opts map[string]interface{}) (err error) {
+	subject := utils.DefaultQueueID
+	if val, has := opts[utils.NatsSubject]; has {
+		subject = utils.IfaceAsString(val)
+	}
+	if pstr.subject, err = config.NewRSRParsers(subject, utils.InfieldSep); err != nil {
+		return
+	}
+	pstr.dynSubject = strings.Contains(subject, utils.DynamicDataPrefix)
+	if val, has := opts[utils.NatsJetStream]; has {
+		pstr.jetStream, err = utils.IfaceAsBool(val)
+	}
+	return
+}
+
+// parseSubject returns the subject for the posted content
+func (pstr *NatsPoster) parseSubject(content []byte) (subj string, err error) {
+	dP := utils.MapStorage{}
+	if pstr.dynSubject {
+		var ev map[string]interface{}
+		if err = json.Unmarshal(content, &ev); err != nil {
+			return
+		}
+		dP[utils.MetaReq] = utils.MapStorage(ev)
+	}
+	return pstr.subject.ParseDataProvider(dP)
+}
+
+// Post is the method being called when we need to post anything in the queue
+// for JetStream the message is acknowledged by the server before returning
+func (pstr *NatsPoster) Post(content []byte, _ string) (err error) {
+	var subj string
+	if subj, err = pstr.parseSubject(content); err != nil {
+		return
+	}
+	fib := utils.Fib()
+	for i := 0; i < pstr.attempts; i++ {
+		if err = pstr.publish(subj, content); err == nil {
+			return
+		}
+		if i+1 < pstr.attempts {
+			time.Sleep(time.Duration(fib()) * time.Second)
+		}
+	}
+	utils.Logger.Warning(fmt.Sprintf("<NatsPoster> publishing on subject <%s>, err: %s", subj, err.Error()))
+	return
+}
+
+func (pstr *NatsPoster) publish(subj string, content []byte) (err error) {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.conn == nil {
+		if err = pstr.connect(); err != nil {
+			return
+		}
+	}
+	if pstr.jetStream {
+		_, err = pstr.js.Publish(subj, content)
+		return
+	}
+	return pstr.conn.Publish(subj, content)
+}
+
+func (pstr *NatsPoster) connect() (err error) {
+	var conn *nats.Conn
+	if conn, err = nats.Connect(pstr.dialURL); err != nil {
+		return
+	}
+	if pstr.jetStream {
+		if pstr.js, err = conn.JetStream(); err != nil {
+			conn.Close()
+			return
+		}
+	}
+	pstr.conn = conn
+	return
+}
+
+// Close closes the nats connection
+func (pstr *NatsPoster) Close() {
+	pstr.Lock()
+	if pstr.conn != nil {
+		pstr.conn.Close()
+	}
+	pstr.conn = nil
+	pstr.js = nil
+	pstr.Unlock()
+}
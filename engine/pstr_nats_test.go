@@ -0,0 +1,68 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNatsPosterParseSubject(t *testing.T) {
+	pstr, err := NewNatsPoster("nats://localhost:4222", 1, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pstr.jetStream || pstr.dynSubject {
+		t.Errorf("Unexpected poster: %+v", pstr)
+	}
+	if subj, err := pstr.parseSubject([]byte(`not json`)); err != nil {
+		t.Error(err)
+	} else if subj != utils.DefaultQueueID {
+		t.Errorf("Expected: %s ,received: %s", utils.DefaultQueueID, subj)
+	}
+
+	if pstr, err = NewNatsPoster("nats://localhost:4222", 1, map[string]interface{}{
+		utils.NatsSubject:   "cgrates.cdrs.;~*req.Tenant;.;~*req.Account",
+		utils.NatsJetStream: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !pstr.jetStream || !pstr.dynSubject {
+		t.Errorf("Unexpected poster: %+v", pstr)
+	}
+	exp := "cgrates.cdrs.cgrates.org.1001"
+	if subj, err := pstr.parseSubject([]byte(`{"Tenant":"cgrates.org","Account":"1001"}`)); err != nil {
+		t.Error(err)
+	} else if subj != exp {
+		t.Errorf("Expected: %s ,received: %s", exp, subj)
+	}
+	if _, err := pstr.parseSubject([]byte(`not json`)); err == nil {
+		t.Error("Expected error for invalid content")
+	}
+	if _, err := pstr.parseSubject([]byte(`{"Tenant":"cgrates.org"}`)); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrNotFound, err)
+	}
+
+	if _, err = NewNatsPoster("nats://localhost:4222", 1, map[string]interface{}{
+		utils.NatsJetStream: "notBool",
+	}); err == nil {
+		t.Error("Expected error for invalid natsJetStream option")
+	}
+}
@@ -39,6 +39,8 @@ type RateProfile struct {
 	MinCost            *utils.Decimal
 	MaxCost            *utils.Decimal
 	MaxCostStrategy    string
+	VolumeUsage        string        // dynamic path to the usage consumed within the billing period, used for volume tiers
+	VolumeTiers        []*VolumeTier // ordered by VolumeStart, the first one starting from 0
 	Rates              map[string]*Rate
 }
 
@@ -82,6 +84,12 @@ type IntervalRate struct {
 	Increment     *utils.Decimal // RateIncrement
 }
 
+// VolumeTier changes the price of the usage once the volume consumed within the billing period reaches VolumeStart
+type VolumeTier struct {
+	VolumeStart time.Duration
+	Factor      *utils.Decimal // multiplies the RecurrentFee of the IntervalRates
+}
+
 func (rt *Rate) Compile() (err error) {
 	aTime := rt.ActivationTimes
 	if aTime == utils.EmptyString {
@@ -136,6 +144,8 @@ type RateSIncrement struct {
 	UsageStart        time.Duration
 	Rate              *Rate
 	IntervalRateIndex int
+	VolumeTierIndex   int            // the volume tier of the increment, relevant only with VolumeFactor
+	VolumeFactor      *utils.Decimal // the factor of the volume tier, nil without volume tiers
 	CompressFactor    int64
 	Usage             time.Duration
 
@@ -149,10 +159,28 @@ type RateProfileCost struct {
 	MinCost         float64
 	MaxCost         float64
 	MaxCostStrategy string
+	VolumeUsage     time.Duration // usage consumed within the billing period before the event
 	RateSIntervals  []*RateSInterval
 	Altered         []string
 }
 
+// RateProfileCosts is the cost returned by RateS for a batch of events
+type RateProfileCosts struct {
+	Cost   float64            // total cost of the rated events
+	Usage  time.Duration      // total usage of the rated events
+	Costs  []*RateProfileCost // in the order of the events, nil for the ones not rated
+	Errors map[int]string     // rating errors indexed on the event position
+}
+
+// RateProfileTotal is the total cost of an event set rated with one RateProfile
+type RateProfileTotal struct {
+	RateProfileID string
+	Cost          float64
+	Usage         time.Duration
+	Events        int            // number of rated events
+	Errors        map[int]string // rating errors indexed on the event position
+}
+
 // CorrectCost should be called in final phase of cost calculation
 // in order to apply further correction like Min/MaxCost or rounding
 func (rPc *RateProfileCost) CorrectCost(rndDec *int, rndMtd string) {
@@ -171,12 +199,16 @@ func (rPc *RateProfileCost) CorrectCost(rndDec *int, rndMtd string) {
 }
 
 // Sort will sort the IntervalRates from each Rate based on IntervalStart
+// and the VolumeTiers based on VolumeStart
 func (rpp *RateProfile) Sort() {
 	for _, rate := range rpp.Rates {
 		sort.Slice(rate.IntervalRates, func(i, j int) bool {
 			return rate.IntervalRates[i].IntervalStart < rate.IntervalRates[j].IntervalStart
 		})
 	}
+	sort.Slice(rpp.VolumeTiers, func(i, j int) bool {
+		return rpp.VolumeTiers[i].VolumeStart < rpp.VolumeTiers[j].VolumeStart
+	})
 }
 
 // CompressEquals compares two RateSIntervals for Compress function
@@ -210,6 +242,9 @@ func (rIcr *RateSIncrement) CompressEquals(rIcr2 *RateSIncrement) (eq bool) {
 	if rIcr.IntervalRateIndex != rIcr2.IntervalRateIndex {
 		return
 	}
+	if rIcr.VolumeTierIndex != rIcr2.VolumeTierIndex {
+		return
+	}
 	if rIcr.Usage != rIcr2.Usage {
 		return
 	}
@@ -229,6 +264,9 @@ func (rIcr *RateSIncrement) Cost() *decimal.Big {
 					utils.MultiplyBig(rIcr.cost, icrRt.Increment.Big),
 					icrRt.Unit.Big)
 			}
+			if rIcr.VolumeFactor != nil {
+				rIcr.cost = utils.MultiplyBig(rIcr.cost, rIcr.VolumeFactor.Big)
+			}
 			if rIcr.CompressFactor != 1 {
 				rIcr.cost = utils.MultiplyBig(
 					rIcr.cost,
@@ -259,6 +297,7 @@ func (ext *APIRateProfile) AsRateProfile() (rp *RateProfile, err error) {
 		FilterIDs:          ext.FilterIDs,
 		ActivationInterval: ext.ActivationInterval,
 		MaxCostStrategy:    ext.MaxCostStrategy,
+		VolumeUsage:        ext.VolumeUsage,
 	}
 	if ext.Weights != utils.EmptyString {
 		if rp.Weights, err = utils.NewDynamicWeightsFromString(ext.Weights, ";", "&"); err != nil {
@@ -271,6 +310,14 @@ func (ext *APIRateProfile) AsRateProfile() (rp *RateProfile, err error) {
 	if ext.MaxCost != nil {
 		rp.MaxCost = utils.NewDecimalFromFloat64(*ext.MaxCost)
 	}
+	if len(ext.VolumeTiers) != 0 {
+		rp.VolumeTiers = make([]*VolumeTier, len(ext.VolumeTiers))
+		for i, extTier := range ext.VolumeTiers {
+			if rp.VolumeTiers[i], err = extTier.AsVolumeTier(); err != nil {
+				return
+			}
+		}
+	}
 	if len(ext.Rates) != 0 {
 		rp.Rates = make(map[string]*Rate)
 		for key, extRate := range ext.Rates {
@@ -294,6 +341,8 @@ type APIRateProfile struct {
 	MinCost            *float64
 	MaxCost            *float64
 	MaxCostStrategy    string
+	VolumeUsage        string
+	VolumeTiers        []*APIVolumeTier
 	Rates              map[string]*APIRate
 }
 
@@ -361,3 +410,19 @@ type APIIntervalRate struct {
 	Unit          *float64 // RateUnit
 	Increment     *float64 // RateIncrement
 }
+
+func (ext *APIVolumeTier) AsVolumeTier() (vTier *VolumeTier, err error) {
+	vTier = new(VolumeTier)
+	if vTier.VolumeStart, err = utils.ParseDurationWithNanosecs(ext.VolumeStart); err != nil {
+		return
+	}
+	if ext.Factor != nil {
+		vTier.Factor = utils.NewDecimalFromFloat64(*ext.Factor)
+	}
+	return
+}
+
+type APIVolumeTier struct {
+	VolumeStart string
+	Factor      *float64
+}
@@ -46,8 +46,9 @@ type ResourceProfile struct {
 	AllocationMessage  string                    // message returned by the winning resource on allocation
 	Blocker            bool                      // blocker flag to stop processing on filters matched
 	Stored             bool
-	Weight             float64  // Weight to sort the resources
-	ThresholdIDs       []string // Thresholds to check after changing Limit
+	Weight             float64       // Weight to sort the resources
+	ThresholdIDs       []string      // Thresholds to check after changing Limit
+	RateWindow         time.Duration // rolling window limiting the units allocated, 0 for concurrent usages
 }
 
 // ResourceProfileWithOpts is used in replicatorV1 for dispatcher
@@ -61,6 +62,11 @@ func (rp *ResourceProfile) TenantID() string {
 	return utils.ConcatenatedKey(rp.Tenant, rp.ID)
 }
 
+// isRateLimit returns true if the profile limits the units allocated within the RateWindow
+func (rp *ResourceProfile) isRateLimit() bool {
+	return rp != nil && rp.RateWindow > 0
+}
+
 // ResourceUsage represents an usage counted
 type ResourceUsage struct {
 	Tenant     string
@@ -169,9 +175,15 @@ func (r *ResourceWithConfig) Available() float64 {
 // recordUsage records a new usage
 func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
 	if _, hasID := r.Usages[ru.ID]; hasID {
+		if r.rPrf.isRateLimit() { // the units were already counted in this window
+			return
+		}
 		return fmt.Errorf("duplicate resource usage with id: %s", ru.TenantID())
 	}
-	if r.ttl != nil && *r.ttl != -1 {
+	if r.rPrf.isRateLimit() { // the usage expires with the window, not with the ttl
+		ru = ru.Clone()
+		ru.ExpiryTime = time.Now().Add(r.rPrf.RateWindow)
+	} else if r.ttl != nil && *r.ttl != -1 {
 		if *r.ttl == 0 {
 			return // no recording for ttl of 0
 		}
@@ -209,6 +221,25 @@ func (r *Resource) clearUsage(ruID string) (err error) {
 	return
 }
 
+// retryAfter returns the time after which enough units expire out of the window for the new usage
+// returns false if the usage can not fit the limit
+func (r *Resource) retryAfter(units float64) (rA time.Duration, can bool) {
+	available := r.rPrf.Limit - r.totalUsage()
+	for _, ruID := range r.TTLIdx {
+		ru, has := r.Usages[ruID]
+		if !has {
+			continue
+		}
+		if available += ru.Units; available >= units {
+			if rA = time.Until(ru.ExpiryTime); rA < 0 {
+				rA = 0
+			}
+			return rA, true
+		}
+	}
+	return
+}
+
 // Resources is an orderable list of Resources based on Weight
 type Resources []*Resource
 
@@ -238,8 +269,12 @@ func (rs Resources) recordUsage(ru *ResourceUsage) (err error) {
 }
 
 // clearUsage gives back the units to the pool
+// the units of the rate limited resources are given back only when the window expires
 func (rs Resources) clearUsage(ruTntID string) (err error) {
 	for _, r := range rs {
+		if r.rPrf.isRateLimit() {
+			continue
+		}
 		if errClear := r.clearUsage(ruTntID); errClear != nil &&
 			r.ttl != nil && *r.ttl != 0 { // we only consider not found error in case of ttl different than 0
 			utils.Logger.Warning(fmt.Sprintf("<%s>, clear ruID: %s, err: %s", utils.ResourceS, ruTntID, errClear.Error()))
@@ -277,25 +312,43 @@ func (rs Resources) IDs() []string {
 // allocateResource attempts allocating resources for a *ResourceUsage
 // simulates on dryRun
 // returns utils.ErrResourceUnavailable if allocation is not possible
+// the rate limited resources need to allow the usage, otherwise the time to retry is returned
 func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage string, err error) {
 	if len(rs) == 0 {
 		return "", utils.ErrResourceUnavailable
 	}
 	lockIDs := utils.PrefixSliceItems(rs.tenatIDs(), utils.ResourcesPrefix)
 	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		var rateLimited *utils.ErrRetryAfter
 		// Simulate resource usage
 		for _, r := range rs {
 			r.removeExpiredUnits()
-			if _, hasID := r.Usages[ru.ID]; hasID && !dryRun { // update
-				if err = r.clearUsage(ru.ID); err != nil {
-					return
-				}
-			}
 			if r.rPrf == nil {
 				err = fmt.Errorf("empty configuration for resourceID: %s", r.TenantID())
 				return
 			}
-			if r.rPrf.Limit >= r.totalUsage()+ru.Units || r.rPrf.Limit == -1 {
+			_, hasID := r.Usages[ru.ID]
+			if r.rPrf.isRateLimit() {
+				if !hasID && // the usage was already counted in this window
+					r.rPrf.Limit != -1 &&
+					r.rPrf.Limit < r.totalUsage()+ru.Units {
+					rA, can := r.retryAfter(ru.Units)
+					if !can {
+						err = utils.ErrResourceUnavailable
+						return
+					}
+					if rateLimited == nil || rA > rateLimited.RetryAfter {
+						rateLimited = &utils.ErrRetryAfter{Err: utils.ErrResourceUnavailable, RetryAfter: rA}
+					}
+					continue
+				}
+			} else if hasID && !dryRun { // update
+				if err = r.clearUsage(ru.ID); err != nil {
+					return
+				}
+			}
+			if r.rPrf.Limit >= r.totalUsage()+ru.Units || r.rPrf.Limit == -1 ||
+				(hasID && r.rPrf.isRateLimit()) {
 				if alcMessage == "" {
 					if r.rPrf.AllocationMessage != "" {
 						alcMessage = r.rPrf.AllocationMessage
@@ -305,6 +358,10 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 				}
 			}
 		}
+		if rateLimited != nil {
+			err = rateLimited
+			return
+		}
 		if alcMessage == "" {
 			err = utils.ErrResourceUnavailable
 			return
@@ -649,7 +706,9 @@ func (rS *ResourceService) V1AuthorizeResources(args utils.ArgRSv1ResourceUsage,
 			Tenant: tnt,
 			ID:     args.UsageID,
 			Units:  args.Units}, true); err != nil {
-		if err == utils.ErrResourceUnavailable {
+		if rlErr, isRateLimited := utils.ParseErrRetryAfter(err); isRateLimited {
+			err = utils.NewErrRetryAfter(utils.ErrResourceUnauthorized, rlErr.RetryAfter)
+		} else if err == utils.ErrResourceUnavailable {
 			err = utils.ErrResourceUnauthorized
 		}
 		return
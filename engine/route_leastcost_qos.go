@@ -0,0 +1,147 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// newRouteQOSGuard parses the limits of a metric used by *lc_qos strategy
+// empty limits are considered unbounded
+func newRouteQOSGuard(metricID, minVal, maxVal string) (guard *routeQOSGuard, err error) {
+	guard = &routeQOSGuard{MetricID: metricID}
+	if minVal != utils.EmptyString {
+		var val float64
+		if val, err = strconv.ParseFloat(minVal, 64); err != nil {
+			return
+		}
+		guard.MinValue = &val
+	}
+	if maxVal != utils.EmptyString {
+		var val float64
+		if val, err = strconv.ParseFloat(maxVal, 64); err != nil {
+			return
+		}
+		guard.MaxValue = &val
+	}
+	return
+}
+
+// routeQOSGuard defines the accepted values of a StatS metric for a route
+type routeQOSGuard struct {
+	MetricID string
+	MinValue *float64
+	MaxValue *float64
+}
+
+// check returns the reason why the route is excluded or empty string if the metric is within limits
+// the routes without a value for the metric are not excluded
+func (guard *routeQOSGuard) check(sortingData map[string]interface{}) string {
+	iVal, has := sortingData[guard.MetricID]
+	if !has {
+		return utils.EmptyString
+	}
+	val, err := utils.IfaceAsFloat64(iVal)
+	if err != nil || val == utils.StatsNA {
+		return utils.EmptyString
+	}
+	if guard.MinValue != nil && val < *guard.MinValue {
+		return fmt.Sprintf("metric %s with value %v lower than %v", guard.MetricID, val, *guard.MinValue)
+	}
+	if guard.MaxValue != nil && val > *guard.MaxValue {
+		return fmt.Sprintf("metric %s with value %v higher than %v", guard.MetricID, val, *guard.MaxValue)
+	}
+	return utils.EmptyString
+}
+
+// NewLeastCostQOSSorter .
+func NewLeastCostQOSSorter(rS *RouteService) *LeastCostQOSSorter {
+	return &LeastCostQOSSorter{rS: rS,
+		sorting: utils.MetaLCQOS}
+}
+
+// LeastCostQOSSorter drops the routes with the StatS metrics outside the limits
+// and sorts the remaining ones based on their cost
+// if all the routes are dropped they are sorted based on their ratio and load
+// and no longer reported as excluded
+type LeastCostQOSSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+// SortRoutes .
+func (lcq *LeastCostQOSSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting: lcq.sorting,
+		Routes:  make([]*SortedRoute, 0)}
+	var excluded []*SortedRoute
+	for _, route := range routes {
+		if len(route.RatingPlanIDs) == 0 && len(route.AccountIDs) == 0 {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> supplier: <%s> - empty RatingPlanIDs or AccountIDs",
+					utils.RouteS, route.ID))
+			return nil, utils.NewErrMandatoryIeMissing("RatingPlanIDs or AccountIDs")
+		}
+		srtSpl, pass, err := lcq.rS.populateSortingData(ev, route, extraOpts)
+		if err != nil {
+			return nil, err
+		} else if !pass || srtSpl == nil {
+			continue
+		}
+		var reason string
+		guards, _ := route.cacheRoute[utils.MetaQOS].([]*routeQOSGuard)
+		for _, guard := range guards {
+			if reason = guard.check(srtSpl.SortingData); reason != utils.EmptyString {
+				break
+			}
+		}
+		if reason == utils.EmptyString {
+			sortedRoutes.Routes = append(sortedRoutes.Routes, srtSpl)
+			continue
+		}
+		if sortedRoutes.ExcludedRoutes == nil {
+			sortedRoutes.ExcludedRoutes = make(map[string]string)
+		}
+		sortedRoutes.ExcludedRoutes[route.ID] = reason
+		if srtSpl.SortingData[utils.Ratio], err = utils.IfaceAsFloat64(route.cacheRoute[utils.MetaRatio]); err != nil {
+			return nil, err
+		}
+		var load float64
+		if len(route.StatIDs) != 0 {
+			if load, err = lcq.rS.statMetricsForLoadDistribution(route.StatIDs, ev.Tenant); err != nil {
+				return nil, err
+			}
+		}
+		srtSpl.SortingData[utils.Load] = load
+		excluded = append(excluded, srtSpl)
+	}
+	if len(sortedRoutes.Routes) == 0 && len(excluded) != 0 {
+		// no route passed the QoS guard, fallback on the load distribution
+		sortedRoutes.Routes = excluded
+		sortedRoutes.ExcludedRoutes = nil
+		sortedRoutes.SortLoadDistribution()
+		return
+	}
+	sortedRoutes.SortLeastCost()
+	return
+}
@@ -66,7 +66,8 @@ type RouteProfileWithOpts struct {
 }
 
 func (rp *RouteProfile) compileCacheParameters() error {
-	if rp.Sorting == utils.MetaLoad {
+	switch rp.Sorting {
+	case utils.MetaLoad:
 		// construct the map for ratio
 		ratioMap := make(map[string]int)
 		// []string{"routeID:Ratio"}
@@ -78,23 +79,67 @@ func (rp *RouteProfile) compileCacheParameters() error {
 			}
 			ratioMap[splitted[0]] = ratioVal
 		}
-		// add the ratio for each route
+		rp.compileRatios(ratioMap)
+	case utils.MetaLCQOS:
+		// []string{"routeID:Ratio", "routeID:MetricID:MinValue:MaxValue"}
+		ratioMap := make(map[string]int)
+		guardsMap := make(map[string][]*routeQOSGuard)
+		for _, param := range rp.SortingParameters {
+			splitted := strings.Split(param, utils.ConcatenatedKeySep)
+			switch len(splitted) {
+			case 2:
+				ratioVal, err := strconv.Atoi(splitted[1])
+				if err != nil {
+					return err
+				}
+				ratioMap[splitted[0]] = ratioVal
+			case 4:
+				guard, err := newRouteQOSGuard(splitted[1], splitted[2], splitted[3])
+				if err != nil {
+					return err
+				}
+				guardsMap[splitted[0]] = append(guardsMap[splitted[0]], guard)
+			default:
+				return fmt.Errorf("invalid sorting parameter <%s> for %s strategy", param, utils.MetaLCQOS)
+			}
+		}
+		rp.compileRatios(ratioMap)
 		for _, route := range rp.Routes {
-			route.cacheRoute = make(map[string]interface{})
-			if ratioRoute, has := ratioMap[route.ID]; !has { // in case that ratio isn't defined for specific routes check for default
-				if ratioDefault, has := ratioMap[utils.MetaDefault]; !has { // in case that *default ratio isn't defined take it from config
-					route.cacheRoute[utils.MetaRatio] = config.CgrConfig().RouteSCfg().DefaultRatio
-				} else {
-					route.cacheRoute[utils.MetaRatio] = ratioDefault
+			guards := guardsMap[route.ID]
+			for _, dfltGuard := range guardsMap[utils.MetaDefault] {
+				var overwritten bool // the route specific guard has priority over the *default one
+				for _, guard := range guardsMap[route.ID] {
+					if guard.MetricID == dfltGuard.MetricID {
+						overwritten = true
+						break
+					}
+				}
+				if !overwritten {
+					guards = append(guards, dfltGuard)
 				}
-			} else {
-				route.cacheRoute[utils.MetaRatio] = ratioRoute
 			}
+			route.cacheRoute[utils.MetaQOS] = guards
 		}
 	}
 	return nil
 }
 
+// compileRatios adds the ratio for each route
+func (rp *RouteProfile) compileRatios(ratioMap map[string]int) {
+	for _, route := range rp.Routes {
+		route.cacheRoute = make(map[string]interface{})
+		if ratioRoute, has := ratioMap[route.ID]; !has { // in case that ratio isn't defined for specific routes check for default
+			if ratioDefault, has := ratioMap[utils.MetaDefault]; !has { // in case that *default ratio isn't defined take it from config
+				route.cacheRoute[utils.MetaRatio] = config.CgrConfig().RouteSCfg().DefaultRatio
+			} else {
+				route.cacheRoute[utils.MetaRatio] = ratioDefault
+			}
+		} else {
+			route.cacheRoute[utils.MetaRatio] = ratioRoute
+		}
+	}
+}
+
 // Compile is a wrapper for convenience setting up the RouteProfile
 func (rp *RouteProfile) Compile() error {
 	return rp.compileCacheParameters()
@@ -442,7 +487,11 @@ func (rpS *RouteService) populateSortingData(ev *utils.CGREvent, route *Route,
 			//check if the route have the metric from sortingParameters
 			//in case that the metric don't exist
 			//we use 10000000 for *pdd and -1 for others
+			//*lc_qos has the guards and ratios as sortingParameters
 			for _, metric := range extraOpts.sortingParameters {
+				if extraOpts.sortingStragety == utils.MetaLCQOS {
+					break
+				}
 				if _, hasMetric := metricSupp[metric]; !hasMetric {
 					switch metric {
 					default:
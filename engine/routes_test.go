@@ -25,6 +25,7 @@ import (
 	"github.com/cgrates/cgrates/config"
 
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 func TestRoutesSort(t *testing.T) {
@@ -2328,3 +2329,126 @@ func TestRoutesSortedForEventWithLimitAndOffset2(t *testing.T) {
 		t.Errorf("Expecting: %+v,received: %+v", utils.ToJSON(eFirstRouteProfile), utils.ToJSON(sprf))
 	}
 }
+
+type routesLCQOSMock struct {
+	costs   map[string]float64            // map[ratingPlanID]cost
+	metrics map[string]map[string]float64 // map[statID]metrics
+}
+
+func (rM *routesLCQOSMock) Call(serviceMethod string, args, rply interface{}) error {
+	switch serviceMethod {
+	case utils.ResponderGetCostOnRatingPlans:
+		rpID := args.(*utils.GetCostOnRatingPlansArgs).RatingPlanIDs[0]
+		*rply.(*map[string]interface{}) = map[string]interface{}{
+			utils.Cost:         rM.costs[rpID],
+			utils.RatingPlanID: rpID,
+		}
+	case utils.StatSv1GetQueueFloatMetrics:
+		*rply.(*map[string]float64) = rM.metrics[args.(*utils.TenantIDWithOpts).ID]
+	default:
+		return utils.ErrNotImplemented
+	}
+	return nil
+}
+
+func TestRoutesLeastCostQOSSorter(t *testing.T) {
+	// the internal connections are cached, make sure the mock is used
+	Cache.Clear([]string{utils.CacheRPCConnections})
+	defer Cache.Clear([]string{utils.CacheRPCConnections})
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().RALsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs)}
+	cfg.RouteSCfg().StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)}
+	mock := &routesLCQOSMock{
+		costs: map[string]float64{"RP_1": 0.1, "RP_2": 0.2, "RP_3": 0.3},
+		metrics: map[string]map[string]float64{
+			"STATS_1": {utils.MetaASR: 30, utils.MetaPDD: 1},
+			"STATS_2": {utils.MetaASR: 60, utils.MetaPDD: 5},
+			"STATS_3": {utils.MetaASR: 70, utils.MetaPDD: 2},
+		},
+	}
+	internalChan := make(chan rpcclient.ClientConnector, 1)
+	internalChan <- mock
+	rpS := NewRouteService(nil, nil, cfg, NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs):  internalChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats): internalChan,
+	}))
+	rPrf := &RouteProfile{
+		Tenant:  "cgrates.org",
+		ID:      "ROUTE_LCQOS",
+		Sorting: utils.MetaLCQOS,
+		SortingParameters: []string{
+			"*default:*asr:40:",
+			"route2:*pdd::3",
+			"route2:2",
+		},
+		Routes: []*Route{
+			{ID: "route1", RatingPlanIDs: []string{"RP_1"}, StatIDs: []string{"STATS_1"}, Weight: 10},
+			{ID: "route2", RatingPlanIDs: []string{"RP_2"}, StatIDs: []string{"STATS_2"}, Weight: 10},
+			{ID: "route3", RatingPlanIDs: []string{"RP_3"}, StatIDs: []string{"STATS_3"}, Weight: 10},
+		},
+	}
+	if err := rPrf.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	routes := make(map[string]*Route)
+	for _, route := range rPrf.Routes {
+		routes[route.ID] = route
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "LCQOS",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Destination:  "1002",
+			utils.SetupTime:    time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC),
+			utils.Usage:        time.Minute,
+		},
+	}
+	extraOpts := &optsGetRoutes{
+		sortingParameters: rPrf.SortingParameters,
+		sortingStragety:   rPrf.Sorting,
+	}
+	sRoutes, err := rpS.sorter.SortRoutes(rPrf.ID, rPrf.Sorting, routes, ev, extraOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rIDs := sRoutes.RouteIDs(); !reflect.DeepEqual([]string{"route3"}, rIDs) {
+		t.Errorf("expecting: %v, received: %v", []string{"route3"}, rIDs)
+	}
+	expExcluded := map[string]string{
+		"route1": "metric *asr with value 30 lower than 40",
+		"route2": "metric *pdd with value 5 higher than 3",
+	}
+	if !reflect.DeepEqual(expExcluded, sRoutes.ExcludedRoutes) {
+		t.Errorf("expecting: %v, received: %v", expExcluded, sRoutes.ExcludedRoutes)
+	}
+	if _, has := sRoutes.Routes[0].SortingData["*default:*asr:40:"]; has {
+		t.Errorf("unexpected sorting parameter in SortingData: %v", sRoutes.Routes[0].SortingData)
+	}
+
+	// all routes excluded, fallback on load distribution: route3(12/1), route1(31/1), route2(65/2)
+	mock.metrics["STATS_3"][utils.MetaASR] = 10
+	if sRoutes, err = rpS.sorter.SortRoutes(rPrf.ID, rPrf.Sorting, routes, ev, extraOpts); err != nil {
+		t.Fatal(err)
+	}
+	if len(sRoutes.Routes) != 3 || len(sRoutes.ExcludedRoutes) != 0 {
+		t.Errorf("received: %s", utils.ToJSON(sRoutes))
+	} else if rIDs := sRoutes.RouteIDs(); !reflect.DeepEqual([]string{"route3", "route1", "route2"}, rIDs) {
+		t.Errorf("expecting: %v, received: %v", []string{"route3", "route1", "route2"}, rIDs)
+	}
+}
+
+func TestRoutesCompileLCQOSErrors(t *testing.T) {
+	rPrf := &RouteProfile{
+		Sorting:           utils.MetaLCQOS,
+		SortingParameters: []string{"route1:*asr:40"},
+	}
+	if err := rPrf.Compile(); err == nil ||
+		err.Error() != "invalid sorting parameter <route1:*asr:40> for *lc_qos strategy" {
+		t.Errorf("received error: %v", err)
+	}
+	rPrf.SortingParameters = []string{"route1:*asr:abc:"}
+	if err := rPrf.Compile(); err == nil {
+		t.Error("expecting error")
+	}
+}
@@ -20,6 +20,7 @@ package engine
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -44,16 +45,20 @@ type StatWithCompress struct {
 // cfg serves as general purpose container to pass config options to metric
 func NewStatMetric(metricID string, minItems int, filterIDs []string) (sm StatMetric, err error) {
 	metrics := map[string]func(int, string, []string) (StatMetric, error){
-		utils.MetaASR:      NewASR,
-		utils.MetaACD:      NewACD,
-		utils.MetaTCD:      NewTCD,
-		utils.MetaACC:      NewACC,
-		utils.MetaTCC:      NewTCC,
-		utils.MetaPDD:      NewPDD,
-		utils.MetaDDC:      NewDDC,
-		utils.MetaSum:      NewStatSum,
-		utils.MetaAverage:  NewStatAverage,
-		utils.MetaDistinct: NewStatDistinct,
+		utils.MetaASR:       NewASR,
+		utils.MetaACD:       NewACD,
+		utils.MetaTCD:       NewTCD,
+		utils.MetaACC:       NewACC,
+		utils.MetaTCC:       NewTCC,
+		utils.MetaPDD:       NewPDD,
+		utils.MetaDDC:       NewDDC,
+		utils.MetaSum:       NewStatSum,
+		utils.MetaAverage:   NewStatAverage,
+		utils.MetaDistinct:  NewStatDistinct,
+		utils.MetaP50:       newStatPercentile(50),
+		utils.MetaP95:       newStatPercentile(95),
+		utils.MetaP99:       newStatPercentile(99),
+		utils.MetaHistogram: NewStatHistogram,
 	}
 	// split the metricID
 	// in case of *sum we have *sum#~*req.FieldName
@@ -1453,3 +1458,323 @@ func (dst *StatDistinct) GetCompressFactor(events map[string]int) map[string]int
 	}
 	return events
 }
+
+// sketchValue converts the field value into float64, durations being considered in nanoseconds
+func sketchValue(ival interface{}) (val float64, err error) {
+	if val, err = utils.IfaceAsFloat64(ival); err == nil {
+		return
+	}
+	var dur time.Duration
+	if dur, err = utils.IfaceAsDuration(ival); err != nil {
+		return
+	}
+	return float64(dur.Nanoseconds()), nil
+}
+
+// sketchEventValue returns the value of the field out of the event
+func sketchEventValue(fieldName string, ev utils.DataProvider) (val float64, err error) {
+	var ival interface{}
+	if ival, err = utils.DPDynamicInterface(fieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, fieldName)
+		}
+		return
+	}
+	return sketchValue(ival)
+}
+
+// sketchAddEvent adds the value of the field to the sketch and records the bin for the event
+func sketchAddEvent(sk *StatSketch, events map[string]map[int]int64, fieldName, evID string, ev utils.DataProvider) (err error) {
+	var val float64
+	if val, err = sketchEventValue(fieldName, ev); err != nil {
+		return
+	}
+	sketchAddToBin(sk, events, evID, sk.Key(val))
+	return
+}
+
+// sketchAddToBin counts one value in the bin and records the bin for the event
+func sketchAddToBin(sk *StatSketch, events map[string]map[int]int64, evID string, key int) {
+	sk.AddToBin(key, 1)
+	if _, has := events[evID]; !has {
+		events[evID] = make(map[int]int64)
+	}
+	events[evID][key]++
+}
+
+// sketchRemEvent removes one value of the event from the sketch
+// for compressed events the value from the lowest bin is removed
+func sketchRemEvent(sk *StatSketch, events map[string]map[int]int64, evID string) (err error) {
+	bins, has := events[evID]
+	if !has || len(bins) == 0 {
+		delete(events, evID)
+		return utils.ErrNotFound
+	}
+	key := 0
+	first := true
+	for k := range bins {
+		if first || k < key {
+			key = k
+			first = false
+		}
+	}
+	sk.RemFromBin(key, 1)
+	if bins[key] <= 1 {
+		delete(bins, key)
+	} else {
+		bins[key]--
+	}
+	if len(bins) == 0 {
+		delete(events, evID)
+	}
+	return
+}
+
+// sketchCompress merges the bins of all events under defaultID
+func sketchCompress(sk *StatSketch, events map[string]map[int]int64, queueLen int64, defaultID string) (eventIDs []string, compressed map[string]map[int]int64) {
+	if sk.Count < queueLen {
+		for id := range events {
+			eventIDs = append(eventIDs, id)
+		}
+		return eventIDs, events
+	}
+	bins := make(map[int]int64, len(sk.Bins))
+	for key, cnt := range sk.Bins {
+		bins[key] = cnt
+	}
+	return []string{defaultID}, map[string]map[int]int64{defaultID: bins}
+}
+
+// sketchCompressFactor updates the compress factors with the number of values of each event
+func sketchCompressFactor(events map[string]map[int]int64, cfs map[string]int) map[string]int {
+	for id, bins := range events {
+		compressFactor := 0
+		for _, cnt := range bins {
+			compressFactor += int(cnt)
+		}
+		if cf, has := cfs[id]; !has || cf < compressFactor {
+			cfs[id] = compressFactor
+		}
+	}
+	return cfs
+}
+
+// newStatPercentile returns the constructor of the percentile metric
+func newStatPercentile(percentile float64) func(int, string, []string) (StatMetric, error) {
+	return func(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+		return &StatPercentile{Events: make(map[string]map[int]int64),
+			Sketch:     NewStatSketch(sketchRelativeAccuracy),
+			Percentile: percentile,
+			MinItems:   minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
+	}
+}
+
+// StatPercentile implements the *p50, *p95, *p99 metrics, approximating
+// the percentile of a field with the help of a StatSketch
+type StatPercentile struct {
+	FilterIDs  []string
+	Percentile float64
+	Sketch     *StatSketch
+	Events     map[string]map[int]int64 // map[EventTenantID]map[binKey]count
+	MinItems   int
+	FieldName  string
+	val        *float64 // cached percentile value
+}
+
+// getValue returns the percentile value
+func (pct *StatPercentile) getValue(roundingDecimal int) float64 {
+	if pct.val == nil {
+		if pct.Sketch.Count == 0 || pct.Sketch.Count < int64(pct.MinItems) {
+			pct.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			pct.val = utils.Float64Pointer(utils.Round(pct.Sketch.Quantile(pct.Percentile/100),
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *pct.val
+}
+
+func (pct *StatPercentile) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := pct.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (pct *StatPercentile) GetValue(roundingDecimal int) (v interface{}) {
+	return pct.getValue(roundingDecimal)
+}
+
+func (pct *StatPercentile) GetFloat64Value(roundingDecimal int) (v float64) {
+	return pct.getValue(roundingDecimal)
+}
+
+func (pct *StatPercentile) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	if err = sketchAddEvent(pct.Sketch, pct.Events, pct.FieldName, evID, ev); err != nil {
+		return
+	}
+	pct.val = nil
+	return
+}
+
+func (pct *StatPercentile) RemEvent(evID string) (err error) {
+	if err = sketchRemEvent(pct.Sketch, pct.Events, evID); err != nil {
+		return
+	}
+	pct.val = nil
+	return
+}
+
+func (pct *StatPercentile) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(pct)
+}
+
+func (pct *StatPercentile) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, pct)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (pct *StatPercentile) GetFilterIDs() []string {
+	return pct.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (pct *StatPercentile) GetMinItems() (minIts int) { return pct.MinItems }
+
+// Compress is part of StatMetric interface
+func (pct *StatPercentile) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	eventIDs, pct.Events = sketchCompress(pct.Sketch, pct.Events, queueLen, defaultID)
+	return
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (pct *StatPercentile) GetCompressFactor(events map[string]int) map[string]int {
+	return sketchCompressFactor(pct.Events, events)
+}
+
+// histogramInfBound is the histogram key for the values above the last bound
+const histogramInfBound = "+Inf"
+
+// NewStatHistogram builds the *histogram metric
+// the extraParams are in the format: FieldName[;bound1;bound2...]
+func NewStatHistogram(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	params := strings.Split(extraParams, utils.InfieldSep)
+	bounds := make([]float64, len(params)-1)
+	for i, bound := range params[1:] {
+		var err error
+		if bounds[i], err = sketchValue(bound); err != nil {
+			return nil, fmt.Errorf("invalid histogram bound <%s>", bound)
+		}
+	}
+	sort.Float64s(bounds)
+	return &StatHistogram{Events: make(map[string]map[int]int64),
+		Sketch:   NewStatSketch(sketchRelativeAccuracy),
+		Bounds:   bounds,
+		MinItems: minItems, FieldName: params[0], FilterIDs: filterIDs}, nil
+}
+
+// StatHistogram implements the *histogram metric, counting the
+// values of a field into buckets
+// with Bounds the Sketch bins are the buckets indexes, the values being classified when added
+type StatHistogram struct {
+	FilterIDs []string
+	Bounds    []float64 // upper limits(inclusive) of the buckets, empty for the sketch bins
+	Sketch    *StatSketch
+	Events    map[string]map[int]int64 // map[EventTenantID]map[binKey]count
+	MinItems  int
+	FieldName string
+}
+
+// bucket returns the index of the first bound not smaller than val
+// len(Bounds) is used for the values above the last bound
+func (hst *StatHistogram) bucket(val float64) int {
+	return sort.SearchFloat64s(hst.Bounds, val)
+}
+
+// getValue returns the number of values in each bucket
+func (hst *StatHistogram) getValue() map[string]int64 {
+	if hst.Sketch.Count == 0 || hst.Sketch.Count < int64(hst.MinItems) {
+		return nil
+	}
+	if len(hst.Bounds) == 0 {
+		return hst.Sketch.Histogram()
+	}
+	hist := make(map[string]int64)
+	for idx, cnt := range hst.Sketch.Bins {
+		bound := histogramInfBound
+		if idx < len(hst.Bounds) {
+			bound = strconv.FormatFloat(hst.Bounds[idx], 'f', -1, 64)
+		}
+		hist[bound] += cnt
+	}
+	return hist
+}
+
+func (hst *StatHistogram) GetStringValue(roundingDecimal int) (valStr string) {
+	val := hst.getValue()
+	if val == nil {
+		return utils.NotAvailable
+	}
+	return utils.ToJSON(val)
+}
+
+// GetValue returns the buckets as map[bound]count
+func (hst *StatHistogram) GetValue(roundingDecimal int) (v interface{}) {
+	if val := hst.getValue(); val != nil {
+		return val
+	}
+	return utils.StatsNA
+}
+
+// GetFloat64Value returns the number of values in the histogram
+func (hst *StatHistogram) GetFloat64Value(roundingDecimal int) (v float64) {
+	if hst.getValue() == nil {
+		return utils.StatsNA
+	}
+	return float64(hst.Sketch.Count)
+}
+
+func (hst *StatHistogram) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	if len(hst.Bounds) == 0 {
+		return sketchAddEvent(hst.Sketch, hst.Events, hst.FieldName, evID, ev)
+	}
+	var val float64
+	if val, err = sketchEventValue(hst.FieldName, ev); err != nil {
+		return
+	}
+	sketchAddToBin(hst.Sketch, hst.Events, evID, hst.bucket(val))
+	return
+}
+
+func (hst *StatHistogram) RemEvent(evID string) (err error) {
+	return sketchRemEvent(hst.Sketch, hst.Events, evID)
+}
+
+func (hst *StatHistogram) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(hst)
+}
+
+func (hst *StatHistogram) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, hst)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (hst *StatHistogram) GetFilterIDs() []string {
+	return hst.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (hst *StatHistogram) GetMinItems() (minIts int) { return hst.MinItems }
+
+// Compress is part of StatMetric interface
+func (hst *StatHistogram) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	eventIDs, hst.Events = sketchCompress(hst.Sketch, hst.Events, queueLen, defaultID)
+	return
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (hst *StatHistogram) GetCompressFactor(events map[string]int) map[string]int {
+	return sketchCompressFactor(hst.Events, events)
+}
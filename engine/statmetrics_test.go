@@ -18,9 +18,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"math"
 	"net"
 	"reflect"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -4164,3 +4166,157 @@ func TestStatMetricsStatASRAddEventErr3(t *testing.T) {
 		t.Errorf("\nExpecting <%+v>,\n Recevied <%+v>", utils.ErrAccountNotFound, err)
 	}
 }
+
+func TestStatPercentileGetStringValue(t *testing.T) {
+	p95, err := NewStatMetric(utils.MetaP95+utils.HashtagSep+"~*req.PDD", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strVal := p95.GetStringValue(config.CgrConfig().GeneralCfg().RoundingDecimals); strVal != utils.NotAvailable {
+		t.Errorf("wrong p95 value: %s", strVal)
+	}
+	for i := 1; i <= 100; i++ {
+		if err := p95.AddEvent(utils.ConcatenatedKey("EVENT", strconv.Itoa(i)),
+			utils.MapStorage{utils.MetaReq: map[string]interface{}{"PDD": float64(i)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if val := p95.GetFloat64Value(2); val < 93 || val > 97 {
+		t.Errorf("wrong p95 value: %v", val)
+	}
+	for i := 51; i <= 100; i++ {
+		if err := p95.RemEvent(utils.ConcatenatedKey("EVENT", strconv.Itoa(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if val := p95.GetFloat64Value(2); val < 46 || val > 49 {
+		t.Errorf("wrong p95 value: %v", val)
+	}
+	if err := p95.RemEvent("EVENT_100"); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestStatPercentileDuration(t *testing.T) {
+	p50, _ := NewStatMetric(utils.MetaP50+utils.HashtagSep+"~*req.PDD", 0, []string{})
+	for i, pdd := range []string{"1s", "2s", "3s"} {
+		if err := p50.AddEvent(strconv.Itoa(i),
+			utils.MapStorage{utils.MetaReq: map[string]interface{}{"PDD": pdd}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if val := p50.GetFloat64Value(-1); math.Abs(val-float64(2*time.Second))/float64(2*time.Second) > 0.01 {
+		t.Errorf("wrong p50 value: %v", val)
+	}
+	if err := p50.AddEvent("EV", utils.MapStorage{utils.MetaReq: map[string]interface{}{}}); err == nil ||
+		err.Error() != utils.ErrPrefix(utils.ErrNotFound, "~*req.PDD").Error() {
+		t.Errorf("received error: %v", err)
+	}
+}
+
+func TestStatPercentileCompress(t *testing.T) {
+	p99, _ := NewStatMetric(utils.MetaP99+utils.HashtagSep+"~*req.Cost", 0, []string{})
+	for i := 1; i <= 10; i++ {
+		p99.AddEvent(strconv.Itoa(i), utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": float64(i)}})
+	}
+	expIDs := []string{"1", "10", "2", "3", "4", "5", "6", "7", "8", "9"}
+	rcvIDs := p99.Compress(20, "EV", 2)
+	sort.Strings(rcvIDs)
+	if !reflect.DeepEqual(expIDs, rcvIDs) {
+		t.Errorf("expecting: %v, received: %v", expIDs, rcvIDs)
+	}
+	val := p99.GetFloat64Value(2)
+	if rcvIDs = p99.Compress(10, "EV", 2); !reflect.DeepEqual([]string{"EV"}, rcvIDs) {
+		t.Errorf("expecting: %v, received: %v", []string{"EV"}, rcvIDs)
+	}
+	if rcv := p99.GetFloat64Value(2); rcv != val {
+		t.Errorf("expecting: %v, received: %v", val, rcv)
+	}
+	if rcv := p99.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(map[string]int{"EV": 10}, rcv) {
+		t.Errorf("received: %v", rcv)
+	}
+	if err := p99.RemEvent("EV"); err != nil {
+		t.Error(err)
+	}
+	if rcv := p99.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(map[string]int{"EV": 9}, rcv) {
+		t.Errorf("received: %v", rcv)
+	}
+}
+
+func TestStatPercentileMarshal(t *testing.T) {
+	p50, _ := NewStatMetric(utils.MetaP50+utils.HashtagSep+"~*req.Cost", 2, []string{"*string:~*req.Account:1001"})
+	p50.AddEvent("EVENT_1", utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": 10.5}})
+	p50.AddEvent("EVENT_2", utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": 20.5}})
+	for _, ms := range []Marshaler{&jMarshaler, NewCodecMsgpackMarshaler()} {
+		nP50, _ := NewStatMetric(utils.MetaP50+utils.HashtagSep+"~*req.Cost", 0, []string{})
+		if b, err := p50.Marshal(ms); err != nil {
+			t.Error(err)
+		} else if err := nP50.LoadMarshaled(ms, b); err != nil {
+			t.Error(err)
+		} else if nP50.GetStringValue(2) != p50.GetStringValue(2) {
+			t.Errorf("expected: %s, received: %s", p50.GetStringValue(2), nP50.GetStringValue(2))
+		} else if !reflect.DeepEqual(p50.(*StatPercentile).Events, nP50.(*StatPercentile).Events) {
+			t.Errorf("expected: %s, received: %s", utils.ToJSON(p50), utils.ToJSON(nP50))
+		}
+	}
+}
+
+func TestStatHistogramBoundaries(t *testing.T) {
+	hst, err := NewStatMetric(utils.MetaHistogram+utils.HashtagSep+"~*req.Value;100.495;200", 0, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the values are classified on their own, not on the representative value of the sketch bin
+	// 100.5 shares the sketch bin represented by 100.4946 with the bound
+	for i, val := range []float64{100.495, 100.5, 200, 200.5} {
+		if err = hst.AddEvent(strconv.Itoa(i), utils.MapStorage{utils.MetaReq: map[string]interface{}{"Value": val}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exp := map[string]int64{"100.495": 1, "200": 2, histogramInfBound: 1}
+	if rcv := hst.GetValue(2); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expecting: %v, received: %v", exp, rcv)
+	}
+	hst.RemEvent("1")
+	exp = map[string]int64{"100.495": 1, "200": 1, histogramInfBound: 1}
+	if rcv := hst.GetValue(2); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expecting: %v, received: %v", exp, rcv)
+	}
+}
+
+func TestStatHistogram(t *testing.T) {
+	if _, err := NewStatMetric(utils.MetaHistogram+utils.HashtagSep+"~*req.PDD;1s;abc", 0, []string{}); err == nil ||
+		err.Error() != "invalid histogram bound <abc>" {
+		t.Errorf("received error: %v", err)
+	}
+	hst, err := NewStatMetric(utils.MetaHistogram+utils.HashtagSep+"~*req.PDD;2s;500ms", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strVal := hst.GetStringValue(2); strVal != utils.NotAvailable {
+		t.Errorf("wrong histogram value: %s", strVal)
+	}
+	for i, pdd := range []string{"100ms", "300ms", "1s", "5s"} {
+		hst.AddEvent(strconv.Itoa(i), utils.MapStorage{utils.MetaReq: map[string]interface{}{"PDD": pdd}})
+	}
+	exp := map[string]int64{"500000000": 2, "2000000000": 1, histogramInfBound: 1}
+	if rcv := hst.GetValue(2); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expecting: %v, received: %v", exp, rcv)
+	}
+	if rcv := hst.GetFloat64Value(2); rcv != 4 {
+		t.Errorf("expecting: 4, received: %v", rcv)
+	}
+	hst.RemEvent("3")
+	exp = map[string]int64{"500000000": 2, "2000000000": 1}
+	if rcv := hst.GetStringValue(2); rcv != utils.ToJSON(exp) {
+		t.Errorf("expecting: %v, received: %v", utils.ToJSON(exp), rcv)
+	}
+	nHst, _ := NewStatMetric(utils.MetaHistogram+utils.HashtagSep+"~*req.PDD", 0, []string{})
+	if b, err := hst.Marshal(&jMarshaler); err != nil {
+		t.Error(err)
+	} else if err := nHst.LoadMarshaled(&jMarshaler, b); err != nil {
+		t.Error(err)
+	} else if nHst.GetStringValue(2) != hst.GetStringValue(2) {
+		t.Errorf("expected: %s, received: %s", hst.GetStringValue(2), nHst.GetStringValue(2))
+	}
+}
@@ -20,7 +20,9 @@ package engine
 
 import (
 	"fmt"
+	"net/url"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -59,6 +61,7 @@ type StatService struct {
 // Shutdown is called to shutdown the service
 func (sS *StatService) Shutdown() {
 	utils.Logger.Info("<StatS> service shutdown initialized")
+	Metrics.UnregisterCollector(utils.StatS)
 	close(sS.stopBackup)
 	sS.storeStats()
 	utils.Logger.Info("<StatS> service shutdown complete")
@@ -475,6 +478,28 @@ func (sS *StatService) V1GetQueueFloatMetrics(args *utils.TenantID, reply *map[s
 	return
 }
 
+// V1GetQueueBucketMetrics returns the metrics as float64 values for each bucket of a windowed Queue
+func (sS *StatService) V1GetQueueBucketMetrics(args *utils.TenantID, reply *[]*StatBucketMetrics) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	sq, err := sS.getStatQueue(tnt, args.ID)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	sq.RLock()
+	*reply = sq.BucketMetrics(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+	sq.RUnlock()
+	return
+}
+
 // V1GetQueueIDs returns list of queueIDs registered for a tenant
 func (sS *StatService) V1GetQueueIDs(tenant string, qIDs *[]string) (err error) {
 	if tenant == utils.EmptyString {
@@ -503,9 +528,40 @@ func (sS *StatService) Reload() {
 
 // StartLoop starsS the gorutine with the backup loop
 func (sS *StatService) StartLoop() {
+	Metrics.RegisterCollector(utils.StatS, sS.collectMetrics)
 	go sS.runBackup()
 }
 
+// collectMetrics adds the metrics of the StatQueues requested with the stat_ids parameter
+// the IDs can be prefixed with the tenant, ie: cgrates.org:SQ_1
+func (sS *StatService) collectMetrics(pm *utils.PrometheusMetrics, params url.Values) {
+	for _, sqID := range params[utils.PrometheusStatIDs] {
+		tntID := utils.NewTenantID(sqID)
+		if tntID.Tenant == utils.EmptyString {
+			tntID.Tenant = sS.cgrcfg.GeneralCfg().DefaultTenant
+		}
+		var metrics map[string]float64
+		if err := sS.V1GetQueueFloatMetrics(tntID, &metrics); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> exporting metrics of StatQueue <%s>",
+				utils.StatS, err, tntID.TenantID()))
+			continue
+		}
+		metricIDs := make([]string, 0, len(metrics))
+		for metricID := range metrics {
+			metricIDs = append(metricIDs, metricID)
+		}
+		sort.Strings(metricIDs)
+		for _, metricID := range metricIDs {
+			pm.Add("cgrates_stat_metric", utils.PrometheusGauge, "Value of the StatQueue metric.",
+				map[string]string{
+					"tenant": tntID.Tenant,
+					"queue":  tntID.ID,
+					"metric": metricID,
+				}, metrics[metricID])
+		}
+	}
+}
+
 // V1ResetStatQueue resets the stat queue
 func (sS *StatService) V1ResetStatQueue(tntID *utils.TenantID, rply *string) (err error) {
 	var sq *StatQueue
@@ -516,6 +572,7 @@ func (sS *StatService) V1ResetStatQueue(tntID *utils.TenantID, rply *string) (er
 	sq.Lock()
 	defer sq.Unlock()
 	sq.SQItems = make([]SQItem, 0)
+	sq.SQBuckets = nil
 	metrics := sq.SQMetrics
 	sq.SQMetrics = make(map[string]StatMetric)
 	for id, m := range metrics {
@@ -0,0 +1,173 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	// sketchRelativeAccuracy is the maximum relative error of the values returned by a StatSketch
+	sketchRelativeAccuracy = 0.01
+	// sketchMinIndexable is the smallest absolute value not considered zero
+	sketchMinIndexable = 1e-9
+	// sketchKeyOffset keeps the keys of the positive values above 0
+	sketchKeyOffset = 1 << 20
+)
+
+// NewStatSketch returns a StatSketch with the given relative accuracy
+func NewStatSketch(relativeAccuracy float64) *StatSketch {
+	if relativeAccuracy <= 0 || relativeAccuracy >= 1 {
+		relativeAccuracy = sketchRelativeAccuracy
+	}
+	return &StatSketch{
+		RelativeAccuracy: relativeAccuracy,
+		Bins:             make(map[int]int64),
+	}
+}
+
+// StatSketch is a streaming quantile sketch using logarithmic bins
+// (DDSketch alike) so the values can be both added and removed
+// and the stored size depends on the value range, not on the number of values
+type StatSketch struct {
+	RelativeAccuracy float64
+	Bins             map[int]int64 // map[binKey]count
+	Count            int64
+	lnGamma          float64 // cached ln((1+RelativeAccuracy)/(1-RelativeAccuracy))
+}
+
+// logGamma returns the logarithm of the bin growth factor
+func (sk *StatSketch) logGamma() float64 {
+	if sk.lnGamma == 0 {
+		sk.lnGamma = math.Log((1 + sk.RelativeAccuracy) / (1 - sk.RelativeAccuracy))
+	}
+	return sk.lnGamma
+}
+
+// Key returns the bin key of the value
+// 0 is used for zero, positive keys for positive values and negative keys for negative ones
+func (sk *StatSketch) Key(val float64) int {
+	absVal := math.Abs(val)
+	if absVal < sketchMinIndexable {
+		return 0
+	}
+	key := int(math.Ceil(math.Log(absVal)/sk.logGamma())) + sketchKeyOffset
+	if val < 0 {
+		return -key
+	}
+	return key
+}
+
+// Value returns the value represented by the bin key
+func (sk *StatSketch) Value(key int) float64 {
+	if key == 0 {
+		return 0
+	}
+	idx := key
+	if key < 0 {
+		idx = -key
+	}
+	gamma := math.Exp(sk.logGamma())
+	val := 2 * math.Exp(float64(idx-sketchKeyOffset)*sk.logGamma()) / (gamma + 1)
+	if key < 0 {
+		return -val
+	}
+	return val
+}
+
+// UpperBound returns the biggest value that can be found in the bin
+func (sk *StatSketch) UpperBound(key int) float64 {
+	switch {
+	case key == 0:
+		return 0
+	case key < 0:
+		return -math.Exp(float64(-key-sketchKeyOffset-1) * sk.logGamma())
+	}
+	return math.Exp(float64(key-sketchKeyOffset) * sk.logGamma())
+}
+
+// Add adds the value to the sketch returning the bin key used
+func (sk *StatSketch) Add(val float64) (key int) {
+	key = sk.Key(val)
+	sk.AddToBin(key, 1)
+	return
+}
+
+// AddToBin increases the count of the bin
+func (sk *StatSketch) AddToBin(key int, count int64) {
+	sk.Bins[key] += count
+	sk.Count += count
+}
+
+// RemFromBin decreases the count of the bin, removing it when empty
+func (sk *StatSketch) RemFromBin(key int, count int64) {
+	cnt, has := sk.Bins[key]
+	if !has {
+		return
+	}
+	if count > cnt {
+		count = cnt
+	}
+	if cnt == count {
+		delete(sk.Bins, key)
+	} else {
+		sk.Bins[key] = cnt - count
+	}
+	sk.Count -= count
+}
+
+// sortedKeys returns the bin keys sorted by the value they represent
+func (sk *StatSketch) sortedKeys() (keys []int) {
+	keys = make([]int, 0, len(sk.Bins))
+	for key := range sk.Bins {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys) // negative keys are mirrored so the natural order matches the values order
+	return
+}
+
+// Quantile returns the approximated value at quantile q (0 <= q <= 1)
+func (sk *StatSketch) Quantile(q float64) float64 {
+	if sk.Count == 0 || q < 0 || q > 1 {
+		return utils.StatsNA
+	}
+	rank := q * float64(sk.Count-1)
+	var cumulated int64
+	keys := sk.sortedKeys()
+	for _, key := range keys {
+		cumulated += sk.Bins[key]
+		if float64(cumulated) > rank {
+			return sk.Value(key)
+		}
+	}
+	return sk.Value(keys[len(keys)-1])
+}
+
+// Histogram returns the number of values in each populated bin keyed by the bin upper bound
+func (sk *StatSketch) Histogram() (hist map[string]int64) {
+	hist = make(map[string]int64)
+	for key, cnt := range sk.Bins {
+		hist[strconv.FormatFloat(sk.UpperBound(key), 'g', 6, 64)] += cnt
+	}
+	return
+}
@@ -0,0 +1,97 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestStatSketchQuantile(t *testing.T) {
+	sk := NewStatSketch(0.01)
+	if val := sk.Quantile(0.5); val != utils.StatsNA {
+		t.Errorf("expecting: %v, received: %v", utils.StatsNA, val)
+	}
+	for i := 1; i <= 1000; i++ {
+		sk.Add(float64(i))
+	}
+	if sk.Count != 1000 {
+		t.Errorf("expecting: 1000, received: %v", sk.Count)
+	}
+	for q, exp := range map[float64]float64{0.5: 500, 0.95: 950, 0.99: 990, 1: 1000} {
+		if val := sk.Quantile(q); math.Abs(val-exp)/exp > 0.02 {
+			t.Errorf("quantile %v, expecting: %v, received: %v", q, exp, val)
+		}
+	}
+	if len(sk.Bins) >= 1000 {
+		t.Errorf("expecting less bins than values, received: %v", len(sk.Bins))
+	}
+}
+
+func TestStatSketchNegativeAndZero(t *testing.T) {
+	sk := NewStatSketch(0.01)
+	for _, v := range []float64{-10, -1, 0, 1, 10} {
+		sk.Add(v)
+	}
+	if val := sk.Quantile(0); math.Abs(val+10) > 0.2 {
+		t.Errorf("expecting: -10, received: %v", val)
+	}
+	if val := sk.Quantile(0.25); math.Abs(val+1) > 0.02 {
+		t.Errorf("expecting: -1, received: %v", val)
+	}
+	if val := sk.Quantile(0.5); val != 0 {
+		t.Errorf("expecting: 0, received: %v", val)
+	}
+	if val := sk.Quantile(1); math.Abs(val-10) > 0.2 {
+		t.Errorf("expecting: 10, received: %v", val)
+	}
+}
+
+func TestStatSketchRemFromBin(t *testing.T) {
+	sk := NewStatSketch(0.01)
+	key := sk.Add(5)
+	sk.Add(5)
+	sk.RemFromBin(key, 1)
+	if sk.Count != 1 || sk.Bins[key] != 1 {
+		t.Errorf("received: %s", utils.ToJSON(sk))
+	}
+	sk.RemFromBin(key, 2)
+	if sk.Count != 0 || len(sk.Bins) != 0 {
+		t.Errorf("received: %s", utils.ToJSON(sk))
+	}
+	sk.RemFromBin(key, 1)
+	if sk.Count != 0 {
+		t.Errorf("received: %s", utils.ToJSON(sk))
+	}
+}
+
+func TestStatSketchHistogram(t *testing.T) {
+	sk := NewStatSketch(0.01)
+	for _, v := range []float64{1, 2, 3, 7, 15} {
+		sk.Add(v)
+	}
+	var total int64
+	for _, cnt := range sk.Histogram() {
+		total += cnt
+	}
+	if total != 5 {
+		t.Errorf("expecting: 5, received: %v", total)
+	}
+}
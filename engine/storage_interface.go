@@ -138,6 +138,12 @@ type DataDB interface {
 	GetAccountProfileDrv(string, string) (*utils.AccountProfile, error)
 	SetAccountProfileDrv(profile *utils.AccountProfile) error
 	RemoveAccountProfileDrv(string, string) error
+	GetSessionsBackupDrv(nodeID string) ([]*StoredSession, error)
+	SetSessionBackupDrv(nodeID string, storedSession *StoredSession) error
+	RemoveSessionsBackupDrv(nodeID, cgrID string) error
+	GetRerateJobDrv(id string) (*StoredRerateJob, error)
+	SetRerateJobDrv(*StoredRerateJob) error
+	RemoveRerateJobDrv(string) error
 }
 
 type StorDB interface {
@@ -955,3 +955,54 @@ func (iDB *InternalDB) RemoveAccountProfileDrv(tenant, id string) (err error) {
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
+
+func (iDB *InternalDB) GetSessionsBackupDrv(nodeID string) (storedSessions []*StoredSession, err error) {
+	for _, id := range Cache.GetItemIDs(utils.CacheSessionsBackup, nodeID+utils.ConcatenatedKeySep) {
+		if x, ok := Cache.Get(utils.CacheSessionsBackup, id); ok && x != nil {
+			storedSessions = append(storedSessions, x.(*StoredSession))
+		}
+	}
+	if len(storedSessions) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+func (iDB *InternalDB) SetSessionBackupDrv(nodeID string, storedSession *StoredSession) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheSessionsBackup, utils.ConcatenatedKey(nodeID, storedSession.CGRID),
+		storedSession, nil, cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveSessionsBackupDrv(nodeID, cgrID string) (err error) {
+	if cgrID != utils.EmptyString {
+		Cache.RemoveWithoutReplicate(utils.CacheSessionsBackup, utils.ConcatenatedKey(nodeID, cgrID),
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		return
+	}
+	for _, id := range Cache.GetItemIDs(utils.CacheSessionsBackup, nodeID+utils.ConcatenatedKeySep) {
+		Cache.RemoveWithoutReplicate(utils.CacheSessionsBackup, id,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	return
+}
+
+func (iDB *InternalDB) GetRerateJobDrv(tntID string) (job *StoredRerateJob, err error) {
+	x, ok := Cache.Get(utils.CacheRerateJobs, tntID)
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*StoredRerateJob), nil
+}
+
+func (iDB *InternalDB) SetRerateJobDrv(job *StoredRerateJob) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheRerateJobs, job.TenantID(), job, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveRerateJobDrv(tntID string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheRerateJobs, tntID,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
@@ -80,6 +80,8 @@ const (
 	ColApp  = "action_profiles"
 	ColLID  = "load_ids"
 	ColAnp  = "account_profiles"
+	ColSbk  = "sessions_backup"
+	ColRrj  = "rerate_jobs"
 )
 
 var (
@@ -2246,3 +2248,96 @@ func (ms *MongoStorage) RemoveAccountProfileDrv(tenant, id string) (err error) {
 		return err
 	})
 }
+
+// GetSessionsBackupDrv returns the sessions backed up by the given node
+func (ms *MongoStorage) GetSessionsBackupDrv(nodeID string) (storedSessions []*StoredSession, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(ColSbk).Find(sctx, bson.M{"nodeid": nodeID})
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var elem struct {
+				Session []byte
+			}
+			if err = cur.Decode(&elem); err != nil {
+				return err
+			}
+			var ss *StoredSession
+			if err = ms.ms.Unmarshal(elem.Session, &ss); err != nil {
+				return err
+			}
+			storedSessions = append(storedSessions, ss)
+		}
+		return cur.Close(sctx)
+	})
+	if err == nil && len(storedSessions) == 0 {
+		err = utils.ErrNotFound
+	}
+	return
+}
+
+func (ms *MongoStorage) SetSessionBackupDrv(nodeID string, storedSession *StoredSession) (err error) {
+	var result []byte
+	if result, err = ms.ms.Marshal(storedSession); err != nil {
+		return
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSbk).UpdateOne(sctx, bson.M{"nodeid": nodeID, "cgrid": storedSession.CGRID},
+			bson.M{"$set": bson.M{"nodeid": nodeID, "cgrid": storedSession.CGRID, "session": result}},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveSessionsBackupDrv(nodeID, cgrID string) (err error) {
+	fltr := bson.M{"nodeid": nodeID}
+	if cgrID != utils.EmptyString {
+		fltr["cgrid"] = cgrID
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSbk).DeleteMany(sctx, fltr)
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetRerateJobDrv(tntID string) (job *StoredRerateJob, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		var elem struct {
+			Job []byte
+		}
+		if err = ms.getCol(ColRrj).FindOne(sctx, bson.M{"id": tntID}).Decode(&elem); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return ms.ms.Unmarshal(elem.Job, &job)
+	})
+	return
+}
+
+func (ms *MongoStorage) SetRerateJobDrv(job *StoredRerateJob) (err error) {
+	var result []byte
+	if result, err = ms.ms.Marshal(job); err != nil {
+		return
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColRrj).UpdateOne(sctx, bson.M{"id": job.TenantID()},
+			bson.M{"$set": bson.M{"id": job.TenantID(), "job": result}},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveRerateJobDrv(tntID string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColRrj).DeleteOne(sctx, bson.M{"id": tntID})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
@@ -1388,3 +1388,63 @@ func (rs *RedisStorage) SetAccountProfileDrv(ap *utils.AccountProfile) (err erro
 func (rs *RedisStorage) RemoveAccountProfileDrv(tenant, id string) (err error) {
 	return rs.Cmd(nil, redis_DEL, utils.AccountProfilePrefix+utils.ConcatenatedKey(tenant, id))
 }
+
+// GetSessionsBackupDrv returns the sessions stored in the node's backup hash
+func (rs *RedisStorage) GetSessionsBackupDrv(nodeID string) (storedSessions []*StoredSession, err error) {
+	mp := make(map[string]string)
+	if err = rs.Cmd(&mp, redis_HGETALL, utils.SessionsBackupPrefix+nodeID); err != nil {
+		return
+	}
+	if len(mp) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	storedSessions = make([]*StoredSession, 0, len(mp))
+	for _, values := range mp {
+		var ss *StoredSession
+		if err = rs.ms.Unmarshal([]byte(values), &ss); err != nil {
+			return nil, err
+		}
+		storedSessions = append(storedSessions, ss)
+	}
+	return
+}
+
+func (rs *RedisStorage) SetSessionBackupDrv(nodeID string, storedSession *StoredSession) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(storedSession); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_HMSET, utils.SessionsBackupPrefix+nodeID,
+		storedSession.CGRID, string(result))
+}
+
+func (rs *RedisStorage) RemoveSessionsBackupDrv(nodeID, cgrID string) (err error) {
+	if cgrID == utils.EmptyString {
+		return rs.Cmd(nil, redis_DEL, utils.SessionsBackupPrefix+nodeID)
+	}
+	return rs.Cmd(nil, redis_HDEL, utils.SessionsBackupPrefix+nodeID, cgrID)
+}
+
+func (rs *RedisStorage) GetRerateJobDrv(tntID string) (job *StoredRerateJob, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.RerateJobPrefix+tntID); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &job)
+	return
+}
+
+func (rs *RedisStorage) SetRerateJobDrv(job *StoredRerateJob) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(job); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.RerateJobPrefix+job.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveRerateJobDrv(tntID string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.RerateJobPrefix+tntID)
+}
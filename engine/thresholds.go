@@ -54,6 +54,7 @@ type ThresholdProfile struct {
 	Blocker            bool    // blocker flag to stop processing on filters matched
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
+	ActionProfileIDs   []string // ActionProfiles executed through ActionS
 	Async              bool
 }
 
@@ -86,7 +87,8 @@ func (t *Threshold) TenantID() string {
 
 // ProcessEvent processes an ThresholdEvent
 // concurrentActions limits the number of simultaneous action sets executed
-func (t *Threshold) ProcessEvent(args *ThresholdsArgsProcessEvent, dm *DataManager) (err error) {
+func (t *Threshold) ProcessEvent(args *ThresholdsArgsProcessEvent, dm *DataManager,
+	connMgr *ConnManager, actionSConns []string) (err error) {
 	if t.Snooze.After(time.Now()) || // snoozed, not executing actions
 		t.Hits < t.tPrfl.MinHits || // number of hits was not met, will not execute actions
 		(t.tPrfl.MaxHits != -1 &&
@@ -124,6 +126,33 @@ func (t *Threshold) ProcessEvent(args *ThresholdsArgsProcessEvent, dm *DataManag
 			err = utils.ErrPartiallyExecuted
 		}
 	}
+	if len(t.tPrfl.ActionProfileIDs) == 0 {
+		return
+	}
+	actArgs := &utils.ArgActionSv1ScheduleActions{
+		CGREvent:         args.CGREvent.Clone(),
+		ActionProfileIDs: t.tPrfl.ActionProfileIDs,
+	}
+	if actArgs.Opts == nil {
+		actArgs.Opts = make(map[string]interface{})
+	}
+	actArgs.Opts[utils.OptsTrgID] = t.TenantID()
+	if t.tPrfl.Async {
+		go func() {
+			var reply string
+			if errExec := connMgr.Call(actionSConns, nil, utils.ActionSv1ExecuteActions,
+				actArgs, &reply); errExec != nil {
+				utils.Logger.Warning(fmt.Sprintf("<ThresholdS> failed executing action profiles: %v, error: %s", t.tPrfl.ActionProfileIDs, errExec.Error()))
+			}
+		}()
+	} else {
+		var reply string
+		if errExec := connMgr.Call(actionSConns, nil, utils.ActionSv1ExecuteActions,
+			actArgs, &reply); errExec != nil {
+			utils.Logger.Warning(fmt.Sprintf("<ThresholdS> failed executing action profiles: %v, error: %s", t.tPrfl.ActionProfileIDs, errExec.Error()))
+			err = utils.ErrPartiallyExecuted
+		}
+	}
 	return
 }
 
@@ -136,10 +165,12 @@ func (ts Thresholds) Sort() {
 }
 
 // NewThresholdService the constructor for ThresoldS service
-func NewThresholdService(dm *DataManager, cgrcfg *config.CGRConfig, filterS *FilterS) (tS *ThresholdService) {
+func NewThresholdService(dm *DataManager, cgrcfg *config.CGRConfig, filterS *FilterS,
+	connMgr *ConnManager) (tS *ThresholdService) {
 	return &ThresholdService{dm: dm,
 		cgrcfg:      cgrcfg,
 		filterS:     filterS,
+		connMgr:     connMgr,
 		stopBackup:  make(chan struct{}),
 		loopStoped:  make(chan struct{}),
 		storedTdIDs: make(utils.StringSet),
@@ -151,6 +182,7 @@ type ThresholdService struct {
 	dm          *DataManager
 	cgrcfg      *config.CGRConfig
 	filterS     *FilterS
+	connMgr     *ConnManager
 	stopBackup  chan struct{}
 	loopStoped  chan struct{}
 	storedTdIDs utils.StringSet // keep a record of stats which need saving, map[statsTenantID]bool
@@ -339,7 +371,7 @@ func (tS *ThresholdService) processEvent(tnt string, args *ThresholdsArgsProcess
 	for _, t := range matchTs {
 		tIDs = append(tIDs, t.ID)
 		t.Hits++
-		err = t.ProcessEvent(args, tS.dm)
+		err = t.ProcessEvent(args, tS.dm, tS.connMgr, tS.cgrcfg.ThresholdSCfg().ActionSConns)
 		if err != nil {
 			utils.Logger.Warning(
 				fmt.Sprintf("<ThresholdService> threshold: %s, ignoring event: %s, error: %s",
@@ -24,6 +24,7 @@ import (
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 func TestThresholdsSort(t *testing.T) {
@@ -293,7 +294,7 @@ func TestThresholdsmatchingThresholdsForEvent(t *testing.T) {
 	defaultCfg.ThresholdSCfg().StoreInterval = 0
 	defaultCfg.ThresholdSCfg().StringIndexedFields = nil
 	defaultCfg.ThresholdSCfg().PrefixIndexedFields = nil
-	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg})
+	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg}, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -503,7 +504,7 @@ func TestThresholdsProcessEvent(t *testing.T) {
 	defaultCfg.ThresholdSCfg().StoreInterval = 0
 	defaultCfg.ThresholdSCfg().StringIndexedFields = nil
 	defaultCfg.ThresholdSCfg().PrefixIndexedFields = nil
-	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg})
+	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg}, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -704,7 +705,7 @@ func TestThresholdsVerifyIfExecuted(t *testing.T) {
 	defaultCfg.ThresholdSCfg().StoreInterval = 0
 	defaultCfg.ThresholdSCfg().StringIndexedFields = nil
 	defaultCfg.ThresholdSCfg().PrefixIndexedFields = nil
-	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg})
+	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg}, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -925,7 +926,7 @@ func TestThresholdsProcessEvent2(t *testing.T) {
 	defaultCfg.ThresholdSCfg().StoreInterval = 0
 	defaultCfg.ThresholdSCfg().StringIndexedFields = nil
 	defaultCfg.ThresholdSCfg().PrefixIndexedFields = nil
-	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg})
+	thServ = NewThresholdService(dmTH, defaultCfg, &FilterS{dm: dmTH, cfg: defaultCfg}, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -1068,3 +1069,70 @@ func TestThresholdsProcessEvent2(t *testing.T) {
 		}
 	}
 }
+
+type thdActionSMock struct {
+	args *utils.ArgActionSv1ScheduleActions
+	err  error
+}
+
+func (aS *thdActionSMock) Call(serviceMethod string, args, rply interface{}) error {
+	if serviceMethod != utils.ActionSv1ExecuteActions {
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	aS.args = args.(*utils.ArgActionSv1ScheduleActions)
+	if aS.err != nil {
+		return aS.err
+	}
+	*rply.(*string) = utils.OK
+	return nil
+}
+
+func TestThresholdProcessEventActionProfiles(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	actsConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaActions)}
+	mock := new(thdActionSMock)
+	aChan := make(chan rpcclient.ClientConnector, 1)
+	aChan <- mock
+	Cache.Clear([]string{utils.CacheRPCConnections})
+	connMgr := NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		actsConns[0]: aChan,
+	})
+	th := &Threshold{
+		Tenant: "cgrates.org",
+		ID:     "TH_ACTPRF",
+		tPrfl: &ThresholdProfile{
+			Tenant:           "cgrates.org",
+			ID:               "TH_ACTPRF",
+			MaxHits:          -1,
+			ActionProfileIDs: []string{"AP_TOPUP"},
+		},
+	}
+	args := &ThresholdsArgsProcessEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "EvActPrf",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+			},
+		},
+	}
+	if err := th.ProcessEvent(args, nil, connMgr, actsConns); err != nil {
+		t.Fatal(err)
+	}
+	if mock.args == nil {
+		t.Fatal("ActionS was not called")
+	}
+	if !reflect.DeepEqual(mock.args.ActionProfileIDs, []string{"AP_TOPUP"}) {
+		t.Errorf("Unexpected ActionProfileIDs: %+v", mock.args.ActionProfileIDs)
+	}
+	if trgID := mock.args.Opts[utils.OptsTrgID]; trgID != "cgrates.org:TH_ACTPRF" {
+		t.Errorf("Expected trgID: cgrates.org:TH_ACTPRF, received: %v", trgID)
+	}
+	if args.Opts != nil {
+		t.Errorf("The original event should not be modified: %+v", args.Opts)
+	}
+	mock.err = utils.ErrNotFound
+	if err := th.ProcessEvent(args, nil, connMgr, actsConns); err != utils.ErrPartiallyExecuted {
+		t.Errorf("Expected %v, received: %v", utils.ErrPartiallyExecuted, err)
+	}
+}
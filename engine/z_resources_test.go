@@ -866,6 +866,58 @@ func TestResourceAllocateResource(t *testing.T) {
 	}
 }
 
+func TestResourceAllocateResourceRateLimit(t *testing.T) {
+	r := &Resource{
+		Tenant: "cgrates.org",
+		ID:     "RL_RATE",
+		rPrf: &ResourceProfile{
+			Tenant:     "cgrates.org",
+			ID:         "RL_RATE",
+			Limit:      2,
+			RateWindow: 50 * time.Millisecond,
+		},
+		Usages: make(map[string]*ResourceUsage),
+		ttl:    utils.DurationPointer(0), // the window overwrites the ttl
+	}
+	rs := Resources{r}
+	for _, ruID := range []string{"RU1", "RU2"} {
+		if _, err := rs.allocateResource(&ResourceUsage{Tenant: "cgrates.org", ID: ruID, Units: 1}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the same usage is not counted twice
+	if _, err := rs.allocateResource(&ResourceUsage{Tenant: "cgrates.org", ID: "RU1", Units: 1}, false); err != nil {
+		t.Error(err)
+	}
+	if _, err := rs.allocateResource(&ResourceUsage{Tenant: "cgrates.org", ID: "RU3", Units: 1}, true); err == nil {
+		t.Fatal("Expected the resource to be rate limited")
+	} else if rlErr, isRateLimited := err.(*utils.ErrRetryAfter); !isRateLimited {
+		t.Fatalf("Expected rate limited error, received: %v", err)
+	} else if rlErr.RetryAfter <= 0 || rlErr.RetryAfter > 50*time.Millisecond {
+		t.Errorf("Unexpected retry after: %v", rlErr.RetryAfter)
+	} else if rlErr.Err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected %v, received: %v", utils.ErrResourceUnavailable, rlErr.Err)
+	}
+	// the units are not given back on release
+	if err := rs.clearUsage("RU1"); err != nil {
+		t.Error(err)
+	}
+	if tU := r.totalUsage(); tU != 2 {
+		t.Errorf("Expected: %v, received: %v", 2, tU)
+	}
+	// the usage can never fit the limit
+	if _, err := rs.allocateResource(&ResourceUsage{Tenant: "cgrates.org", ID: "RU4", Units: 3}, true); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected %v, received: %v", utils.ErrResourceUnavailable, err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if _, err := rs.allocateResource(&ResourceUsage{Tenant: "cgrates.org", ID: "RU3", Units: 1}, false); err != nil {
+		t.Error(err)
+	}
+	if len(r.Usages) != 1 {
+		t.Errorf("Expected only the last usage in the window, received: %s", utils.ToJSON(r.Usages))
+	}
+}
+
 // TestRSCacheSetGet assurace the presence of private params in cached resource
 func TestRSCacheSetGet(t *testing.T) {
 	r := &Resource{
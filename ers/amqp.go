@@ -67,6 +67,7 @@ type AMQPER struct {
 	exchange     string
 	exchangeType string
 	routingKey   string
+	atLeastOnce  bool // ack only after the message was processed
 
 	rdrEvents chan *erEvent // channel to dispatch the events created to
 	rdrExit   chan struct{}
@@ -160,12 +161,16 @@ func (rdr *AMQPER) readLoop(msgChan <-chan amqp.Delivery) {
 				continue
 			}
 			go func(msg amqp.Delivery) {
-				if err := rdr.processMessage(msg.Body); err != nil {
+				err := rdr.processMessage(msg.Body)
+				if err != nil {
 					utils.Logger.Warning(
 						fmt.Sprintf("<%s> processing message %s error: %s",
 							utils.ERs, msg.MessageId, err.Error()))
 				}
-				if rdr.poster != nil { // post it
+				if rdr.atLeastOnce {
+					rdr.acknowledge(msg, isDelivered(err))
+				}
+				if rdr.poster != nil && isDelivered(err) { // post it
 					if err := rdr.poster.Post(msg.Body, utils.EmptyString); err != nil {
 						utils.Logger.Warning(
 							fmt.Sprintf("<%s> writing message %s error: %s",
@@ -201,11 +206,30 @@ func (rdr *AMQPER) processMessage(msg []byte) (err error) {
 		return
 	}
 	cgrEv := config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
-	rdr.rdrEvents <- &erEvent{
-		cgrEvent: cgrEv,
-		rdrCfg:   rdr.Config(),
+	return dispatchEvent(rdr.rdrEvents, cgrEv, rdr.Config(), msg)
+}
+
+// acknowledge acks the delivered message, otherwise the message is requeued once
+// if it fails again after being redelivered it is rejected without requeue so the broker
+// moves it to the dead-letter exchange of the queue, if configured, or drops it
+func (rdr *AMQPER) acknowledge(msg amqp.Delivery, delivered bool) {
+	var err error
+	switch {
+	case delivered:
+		err = msg.Ack(false)
+	case !msg.Redelivered:
+		err = msg.Nack(false, true)
+	default:
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> message %s not delivered after redelivery, rejecting it",
+				utils.ERs, msg.MessageId))
+		err = msg.Nack(false, false)
+	}
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> acknowledging message %s error: %s",
+				utils.ERs, msg.MessageId, err.Error()))
 	}
-	return
 }
 
 func (rdr *AMQPER) setOpts(opts map[string]interface{}) {
@@ -227,6 +251,7 @@ func (rdr *AMQPER) setOpts(opts map[string]interface{}) {
 	if vals, has := opts[utils.ExchangeType]; has {
 		rdr.exchangeType = utils.IfaceAsString(vals)
 	}
+	rdr.atLeastOnce = isAtLeastOnce(opts)
 }
 
 func (rdr *AMQPER) close() (err error) {
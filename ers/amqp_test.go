@@ -20,6 +20,8 @@ package ers
 
 import (
 	"testing"
+
+	"github.com/streadway/amqp"
 )
 
 func TestAMQPSetOpts(t *testing.T) {
@@ -52,3 +54,42 @@ func TestAMQPSetOpts(t *testing.T) {
 		t.Errorf("Expected: %s ,received: %s", expKafka.tag, k.tag)
 	}
 }
+
+type testAMQPAcknowledger struct {
+	acked   bool
+	requeue *bool
+}
+
+func (ack *testAMQPAcknowledger) Ack(tag uint64, multiple bool) error {
+	ack.acked = true
+	return nil
+}
+
+func (ack *testAMQPAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	ack.requeue = &requeue
+	return nil
+}
+
+func (ack *testAMQPAcknowledger) Reject(tag uint64, requeue bool) error {
+	ack.requeue = &requeue
+	return nil
+}
+
+func TestAMQPAcknowledge(t *testing.T) {
+	rdr := new(AMQPER)
+	ack := new(testAMQPAcknowledger)
+	rdr.acknowledge(amqp.Delivery{Acknowledger: ack}, true)
+	if !ack.acked || ack.requeue != nil {
+		t.Errorf("Expected the message acknowledged, received: %+v", ack)
+	}
+	ack = new(testAMQPAcknowledger)
+	rdr.acknowledge(amqp.Delivery{Acknowledger: ack}, false)
+	if ack.acked || ack.requeue == nil || !*ack.requeue {
+		t.Errorf("Expected the message requeued, received: %+v", ack)
+	}
+	ack = new(testAMQPAcknowledger)
+	rdr.acknowledge(amqp.Delivery{Acknowledger: ack, Redelivered: true}, false)
+	if ack.acked || ack.requeue == nil || *ack.requeue {
+		t.Errorf("Expected the redelivered message rejected, received: %+v", ack)
+	}
+}
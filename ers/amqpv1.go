@@ -32,6 +32,7 @@ import (
 )
 
 // NewAMQPv1ER return a new amqpv1 event reader
+// *at_least_once delivery is not supported since the messages are accepted before processing, only the config sanity check rejects it
 func NewAMQPv1ER(cfg *config.CGRConfig, cfgIdx int,
 	rdrEvents chan *erEvent, rdrErr chan error,
 	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
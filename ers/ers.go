@@ -21,6 +21,7 @@ package ers
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
@@ -32,6 +33,8 @@ import (
 type erEvent struct {
 	cgrEvent *utils.CGREvent
 	rdrCfg   *config.EventReaderCfg
+	payload  []byte     // original message, exported if the event fails to be processed
+	rply     chan error // populated if the reader waits for the processing result
 }
 
 // NewERService instantiates the ERService
@@ -87,10 +90,21 @@ func (erS *ERService) ListenAndServe(stopChan, cfgRldChan chan struct{}) (err er
 			erS.closeAllRdrs()
 			return
 		case erEv := <-erS.rdrEvents:
+			if erEv.rply != nil { // the reader acknowledges the message after processing
+				go erS.deliverEvent(erEv)
+				continue
+			}
 			if err := erS.processEvent(erEv.cgrEvent, erEv.rdrCfg); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> reading event: <%s> got error: <%s>",
 						utils.ERs, utils.ToIJSON(erEv.cgrEvent), err.Error()))
+				engine.Metrics.IncrementCounter("cgrates_ers_events_failed_total",
+					"Number of events failed to be processed by ERs.",
+					map[string]string{"reader": erEv.rdrCfg.ID})
+			} else {
+				engine.Metrics.IncrementCounter("cgrates_ers_events_processed_total",
+					"Number of events processed by ERs.",
+					map[string]string{"reader": erEv.rdrCfg.ID})
 			}
 		case <-cfgRldChan: // handle reload
 			cfgIDs := make(map[string]int)
@@ -295,6 +309,80 @@ func (erS *ERService) processEvent(cgrEv *utils.CGREvent,
 	return
 }
 
+// deliverEvent processes the event for the readers with *at_least_once delivery
+// the processing is retried and, if still failing, the event is exported to the dead-letter exporter
+// the reader is informed if the message can be acknowledged
+func (erS *ERService) deliverEvent(erEv *erEvent) {
+	retries := utils.DefaultDeliveryRetries
+	if val, has := erEv.rdrCfg.Opts[utils.DeliveryRetries]; has {
+		if rts, err := utils.IfaceAsTInt64(val); err == nil {
+			retries = int(rts)
+		}
+	}
+	var err error
+	fib := utils.Fib()
+	for i := 0; ; i++ {
+		if err = erS.processEvent(erEv.cgrEvent.Clone(), erEv.rdrCfg); err == nil ||
+			i >= retries {
+			break
+		}
+		time.Sleep(time.Duration(fib()) * time.Second)
+	}
+	if err == nil {
+		engine.Metrics.IncrementCounter("cgrates_ers_events_processed_total",
+			"Number of events processed by ERs.",
+			map[string]string{"reader": erEv.rdrCfg.ID})
+		erEv.rply <- nil
+		return
+	}
+	utils.Logger.Warning(
+		fmt.Sprintf("<%s> reading event: <%s> got error: <%s>",
+			utils.ERs, utils.ToIJSON(erEv.cgrEvent), err.Error()))
+	engine.Metrics.IncrementCounter("cgrates_ers_events_failed_total",
+		"Number of events failed to be processed by ERs.",
+		map[string]string{"reader": erEv.rdrCfg.ID})
+	expID := utils.IfaceAsString(erEv.rdrCfg.Opts[utils.DeadLetterExporterID])
+	if expID == utils.EmptyString {
+		erEv.rply <- &deliveryError{err}
+		return
+	}
+	if errExp := erS.exportFailedEvent(erEv, expID, err); errExp != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> exporting failed event: <%s> to <%s> got error: <%s>",
+				utils.ERs, utils.ToIJSON(erEv.cgrEvent), expID, errExp.Error()))
+		erEv.rply <- &deliveryError{err}
+		return
+	}
+	engine.Metrics.IncrementCounter("cgrates_ers_events_dead_lettered_total",
+		"Number of events exported by ERs to the dead-letter exporter.",
+		map[string]string{"reader": erEv.rdrCfg.ID})
+	erEv.rply <- nil
+}
+
+// exportFailedEvent sends the event together with the original payload and the error to the dead-letter exporter
+// the exporter should be synchronous so the export errors are returned
+func (erS *ERService) exportFailedEvent(erEv *erEvent, expID string, prcErr error) (err error) {
+	ev := make(map[string]interface{}, len(erEv.cgrEvent.Event)+3)
+	for k, v := range erEv.cgrEvent.Event {
+		ev[k] = v
+	}
+	ev[utils.Payload] = string(erEv.payload)
+	ev[utils.Error] = prcErr.Error()
+	ev[utils.ReaderID] = erEv.rdrCfg.ID
+	var rply map[string]map[string]interface{}
+	return erS.connMgr.Call(erS.cfg.ERsCfg().EEsConns, nil, utils.EeSv1ProcessEvent,
+		&utils.CGREventWithEeIDs{
+			EeIDs: []string{expID},
+			CGREvent: &utils.CGREvent{
+				Tenant: erEv.cgrEvent.Tenant,
+				ID:     utils.UUIDSha1Prefix(),
+				Time:   utils.TimePointer(time.Now()),
+				Event:  ev,
+				Opts:   erEv.cgrEvent.Opts,
+			},
+		}, &rply)
+}
+
 func (erS *ERService) closeAllRdrs() {
 	for _, stopL := range erS.stopLsn {
 		close(stopL)
@@ -0,0 +1,183 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+type deliveryConnMock struct {
+	cdrErr   error
+	cdrCalls int
+	exported chan *utils.CGREventWithEeIDs
+}
+
+func (dM *deliveryConnMock) Call(serviceMethod string, args, rply interface{}) error {
+	switch serviceMethod {
+	case utils.SessionSv1ProcessCDR:
+		dM.cdrCalls++
+		return dM.cdrErr
+	case utils.EeSv1ProcessEvent:
+		dM.exported <- args.(*utils.CGREventWithEeIDs)
+		return nil
+	}
+	return rpcclient.ErrUnsupporteServiceMethod
+}
+
+func testDeliveryERService(mock *deliveryConnMock) *ERService {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ERsCfg().SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
+	cfg.ERsCfg().EEsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)}
+	sChan := make(chan rpcclient.ClientConnector, 1)
+	sChan <- mock
+	eChan := make(chan rpcclient.ClientConnector, 1)
+	eChan <- mock
+	engine.Cache.Clear([]string{utils.CacheRPCConnections}) // the connections are cached between tests
+	return NewERService(cfg, nil, engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS): sChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs):      eChan,
+	}))
+}
+
+func testDeliveryEvent(opts map[string]interface{}) *erEvent {
+	return &erEvent{
+		cgrEvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "dlvEv1",
+			Event: map[string]interface{}{
+				utils.OriginID: "dlv1",
+			},
+			Opts: map[string]interface{}{},
+		},
+		rdrCfg: &config.EventReaderCfg{
+			ID:    "dlvRdr",
+			Flags: utils.FlagsWithParamsFromSlice([]string{utils.MetaCDRs}),
+			Opts:  opts,
+		},
+		payload: []byte(`{"OriginID":"dlv1"}`),
+		rply:    make(chan error, 1),
+	}
+}
+
+func TestERsDeliverEvent(t *testing.T) {
+	mock := &deliveryConnMock{exported: make(chan *utils.CGREventWithEeIDs, 1)}
+	erS := testDeliveryERService(mock)
+	erEv := testDeliveryEvent(map[string]interface{}{
+		utils.DeliveryMode: utils.MetaAtLeastOnce,
+	})
+	erS.deliverEvent(erEv)
+	if err := <-erEv.rply; err != nil {
+		t.Error(err)
+	}
+	if mock.cdrCalls != 1 {
+		t.Errorf("Expected 1 call, received: %d", mock.cdrCalls)
+	}
+}
+
+func TestERsDeliverEventNotDelivered(t *testing.T) {
+	mock := &deliveryConnMock{
+		cdrErr:   errors.New("SESSIONS_DOWN"),
+		exported: make(chan *utils.CGREventWithEeIDs, 1),
+	}
+	erS := testDeliveryERService(mock)
+	erEv := testDeliveryEvent(map[string]interface{}{
+		utils.DeliveryMode:    utils.MetaAtLeastOnce,
+		utils.DeliveryRetries: 1,
+	})
+	erS.deliverEvent(erEv)
+	if err := <-erEv.rply; err == nil || err.Error() != "SESSIONS_DOWN" {
+		t.Errorf("Expected SESSIONS_DOWN, received: %v", err)
+	} else if isDelivered(err) {
+		t.Error("Expected the message to be redelivered")
+	}
+	if mock.cdrCalls != 2 {
+		t.Errorf("Expected 2 calls, received: %d", mock.cdrCalls)
+	}
+	select {
+	case ev := <-mock.exported:
+		t.Errorf("Unexpected export: %s", utils.ToJSON(ev))
+	default:
+	}
+}
+
+func TestERsDeliverEventDeadLetter(t *testing.T) {
+	mock := &deliveryConnMock{
+		cdrErr:   errors.New("SESSIONS_DOWN"),
+		exported: make(chan *utils.CGREventWithEeIDs, 1),
+	}
+	erS := testDeliveryERService(mock)
+	erEv := testDeliveryEvent(map[string]interface{}{
+		utils.DeliveryMode:         utils.MetaAtLeastOnce,
+		utils.DeliveryRetries:      0,
+		utils.DeadLetterExporterID: "dlq",
+	})
+	erS.deliverEvent(erEv)
+	if err := <-erEv.rply; err != nil {
+		t.Error(err)
+	}
+	select {
+	case ev := <-mock.exported:
+		if len(ev.EeIDs) != 1 || ev.EeIDs[0] != "dlq" {
+			t.Errorf("Unexpected exporters: %+v", ev.EeIDs)
+		}
+		if ev.Event[utils.Payload] != `{"OriginID":"dlv1"}` ||
+			ev.Event[utils.Error] != "SESSIONS_DOWN" ||
+			ev.Event[utils.ReaderID] != "dlvRdr" ||
+			ev.Event[utils.OriginID] != "dlv1" {
+			t.Errorf("Unexpected dead-letter event: %s", utils.ToJSON(ev))
+		}
+	case <-time.After(time.Second):
+		t.Error("The event was not exported")
+	}
+	if mock.cdrCalls != 1 {
+		t.Errorf("Expected 1 call, received: %d", mock.cdrCalls)
+	}
+}
+
+func TestERsDispatchEvent(t *testing.T) {
+	rdrEvents := make(chan *erEvent, 1)
+	cgrEv := &utils.CGREvent{Tenant: "cgrates.org", ID: "dispEv1"}
+	if err := dispatchEvent(rdrEvents, cgrEv, &config.EventReaderCfg{}, nil); err != nil {
+		t.Error(err)
+	}
+	if erEv := <-rdrEvents; erEv.rply != nil {
+		t.Error("Expected the event not to wait for the processing")
+	}
+	rdrCfg := &config.EventReaderCfg{
+		Opts: map[string]interface{}{utils.DeliveryMode: utils.MetaAtLeastOnce},
+	}
+	go func() {
+		erEv := <-rdrEvents
+		if string(erEv.payload) != "payload" {
+			t.Errorf("Unexpected payload: %q", erEv.payload)
+		}
+		erEv.rply <- &deliveryError{errors.New("NOT_PROCESSED")}
+	}()
+	if err := dispatchEvent(rdrEvents, cgrEv, rdrCfg, []byte("payload")); err == nil ||
+		err.Error() != "NOT_PROCESSED" || isDelivered(err) {
+		t.Errorf("Expected NOT_PROCESSED delivery error, received: %v", err)
+	}
+}
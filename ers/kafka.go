@@ -72,6 +72,8 @@ type KafkaER struct {
 	groupID string
 	maxWait time.Duration
 
+	atLeastOnce bool // commit the offset only after the message was processed
+
 	rdrEvents chan *erEvent // channel to dispatch the events created to
 	rdrExit   chan struct{}
 	rdrErr    chan error
@@ -116,11 +118,15 @@ func (rdr *KafkaER) Serve() (err error) {
 }
 
 func (rdr *KafkaER) readLoop(r *kafka.Reader) {
+	fetch := r.ReadMessage
+	if rdr.atLeastOnce {
+		fetch = r.FetchMessage // the offset is commited manually
+	}
 	for {
 		if rdr.Config().ConcurrentReqs != -1 {
 			<-rdr.cap // do not try to read if the limit is reached
 		}
-		msg, err := r.ReadMessage(context.Background())
+		msg, err := fetch(context.Background())
 		if err != nil {
 			if err == io.EOF {
 				// ignore io.EOF received from closing the connection from our side
@@ -131,6 +137,13 @@ func (rdr *KafkaER) readLoop(r *kafka.Reader) {
 			rdr.rdrErr <- err
 			return
 		}
+		if rdr.atLeastOnce { // process the messages in order so the offsets are commited after processing
+			rdr.processAndCommit(r, msg)
+			if rdr.Config().ConcurrentReqs != -1 {
+				rdr.cap <- struct{}{}
+			}
+			continue
+		}
 		go func(msg kafka.Message) {
 			if err := rdr.processMessage(msg.Value); err != nil {
 				utils.Logger.Warning(
@@ -151,6 +164,43 @@ func (rdr *KafkaER) readLoop(r *kafka.Reader) {
 	}
 }
 
+// processAndCommit processes the message and commits its offset
+// the message not delivered after the configured retries is processed again with a growing delay,
+// blocking the reader, so its offset is not commited until delivered or the reader is stopped
+func (rdr *KafkaER) processAndCommit(r *kafka.Reader, msg kafka.Message) {
+	delay := time.Second
+	for {
+		err := rdr.processMessage(msg.Value)
+		if err == nil {
+			break
+		}
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> processing message %s error: %s",
+				utils.ERs, string(msg.Key), err.Error()))
+		if isDelivered(err) {
+			break
+		}
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> message %s at offset %d not delivered, retrying in %s",
+				utils.ERs, string(msg.Key), msg.Offset, delay))
+		if !waitRedelivery(rdr.rdrExit, &delay) { // not commited so the message is read again after restart
+			return
+		}
+	}
+	if err := r.CommitMessages(context.Background(), msg); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> commiting message %s error: %s",
+				utils.ERs, string(msg.Key), err.Error()))
+	}
+	if rdr.poster != nil { // post it
+		if err := rdr.poster.Post(msg.Value, string(msg.Key)); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> writing message %s error: %s",
+					utils.ERs, string(msg.Key), err.Error()))
+		}
+	}
+}
+
 func (rdr *KafkaER) processMessage(msg []byte) (err error) {
 	var decodedMessage map[string]interface{}
 	if err = json.Unmarshal(msg, &decodedMessage); err != nil {
@@ -173,11 +223,7 @@ func (rdr *KafkaER) processMessage(msg []byte) (err error) {
 		return
 	}
 	cgrEv := config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
-	rdr.rdrEvents <- &erEvent{
-		cgrEvent: cgrEv,
-		rdrCfg:   rdr.Config(),
-	}
-	return
+	return dispatchEvent(rdr.rdrEvents, cgrEv, rdr.Config(), msg)
 }
 
 func (rdr *KafkaER) setOpts(opts map[string]interface{}) (err error) {
@@ -191,6 +237,7 @@ func (rdr *KafkaER) setOpts(opts map[string]interface{}) (err error) {
 	if vals, has := opts[utils.KafkaGroupID]; has {
 		rdr.groupID = utils.IfaceAsString(vals)
 	}
+	rdr.atLeastOnce = isAtLeastOnce(opts)
 	if vals, has := opts[utils.KafkaMaxWait]; has {
 		rdr.maxWait, err = utils.IfaceAsDuration(vals)
 	}
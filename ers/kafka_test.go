@@ -19,8 +19,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package ers
 
 import (
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/segmentio/kafka-go"
 )
 
 func TestKafkasetOpts(t *testing.T) {
@@ -91,3 +96,33 @@ func TestKafkasetOpts(t *testing.T) {
 		t.Errorf("Expected: %s ,received: %s", expKafka.maxWait, k.maxWait)
 	}
 }
+
+func TestKafkaProcessAndCommitNotDelivered(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ERsCfg().Readers[0].Opts = map[string]interface{}{utils.DeliveryMode: utils.MetaAtLeastOnce}
+	cfg.ERsCfg().Readers[0].Fields = nil
+	rdrEvents := make(chan *erEvent, 1)
+	rdr := &KafkaER{
+		cgrCfg:      cfg,
+		rdrEvents:   rdrEvents,
+		rdrExit:     make(chan struct{}),
+		atLeastOnce: true,
+	}
+	go func() {
+		erEv := <-rdrEvents
+		erEv.rply <- &deliveryError{errors.New("NOT_PROCESSED")}
+		close(rdr.rdrExit)
+	}()
+	// the reader is stopped while waiting to redeliver so the offset is not commited
+	done := make(chan struct{})
+	go func() {
+		rdr.processAndCommit(nil, kafka.Message{Key: []byte("key1"), Offset: 10,
+			Value: []byte(`{"OriginID":"1"}`)})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the redelivery to stop with the reader")
+	}
+}
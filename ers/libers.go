@@ -20,7 +20,9 @@ package ers
 
 import (
 	"strings"
+	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -33,3 +35,55 @@ func getProcessOptions(opts map[string]interface{}) (proc map[string]interface{}
 	}
 	return
 }
+
+// deliveryError is returned to the readers when the event was neither processed nor dead-lettered
+// so the message should not be acknowledged
+type deliveryError struct {
+	error
+}
+
+// isAtLeastOnce returns true if the reader acknowledges the messages only after they were processed
+func isAtLeastOnce(opts map[string]interface{}) bool {
+	return utils.IfaceAsString(opts[utils.DeliveryMode]) == utils.MetaAtLeastOnce
+}
+
+// maxRedeliveryDelay caps the delay between the attempts of delivering the same message
+const maxRedeliveryDelay = time.Minute
+
+// waitRedelivery waits before the next attempt of delivering the message, doubling the delay up to maxRedeliveryDelay
+// returns false if the reader was stopped meanwhile
+func waitRedelivery(rdrExit chan struct{}, delay *time.Duration) bool {
+	select {
+	case <-rdrExit:
+		return false
+	case <-time.After(*delay):
+	}
+	if *delay *= 2; *delay > maxRedeliveryDelay {
+		*delay = maxRedeliveryDelay
+	}
+	return true
+}
+
+// isDelivered returns false if the message needs to be redelivered
+func isDelivered(err error) bool {
+	_, notDlvd := err.(*deliveryError)
+	return !notDlvd
+}
+
+// dispatchEvent sends the event to ERService
+// with *at_least_once delivery it waits for the processing result
+func dispatchEvent(rdrEvents chan *erEvent, cgrEv *utils.CGREvent,
+	rdrCfg *config.EventReaderCfg, payload []byte) (err error) {
+	erEv := &erEvent{
+		cgrEvent: cgrEv,
+		rdrCfg:   rdrCfg,
+	}
+	if !isAtLeastOnce(rdrCfg.Opts) {
+		rdrEvents <- erEv
+		return
+	}
+	erEv.payload = payload
+	erEv.rply = make(chan error, 1)
+	rdrEvents <- erEv
+	return <-erEv.rply
+}
@@ -0,0 +1,222 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/nats-io/nats.go"
+)
+
+// NewNatsER return a new nats event reader
+func NewNatsER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &NatsER{
+		cgrCfg:    cfg,
+		cfgIdx:    cfgIdx,
+		fltrS:     fltrS,
+		rdrEvents: rdrEvents,
+		rdrExit:   rdrExit,
+		rdrErr:    rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.cap <- struct{}{}
+		}
+	}
+	rdr.dialURL = rdr.Config().SourcePath
+	if err = rdr.setOpts(rdr.Config().Opts); err != nil {
+		return
+	}
+	if err = rdr.createPoster(); err != nil {
+		return
+	}
+	return rdr, nil
+}
+
+// NatsER implements EventReader interface for nats message
+// with JetStream the messages are consumed through a durable consumer
+// and acknowledged only after they were processed
+type NatsER struct {
+	// sync.RWMutex
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	dialURL      string
+	subject      string
+	jetStream    bool
+	consumerName string
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	cap       chan struct{}
+
+	conn *nats.Conn
+
+	poster engine.Poster
+}
+
+// Config returns the curent configuration
+func (rdr *NatsER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will start the gorutines needed to watch the nats subject
+func (rdr *NatsER) Serve() (err error) {
+	if rdr.Config().RunDelay == time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		return
+	}
+	if rdr.conn, err = nats.Connect(rdr.dialURL); err != nil {
+		return
+	}
+	msgChan := make(chan *nats.Msg, nats.DefaultSubPendingMsgsLimit)
+	if rdr.jetStream {
+		var js nats.JetStreamContext
+		if js, err = rdr.conn.JetStream(); err != nil {
+			rdr.close()
+			return
+		}
+		_, err = js.ChanSubscribe(rdr.subject, msgChan,
+			nats.Durable(rdr.consumerName), nats.ManualAck(), nats.AckExplicit())
+	} else {
+		_, err = rdr.conn.ChanSubscribe(rdr.subject, msgChan)
+	}
+	if err != nil {
+		rdr.close()
+		return
+	}
+	go rdr.readLoop(msgChan) // read until the connection is closed
+	return
+}
+
+func (rdr *NatsER) readLoop(msgChan <-chan *nats.Msg) {
+	for {
+		if rdr.Config().ConcurrentReqs != -1 {
+			<-rdr.cap // do not try to read if the limit is reached
+		}
+		select {
+		case <-rdr.rdrExit:
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> stop monitoring nats path <%s>",
+					utils.ERs, rdr.dialURL))
+			rdr.close()
+			return
+		case msg := <-msgChan:
+			go func(msg *nats.Msg) {
+				err := rdr.processMessage(msg.Data)
+				if err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> processing message on subject %s error: %s",
+							utils.ERs, msg.Subject, err.Error()))
+					if rdr.jetStream {
+						if isDelivered(err) { // the message can not be processed so do not redeliver it
+							msg.Term()
+						} else {
+							msg.Nak()
+						}
+					}
+				} else if rdr.jetStream {
+					if err := msg.Ack(); err != nil {
+						utils.Logger.Warning(
+							fmt.Sprintf("<%s> acknowledging message on subject %s error: %s",
+								utils.ERs, msg.Subject, err.Error()))
+					}
+				}
+				if rdr.poster != nil && isDelivered(err) { // post it
+					if err := rdr.poster.Post(msg.Data, utils.EmptyString); err != nil {
+						utils.Logger.Warning(
+							fmt.Sprintf("<%s> writing message on subject %s error: %s",
+								utils.ERs, msg.Subject, err.Error()))
+					}
+				}
+				if rdr.Config().ConcurrentReqs != -1 {
+					rdr.cap <- struct{}{}
+				}
+			}(msg)
+		}
+	}
+}
+
+func (rdr *NatsER) processMessage(msg []byte) (err error) {
+	var decodedMessage map[string]interface{}
+	if err = json.Unmarshal(msg, &decodedMessage); err != nil {
+		return
+	}
+	agReq := agents.NewAgentRequest(
+		utils.MapStorage(decodedMessage), nil,
+		nil, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil || !pass {
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return
+	}
+	cgrEv := config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	return dispatchEvent(rdr.rdrEvents, cgrEv, rdr.Config(), msg)
+}
+
+func (rdr *NatsER) setOpts(opts map[string]interface{}) (err error) {
+	rdr.subject = utils.DefaultQueueID
+	rdr.consumerName = utils.NatsDefaultConsumerName
+	if vals, has := opts[utils.NatsSubject]; has {
+		rdr.subject = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NatsConsumerName]; has {
+		rdr.consumerName = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NatsJetStream]; has {
+		rdr.jetStream, err = utils.IfaceAsBool(vals)
+	}
+	return
+}
+
+// close the connection without unsubscribing so the durable consumer is kept on the server
+func (rdr *NatsER) close() {
+	if rdr.poster != nil {
+		rdr.poster.Close()
+	}
+	rdr.conn.Close()
+}
+
+func (rdr *NatsER) createPoster() (err error) {
+	processedOpt := getProcessOptions(rdr.Config().Opts)
+	if len(processedOpt) == 0 &&
+		len(rdr.Config().ProcessedPath) == 0 {
+		return
+	}
+	rdr.poster, err = engine.NewNatsPoster(utils.FirstNonEmpty(rdr.Config().ProcessedPath, rdr.Config().SourcePath),
+		rdr.cgrCfg.GeneralCfg().PosterAttempts, processedOpt)
+	return
+}
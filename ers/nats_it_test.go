@@ -0,0 +1,147 @@
+// +build integration
+
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/nats-io/nats.go"
+)
+
+// needs a local nats-server started with JetStream enabled: nats-server -js
+func TestNatsJetStreamER(t *testing.T) {
+	cfg, err := config.NewCGRConfigFromJSONStringWithDefaults(`{
+"ers": {
+	"enabled": true,
+	"readers": [
+		{
+			"id": "nats",
+			"type": "*nats_json_map",
+			"run_delay":  "-1",
+			"concurrent_requests": 1024,
+			"source_path": "nats://localhost:4222",
+			"opts": {
+				"natsSubject": "cgrates.ers.in",
+				"natsJetStream": true,
+				"natsConsumerName": "cgrates_ers_it",
+				"natsSubjectProcessed": "cgrates.ers.out.;~*req.Account",
+				"natsJetStreamProcessed": true,
+			},
+			"tenant": "cgrates.org",
+			"filters": [],
+			"flags": [],
+			"fields":[
+				{"tag": "CGRID", "type": "*composed", "value": "~*req.CGRID", "path": "*cgreq.CGRID"},
+				{"tag": "Account", "type": "*composed", "value": "~*req.Account", "path": "*cgreq.Account"},
+			],
+		},
+	],
+},
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := nats.Connect("nats://localhost:4222")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	js, err := conn.JetStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	js.DeleteStream("CGRATES_ERS")
+	if _, err = js.AddStream(&nats.StreamConfig{
+		Name:     "CGRATES_ERS",
+		Subjects: []string{"cgrates.ers.>"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer js.DeleteStream("CGRATES_ERS")
+	processed, err := conn.SubscribeSync("cgrates.ers.out.1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdrEvents = make(chan *erEvent, 1)
+	rdrErr = make(chan error, 1)
+	rdrExit = make(chan struct{}, 1)
+
+	if rdr, err = NewNatsER(cfg, 1, rdrEvents,
+		rdrErr, new(engine.FilterS), rdrExit); err != nil {
+		t.Fatal(err)
+	}
+	randomCGRID := utils.UUIDSha1Prefix()
+	if _, err = js.Publish("cgrates.ers.in",
+		[]byte(`{"CGRID": "`+randomCGRID+`", "Account": "1001"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err = rdr.Serve(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err = <-rdrErr:
+		t.Error(err)
+	case ev := <-rdrEvents:
+		if ev.rdrCfg.ID != "nats" {
+			t.Errorf("Expected 'nats' received `%s`", ev.rdrCfg.ID)
+		}
+		expected := &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     ev.cgrEvent.ID,
+			Time:   ev.cgrEvent.Time,
+			Event: map[string]interface{}{
+				"CGRID":   randomCGRID,
+				"Account": "1001",
+			},
+			Opts: map[string]interface{}{},
+		}
+		if !reflect.DeepEqual(ev.cgrEvent, expected) {
+			t.Errorf("Expected %s ,received %s", utils.ToJSON(expected), utils.ToJSON(ev.cgrEvent))
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timeout")
+	}
+	if _, err = processed.NextMsg(10 * time.Second); err != nil {
+		t.Errorf("Expected the processed message on the templated subject, received: %v", err)
+	}
+	// the message was acknowledged so the durable consumer has nothing pending
+	for i := 0; i < 100; i++ {
+		var info *nats.ConsumerInfo
+		if info, err = js.ConsumerInfo("CGRATES_ERS", "cgrates_ers_it"); err != nil {
+			t.Fatal(err)
+		}
+		if info.NumAckPending == 0 && info.NumPending == 0 {
+			break
+		}
+		if i == 99 {
+			t.Errorf("Expected the message to be acknowledged, received: %s", utils.ToJSON(info))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(rdrExit)
+}
@@ -0,0 +1,60 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNatssetOpts(t *testing.T) {
+	rdr := new(NatsER)
+	if err := rdr.setOpts(map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	} else if rdr.subject != utils.DefaultQueueID {
+		t.Errorf("Expected: %s ,received: %s", utils.DefaultQueueID, rdr.subject)
+	} else if rdr.consumerName != utils.NatsDefaultConsumerName {
+		t.Errorf("Expected: %s ,received: %s", utils.NatsDefaultConsumerName, rdr.consumerName)
+	} else if rdr.jetStream {
+		t.Error("Expected JetStream to be disabled")
+	}
+
+	rdr = new(NatsER)
+	if err := rdr.setOpts(map[string]interface{}{
+		utils.NatsSubject:      "cgrates.cdrs",
+		utils.NatsConsumerName: "ers1",
+		utils.NatsJetStream:    "true",
+	}); err != nil {
+		t.Fatal(err)
+	} else if rdr.subject != "cgrates.cdrs" {
+		t.Errorf("Expected: %s ,received: %s", "cgrates.cdrs", rdr.subject)
+	} else if rdr.consumerName != "ers1" {
+		t.Errorf("Expected: %s ,received: %s", "ers1", rdr.consumerName)
+	} else if !rdr.jetStream {
+		t.Error("Expected JetStream to be enabled")
+	}
+
+	rdr = new(NatsER)
+	if err := rdr.setOpts(map[string]interface{}{
+		utils.NatsJetStream: "notBool",
+	}); err == nil {
+		t.Error("Expected error for invalid natsJetStream option")
+	}
+}
@@ -35,6 +35,7 @@ import (
 )
 
 // NewS3ER return a new s3 event reader
+// the objects are removed without waiting for the processing result, so the config sanity check rejects *at_least_once delivery
 func NewS3ER(cfg *config.CGRConfig, cfgIdx int,
 	rdrEvents chan *erEvent, rdrErr chan error,
 	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
@@ -44,6 +44,7 @@ const (
 )
 
 // NewSQLEventReader return a new sql event reader
+// the rows are deleted once read, *at_least_once delivery is not checked here but by the config sanity
 func NewSQLEventReader(cfg *config.CGRConfig, cfgIdx int,
 	rdrEvents chan *erEvent, rdrErr chan error,
 	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
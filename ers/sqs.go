@@ -116,11 +116,7 @@ func (rdr *SQSER) processMessage(body []byte) (err error) {
 		return
 	}
 	cgrEv := config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
-	rdr.rdrEvents <- &erEvent{
-		cgrEvent: cgrEv,
-		rdrCfg:   rdr.Config(),
-	}
-	return
+	return dispatchEvent(rdr.rdrEvents, cgrEv, rdr.Config(), body)
 }
 
 func (rdr *SQSER) parseOpts(opts map[string]interface{}) {
@@ -223,7 +219,7 @@ func (rdr *SQSER) readMsg(scv *sqs.SQS, msg *sqs.Message) (err error) {
 	}
 	body := []byte(*msg.Body)
 	key := *msg.MessageId
-	if err = rdr.processMessage(body); err != nil {
+	if err = rdr.processMessage(body); err != nil { // the message is not deleted so it will be received again
 		utils.Logger.Warning(
 			fmt.Sprintf("<%s> processing message %s error: %s",
 				utils.ERs, key, err.Error()))
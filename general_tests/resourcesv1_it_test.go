@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package general_tests
 
 import (
+	"errors"
 	"net/rpc"
 	"path"
 	"testing"
@@ -222,7 +223,7 @@ func testV1RsAuthorize(t *testing.T) {
 		Units: 1,
 	}
 	if err := rlsV1Rpc.Call(utils.ResourceSv1AuthorizeResources,
-		&argsRU, &reply2); err.Error() != "RESOURCE_UNAUTHORIZED" {
+		&argsRU, &reply2); !errors.Is(utils.CastRPCErr(err), utils.ErrResourceUnauthorized) {
 		t.Error(err)
 	}
 }
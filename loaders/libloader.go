@@ -50,7 +50,14 @@ func (ld LoaderData) GetRateIDs() ([]string, error) {
 // contained in record and processed with cfgTpl
 func (ld LoaderData) UpdateFromCSV(fileName string, record []string,
 	cfgTpl []*config.FCTemplate, tnt config.RSRParsers, filterS *engine.FilterS) (err error) {
-	csvProvider := newCsvProvider(record, fileName)
+	return ld.UpdateFromDataProvider(newCsvProvider(record, fileName),
+		cfgTpl, tnt, filterS)
+}
+
+// UpdateFromDataProvider will update LoaderData with the record
+// in csvProvider processed with cfgTpl
+func (ld LoaderData) UpdateFromDataProvider(csvProvider utils.DataProvider,
+	cfgTpl []*config.FCTemplate, tnt config.RSRParsers, filterS *engine.FilterS) (err error) {
 	tenant, err := tnt.ParseValue("")
 	if err != nil {
 		return err
@@ -173,3 +180,91 @@ func (cP *csvProvider) FieldAsString(fldPath []string) (data string, err error)
 func (cP *csvProvider) RemoteHost() net.Addr {
 	return utils.LocalAddr()
 }
+
+// newRecordProvider constructs a DataProvider out of a record read from the not CSV sources
+func newRecordProvider(record utils.MapStorage, fileName string) (dP utils.DataProvider) {
+	return &recordProvider{
+		req:      record,
+		fileName: fileName,
+		cache:    utils.MapStorage{},
+		cfg:      config.CgrConfig().GetDataProvider(),
+	}
+}
+
+// recordProvider implements utils.DataProvider so we can pass it to filters
+type recordProvider struct {
+	req      utils.MapStorage
+	fileName string
+	cache    utils.MapStorage
+	cfg      utils.DataProvider
+}
+
+// String is part of utils.DataProvider interface
+// when called, it will display the already parsed values out of cache
+func (rP *recordProvider) String() string {
+	return utils.ToJSON(rP)
+}
+
+// FieldAsInterface is part of utils.DataProvider interface
+func (rP *recordProvider) FieldAsInterface(fldPath []string) (data interface{}, err error) {
+	if data, err = rP.cache.FieldAsInterface(fldPath); err == nil ||
+		err != utils.ErrNotFound { // item found in cache
+		return
+	}
+	err = nil // cancel previous err
+	var reqPath []string
+	switch {
+	case strings.HasPrefix(fldPath[0], utils.MetaFile+utils.FilterValStart):
+		fileName := strings.TrimPrefix(fldPath[0], utils.MetaFile+utils.FilterValStart)
+		hasSelEnd := false
+		for i, val := range fldPath[1:] {
+			if hasSelEnd = strings.HasSuffix(val, utils.FilterValEnd); hasSelEnd {
+				fileName = fileName + utils.NestingSep + val[:len(val)-1]
+				reqPath = fldPath[i+2:]
+				break
+			}
+			fileName = fileName + utils.NestingSep + val
+		}
+		if !hasSelEnd {
+			return nil, fmt.Errorf("filter rule <%s> needs to end in )", fldPath)
+		}
+		if rP.fileName != fileName {
+			rP.cache.Set(fldPath, nil)
+			return
+		}
+	case fldPath[0] == utils.MetaReq:
+		reqPath = fldPath[1:]
+	case fldPath[0] == utils.MetaCfg:
+		data, err = rP.cfg.FieldAsInterface(fldPath[1:])
+		if err != nil {
+			return
+		}
+		rP.cache.Set(fldPath, data)
+		return
+	default:
+		return nil, fmt.Errorf("invalid prefix for : %s", fldPath)
+	}
+	if data, err = rP.req.FieldAsInterface(reqPath); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		data, err = nil, nil // missing fields are considered empty, same as the empty columns in CSV
+	}
+	rP.cache.Set(fldPath, data)
+	return
+}
+
+// FieldAsString is part of utils.DataProvider interface
+func (rP *recordProvider) FieldAsString(fldPath []string) (data string, err error) {
+	var valIface interface{}
+	valIface, err = rP.FieldAsInterface(fldPath)
+	if err != nil {
+		return
+	}
+	return utils.IfaceAsString(valIface), nil
+}
+
+// RemoteHost is part of utils.DataProvider interface
+func (rP *recordProvider) RemoteHost() net.Addr {
+	return utils.LocalAddr()
+}
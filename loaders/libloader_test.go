@@ -399,3 +399,83 @@ func TestLoadersFieldAsInterfaceError(t *testing.T) {
 		t.Errorf("Expected %+v, received %+q", expected, err)
 	}
 }
+
+func TestDataUpdateFromDataProviderMultiFiles(t *testing.T) {
+	attrSFlds := []*config.FCTemplate{
+		{Tag: "TenantID",
+			Path:      "Tenant",
+			Type:      utils.MetaString,
+			Value:     config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep),
+			Mandatory: true},
+		{Tag: "ProfileID",
+			Path:      "ID",
+			Type:      utils.MetaComposed,
+			Value:     config.NewRSRParsersMustCompile("~*file(File2.json).ID", utils.InfieldSep),
+			Mandatory: true},
+		{Tag: "Path",
+			Path:  "Path",
+			Type:  utils.MetaComposed,
+			Value: config.NewRSRParsersMustCompile("~*file(File1.json).Attribute.Path", utils.InfieldSep)},
+		{Tag: "Substitute",
+			Path:  "Substitute",
+			Type:  utils.MetaComposed,
+			Value: config.NewRSRParsersMustCompile("~*file(File1.json).Attribute.Value", utils.InfieldSep)},
+		{Tag: "FilterIDs",
+			Path:  "FilterIDs",
+			Type:  utils.MetaComposed,
+			Value: config.NewRSRParsersMustCompile("~*file(File1.json).FilterIDs", utils.InfieldSep)},
+		{Tag: "Weight",
+			Path:  "Weight",
+			Type:  utils.MetaComposed,
+			Value: config.NewRSRParsersMustCompile("~*req.Weight", utils.InfieldSep)},
+	}
+
+	loadRun1 := map[string]utils.MapStorage{
+		"File1.json": {"Attribute": map[string]interface{}{"Path": "Subject", "Value": "1001"}},
+		"File2.json": {"ID": "ATTR_1", "Weight": 10.},
+	}
+	lData := make(LoaderData)
+	for fName, record := range loadRun1 {
+		if err := lData.UpdateFromDataProvider(newRecordProvider(record, fName), attrSFlds,
+			config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep), nil); err != nil {
+			t.Error(err)
+		}
+	}
+
+	eLData := LoaderData{"Tenant": "cgrates.org",
+		"ID":         "ATTR_1",
+		"Path":       "Subject",
+		"Substitute": "1001",
+		"FilterIDs":  "",
+		"Weight":     "10",
+	}
+	if !reflect.DeepEqual(eLData, lData) {
+		t.Errorf("expecting: %+v, received: %+v", eLData, lData)
+	}
+}
+
+func TestLoadersRecordProviderFieldAsInterface(t *testing.T) {
+	rP := newRecordProvider(utils.MapStorage{"Tenant": "cgrates.org"}, "File1.json")
+	if rcv, err := rP.FieldAsString([]string{utils.MetaReq, "Tenant"}); err != nil {
+		t.Error(err)
+	} else if rcv != "cgrates.org" {
+		t.Errorf("Expected %+v, received %+v", "cgrates.org", rcv)
+	}
+	if rcv, err := rP.FieldAsInterface([]string{"*file(File2", "json)", "Tenant"}); err != nil {
+		t.Error(err)
+	} else if rcv != nil {
+		t.Errorf("Expected nil, received %+v", rcv)
+	}
+
+	expected := "invalid prefix for : [File2.json]"
+	if _, err := rP.FieldAsInterface([]string{"File2.json"}); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+	expected = "filter rule <[*file() File1.json]> needs to end in )"
+	if _, err := rP.FieldAsInterface([]string{"*file()", "File1.json"}); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+q", expected, err)
+	}
+	if rcv := rP.RemoteHost().String(); rcv != "local" {
+		t.Errorf("Expected %+v, received %+v", "local", rcv)
+	}
+}
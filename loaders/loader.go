@@ -26,6 +26,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -35,8 +36,50 @@ import (
 
 type openedCSVFile struct {
 	fileName string
-	rdr      io.ReadCloser // keep reference so we can close it when done
+	rdr      io.Closer // keep reference so we can close it when done
 	csvRdr   *csv.Reader
+	recRdr   recordReader // used instead of csvRdr for the sources not in CSV format
+}
+
+// read returns the next record out of the file as DataProvider
+func (oF *openedCSVFile) read() (dP utils.DataProvider, err error) {
+	if oF.recRdr != nil {
+		var rec utils.MapStorage
+		if rec, err = oF.recRdr.Read(); err != nil {
+			return
+		}
+		return newRecordProvider(rec, oF.fileName), nil
+	}
+	var record []string
+	if record, err = oF.csvRdr.Read(); err != nil {
+		return
+	}
+	return newCsvProvider(record, oF.fileName), nil
+}
+
+// newOpenedFile wraps the content in rdr based on the loader type
+func (ldr *Loader) newOpenedFile(fName string, rdr io.ReadCloser) *openedCSVFile {
+	if ldr.ldrType == utils.MetaFileNDJSON ||
+		ldr.ldrType == utils.MetaS3NDJSON {
+		return &openedCSVFile{fileName: fName, rdr: rdr,
+			recRdr: newNDJSONReader(rdr)}
+	}
+	csvReader := csv.NewReader(rdr)
+	csvReader.Comma = rune(ldr.fieldSep[0])
+	csvReader.Comment = '#'
+	return &openedCSVFile{fileName: fName, rdr: rdr, csvRdr: csvReader}
+}
+
+// openFile opens fName out of the loader source
+func (ldr *Loader) openFile(fName string) (oF *openedCSVFile, err error) {
+	if ldr.src != nil {
+		return ldr.src.open(fName)
+	}
+	var rdr *os.File
+	if rdr, err = os.Open(path.Join(ldr.tpInDir, fName)); err != nil {
+		return
+	}
+	return ldr.newOpenedFile(fName, rdr), nil
 }
 
 func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
@@ -52,6 +95,8 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 		lockFilename:  cfg.LockFileName,
 		fieldSep:      cfg.FieldSeparator,
 		runDelay:      cfg.RunDelay,
+		ldrType:       cfg.Type,
+		transactional: cfg.Transactional,
 		dataTpls:      make(map[string][]*config.FCTemplate),
 		flagsTpls:     make(map[string]utils.FlagsWithParams),
 		rdrs:          make(map[string]map[string]*openedCSVFile),
@@ -62,6 +107,12 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 		connMgr:       connMgr,
 		cacheConns:    cacheConns,
 	}
+	switch cfg.Type {
+	case utils.MetaS3CSV, utils.MetaS3NDJSON:
+		ldr.src = newS3Source(ldr, cfg.Opts)
+	case utils.MetaSQL:
+		ldr.src = newSQLSource(ldr, cfg.Opts)
+	}
 	for _, ldrData := range cfg.Data {
 		ldr.dataTpls[ldrData.Type] = ldrData.Fields
 		ldr.flagsTpls[ldrData.Type] = ldrData.Flags
@@ -95,6 +146,13 @@ type Loader struct {
 	lockFilename  string
 	fieldSep      string
 	runDelay      time.Duration
+	ldrType       string                               // type of the source: <*file_csv|*file_ndjson|*s3_csv|*s3_ndjson|*sql>
+	src           remoteSource                         // nil for the sources within local folders
+	transactional bool                                 // stage the data and store it at once
+	tx            *loaderTransaction                   // transaction in progress, protected by lkMux through lockFolder
+	txs           []*loaderTransaction                 // applied transactions, kept in memory for rollback
+	txMux         sync.Mutex                           // protects txs
+	lkMux         sync.Mutex                           // serializes the loads within this process
 	dataTpls      map[string][]*config.FCTemplate      // map[loaderType]*config.FCTemplate
 	flagsTpls     map[string]utils.FlagsWithParams     //map[loaderType]utils.FlagsWithParams
 	rdrs          map[string]map[string]*openedCSVFile // map[loaderType]map[fileName]*openedCSVFile for common incremental read
@@ -132,18 +190,41 @@ func (ldr *Loader) ProcessFolder(caching, loadOption string, stopOnError bool) (
 }
 
 // lockFolder will attempt to lock the folder by creating the lock file
+// the loads within this process are serialized before taking the lock
 func (ldr *Loader) lockFolder() (err error) {
-	_, err = os.OpenFile(path.Join(ldr.tpInDir, ldr.lockFilename),
-		os.O_RDONLY|os.O_CREATE, 0644)
+	ldr.lkMux.Lock()
+	if ldr.src != nil {
+		err = ldr.src.lock()
+	} else {
+		_, err = os.OpenFile(path.Join(ldr.tpInDir, ldr.lockFilename),
+			os.O_RDONLY|os.O_CREATE, 0644)
+	}
+	if err != nil {
+		ldr.lkMux.Unlock()
+	}
 	return
 }
 
+// unlockFolder releases the lock taken by lockFolder
 func (ldr *Loader) unlockFolder() (err error) {
+	err = ldr.removeLock()
+	ldr.lkMux.Unlock()
+	return
+}
+
+// removeLock removes the lock on the folder without waiting for the loads in progress
+func (ldr *Loader) removeLock() (err error) {
+	if ldr.src != nil {
+		return ldr.src.unlock()
+	}
 	return os.Remove(path.Join(ldr.tpInDir,
 		ldr.lockFilename))
 }
 
 func (ldr *Loader) isFolderLocked() (locked bool, err error) {
+	if ldr.src != nil {
+		return ldr.src.isLocked()
+	}
 	if _, err = os.Stat(path.Join(ldr.tpInDir,
 		ldr.lockFilename)); err == nil {
 		return true, nil
@@ -165,6 +246,15 @@ func (ldr *Loader) moveFiles() (err error) {
 	if ldr.tpOutDir == utils.EmptyString {
 		return
 	}
+	if ldr.src != nil {
+		var fNames []string
+		for _, rdrs := range ldr.rdrs {
+			for fName := range rdrs {
+				fNames = append(fNames, fName)
+			}
+		}
+		return ldr.src.move(fNames)
+	}
 	filesInDir, _ := ioutil.ReadDir(ldr.tpInDir)
 	for _, file := range filesInDir {
 		fName := file.Name()
@@ -182,15 +272,11 @@ func (ldr *Loader) moveFiles() (err error) {
 
 func (ldr *Loader) processFiles(loaderType, caching, loadOption string) (err error) {
 	for fName := range ldr.rdrs[loaderType] {
-		var rdr *os.File
-		if rdr, err = os.Open(path.Join(ldr.tpInDir, fName)); err != nil {
+		var oF *openedCSVFile
+		if oF, err = ldr.openFile(fName); err != nil {
 			return err
 		}
-		csvReader := csv.NewReader(rdr)
-		csvReader.Comma = rune(ldr.fieldSep[0])
-		csvReader.Comment = '#'
-		ldr.rdrs[loaderType][fName] = &openedCSVFile{
-			fileName: fName, rdr: rdr, csvRdr: csvReader}
+		ldr.rdrs[loaderType][fName] = oF
 		defer ldr.unreferenceFile(loaderType, fName)
 	}
 	// based on load option will store or remove the content
@@ -216,13 +302,16 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 		lineNr++
 		var hasErrors bool
 		lData := make(LoaderData) // one row
-		for fName, rdr := range ldr.rdrs[loaderType] {
-			var record []string
-			if record, err = rdr.csvRdr.Read(); err != nil {
+		for _, rdr := range ldr.rdrs[loaderType] {
+			var record utils.DataProvider
+			if record, err = rdr.read(); err != nil {
 				if err == io.EOF {
 					keepLooping = false
 					break
 				}
+				if ldr.tx != nil { // transactional loads fail on the first bad line
+					return fmt.Errorf("reading line: %d, error: %s", lineNr, err.Error())
+				}
 				hasErrors = true
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> reading line: %d, error: %s",
@@ -232,8 +321,11 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 				continue
 			}
 
-			if err := lData.UpdateFromCSV(fName, record,
+			if err := lData.UpdateFromDataProvider(record,
 				ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS); err != nil {
+				if ldr.tx != nil {
+					return fmt.Errorf("line: %d, error: %s", lineNr, err.Error())
+				}
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
 						utils.LoaderS, ldr.ldrID, lineNr, err.Error()))
@@ -301,6 +393,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(apf)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, apf)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, apf.TenantID())
 				if err := ldr.dm.SetAttributeProfile(apf, true); err != nil {
@@ -331,6 +427,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(res)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, res)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, res.TenantID())
 				if err := ldr.dm.SetResourceProfile(res, true); err != nil {
@@ -374,6 +474,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(fltrPrf)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, fltrPrf)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, fltrPrf.TenantID())
 				if err := ldr.dm.SetFilter(fltrPrf, true); err != nil {
@@ -403,6 +507,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(stsPrf)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, stsPrf)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, stsPrf.TenantID())
 				if err := ldr.dm.SetStatQueueProfile(stsPrf, true); err != nil {
@@ -449,6 +557,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(thPrf)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, thPrf)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, thPrf.TenantID())
 				if err := ldr.dm.SetThresholdProfile(thPrf, true); err != nil {
@@ -483,6 +595,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(spPrf)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, spPrf)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, spPrf.TenantID())
 				if err := ldr.dm.SetRouteProfile(spPrf, true); err != nil {
@@ -513,6 +629,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(cpp)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, cpp)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, cpp.TenantID())
 				if err := ldr.dm.SetChargerProfile(cpp, true); err != nil {
@@ -542,6 +662,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(dsp)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, dsp)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, dsp.TenantID())
 				if err := ldr.dm.SetDispatcherProfile(dsp, true); err != nil {
@@ -567,6 +691,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(dsp)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, dsp)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, dsp.TenantID())
 				if err := ldr.dm.SetDispatcherHost(dsp); err != nil {
@@ -596,6 +724,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(rpl)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, rpl)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, rpl.TenantID())
 				if ldr.flagsTpls[loaderType].GetBool(utils.MetaPartial) {
@@ -632,6 +764,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(acp)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, acp)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, acp.TenantID())
 				if err := ldr.dm.SetActionProfile(acp, true); err != nil {
@@ -665,15 +801,28 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 							utils.LoaderS, ldr.ldrID, utils.ToJSON(acp)))
 					continue
 				}
+				if ldr.tx != nil { // stage the profile, it will be stored on commit
+					ldr.tx.stage(loaderType, acp)
+					continue
+				}
 				// get IDs so we can reload in cache
 				ids = append(ids, acp.TenantID())
-				if err := ldr.dm.SetAccountProfile(acp, true); err != nil {
+				if err := ldr.dm.SetProvisionedAccountProfile(acp, true); err != nil {
 					return err
 				}
 			}
 		}
 	}
 
+	if ldr.tx != nil { // transactional load, the cache is updated on commit
+		return
+	}
+	return ldr.updateCache(caching, cacheArgs, cacheIDs)
+}
+
+// updateCache will update the cache for the loaded items based on the caching option
+func (ldr *Loader) updateCache(caching string, cacheArgs map[string][]string, cacheIDs []string) (err error) {
+
 	if len(ldr.cacheConns) != 0 {
 		var reply string
 		switch caching {
@@ -724,9 +873,9 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 		lineNr++
 		var hasErrors bool
 		lData := make(LoaderData) // one row
-		for fName, rdr := range ldr.rdrs[loaderType] {
-			var record []string
-			if record, err = rdr.csvRdr.Read(); err != nil {
+		for _, rdr := range ldr.rdrs[loaderType] {
+			var record utils.DataProvider
+			if record, err = rdr.read(); err != nil {
 				if err == io.EOF {
 					keepLooping = false
 					break
@@ -740,7 +889,7 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 				continue
 			}
 
-			if err := lData.UpdateFromCSV(fName, record,
+			if err := lData.UpdateFromDataProvider(record,
 				ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
@@ -1086,7 +1235,18 @@ func (ldr *Loader) serve(stopChan chan struct{}) (err error) {
 
 func (ldr *Loader) handleFolder(stopChan chan struct{}) {
 	for {
-		go ldr.ProcessFolder(config.CgrConfig().GeneralCfg().DefaultCaching, utils.MetaStore, false)
+		if ldr.transactional {
+			go func() {
+				if err := ldr.ProcessFolderTransactional(config.CgrConfig().GeneralCfg().DefaultCaching,
+					utils.EmptyString); err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s-%s> transactional load failed, error: %s",
+							utils.LoaderS, ldr.ldrID, err.Error()))
+				}
+			}()
+		} else {
+			go ldr.ProcessFolder(config.CgrConfig().GeneralCfg().DefaultCaching, utils.MetaStore, false)
+		}
 		timer := time.NewTimer(ldr.runDelay)
 		select {
 		case <-stopChan:
@@ -1112,15 +1272,11 @@ func (ldr *Loader) processFile(_, itmID string) (err error) {
 	if ldr.rdrs[loaderType][itmID] != nil {
 		ldr.unreferenceFile(loaderType, itmID)
 	}
-	var rdr *os.File
-	if rdr, err = os.Open(path.Join(ldr.tpInDir, itmID)); err != nil {
+	var oF *openedCSVFile
+	if oF, err = ldr.openFile(itmID); err != nil {
 		return
 	}
-	csvReader := csv.NewReader(rdr)
-	csvReader.Comma = rune(ldr.fieldSep[0])
-	csvReader.Comment = '#'
-	ldr.rdrs[loaderType][itmID] = &openedCSVFile{
-		fileName: itmID, rdr: rdr, csvRdr: csvReader}
+	ldr.rdrs[loaderType][itmID] = oF
 	if !ldr.allFilesPresent(loaderType) {
 		return
 	}
@@ -1128,7 +1284,11 @@ func (ldr *Loader) processFile(_, itmID string) (err error) {
 		defer ldr.unreferenceFile(loaderType, fName)
 	}
 
-	err = ldr.processContent(loaderType, config.CgrConfig().GeneralCfg().DefaultCaching)
+	if ldr.transactional {
+		err = ldr.processContentTransactional(loaderType, config.CgrConfig().GeneralCfg().DefaultCaching)
+	} else {
+		err = ldr.processContent(loaderType, config.CgrConfig().GeneralCfg().DefaultCaching)
+	}
 
 	if ldr.tpOutDir == utils.EmptyString {
 		return
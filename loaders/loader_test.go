@@ -5151,3 +5151,112 @@ func TestLoaderServiceEnabled(t *testing.T) {
 		t.Errorf("Expected false, received %+v", rcv)
 	}
 }
+
+// newDefaultTplLoader returns a Loader reading the content with the default template of the loader type
+func newDefaultTplLoader(t *testing.T, ldrType, fileName, content string) *Loader {
+	ldr := &Loader{
+		ldrID:         "TestLoaderDefaultTemplate",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm: engine.NewDataManager(engine.NewInternalDB(nil, nil, true),
+			config.CgrConfig().CacheCfg(), nil),
+		timezone: "UTC",
+		dataTpls: make(map[string][]*config.FCTemplate),
+	}
+	for _, ldrData := range config.NewDefaultCGRConfig().LoaderCfg()[0].Data {
+		if ldrData.Type == ldrType {
+			ldr.dataTpls[ldrType] = ldrData.Fields
+		}
+	}
+	if len(ldr.dataTpls[ldrType]) == 0 {
+		t.Fatalf("no default template for %s", ldrType)
+	}
+	rdr := ioutil.NopCloser(strings.NewReader(content))
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		ldrType: {
+			fileName: &openedCSVFile{fileName: fileName,
+				rdr: rdr, csvRdr: csvRdr}},
+	}
+	return ldr
+}
+
+func TestLoaderProcessStatsDefaultTemplate(t *testing.T) {
+	ldr := newDefaultTplLoader(t, utils.MetaStats, utils.StatsCsv, `
+#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],QueueLength[4],TTL[5],MinItems[6],Metrics[7],MetricFilterIDs[8],Stored[9],Blocker[10],Weight[11],ThresholdIDs[12],BucketWidth[13],Window[14],WindowType[15]
+cgrates.org,STATS_WINDOW,,,100,,2,*sum#~*req.Usage,,,,20,*none,1m,1h,*tumbling
+`)
+	if err := ldr.processContent(utils.MetaStats, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	sqp, err := ldr.dm.GetStatQueueProfile("cgrates.org", "STATS_WINDOW",
+		true, false, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqp.BucketWidth != time.Minute ||
+		sqp.Window != time.Hour ||
+		sqp.WindowType != utils.MetaTumbling {
+		t.Errorf("unexpected windowing: %s", utils.ToJSON(sqp))
+	}
+}
+
+func TestLoaderProcessRateProfilesDefaultTemplate(t *testing.T) {
+	ldr := newDefaultTplLoader(t, utils.MetaRateProfiles, utils.RateProfilesCsv, `
+#Tenant,ID,FilterIDs,ActivationInterval,Weights,MinCost,MaxCost,MaxCostStrategy,RateID,RateFilterIDs,RateActivationStart,RateWeights,RateBlocker,RateIntervalStart,RateFixedFee,RateRecurrentFee,RateUnit,RateIncrement,VolumeUsage,VolumeTiers
+cgrates.org,RP_VOLUME,,,,,,,RT_ALWAYS,,* * * * *,,false,0s,0,0.01,1m,1s,~*req.Usage,0s;1h:0.5
+`)
+	if err := ldr.processContent(utils.MetaRateProfiles, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	rPrf, err := ldr.dm.GetRateProfile("cgrates.org", "RP_VOLUME",
+		true, false, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rPrf.VolumeUsage != "~*req.Usage" {
+		t.Errorf("Expected %q, received %q", "~*req.Usage", rPrf.VolumeUsage)
+	}
+	if len(rPrf.VolumeTiers) != 2 ||
+		rPrf.VolumeTiers[1].VolumeStart != time.Hour ||
+		rPrf.VolumeTiers[1].Factor.Compare(utils.NewDecimal(5, 1)) != 0 {
+		t.Errorf("Unexpected volume tiers: %s", utils.ToJSON(rPrf.VolumeTiers))
+	}
+}
+
+func TestLoaderProcessThresholdsDefaultTemplate(t *testing.T) {
+	ldr := newDefaultTplLoader(t, utils.MetaThresholds, utils.ThresholdsCsv, `
+#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],MaxHits[4],MinHits[5],MinSleep[6],Blocker[7],Weight[8],ActionIDs[9],Async[10],ActionProfileIDs[11]
+cgrates.org,THD_ACTPRF,,,-1,1,1s,false,10,,true,ACT_PRF_1;ACT_PRF_2
+`)
+	if err := ldr.processContent(utils.MetaThresholds, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	th, err := ldr.dm.GetThresholdProfile("cgrates.org", "THD_ACTPRF",
+		true, false, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(th.ActionProfileIDs)
+	if exp := []string{"ACT_PRF_1", "ACT_PRF_2"}; !reflect.DeepEqual(exp, th.ActionProfileIDs) {
+		t.Errorf("Expected %+v, received %+v", exp, th.ActionProfileIDs)
+	}
+}
+
+func TestLoaderProcessResourcesDefaultTemplate(t *testing.T) {
+	ldr := newDefaultTplLoader(t, utils.MetaResources, utils.ResourcesCsv, `
+#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],TTL[4],Limit[5],AllocationMessage[6],Blocker[7],Stored[8],Weight[9],Thresholds[10],RateWindow[11]
+cgrates.org,RES_RATE,,,1s,10,,false,true,20,*none,1m
+`)
+	if err := ldr.processContent(utils.MetaResources, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	rPrf, err := ldr.dm.GetResourceProfile("cgrates.org", "RES_RATE",
+		true, false, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rPrf.RateWindow != time.Minute {
+		t.Errorf("Expected %v, received %v", time.Minute, rPrf.RateWindow)
+	}
+}
@@ -62,10 +62,12 @@ func (ldrS *LoaderService) ListenAndServe(stopChan chan struct{}) (err error) {
 }
 
 type ArgsProcessFolder struct {
-	LoaderID    string
-	ForceLock   bool
-	Caching     *string
-	StopOnError bool
+	LoaderID      string
+	ForceLock     bool // removes the lock file, the SQL advisory lock of another engine is not released
+	Caching       *string
+	StopOnError   bool
+	Transactional *bool  // overwrites the loader transactional option
+	LoadID        string // identifies the transactional load, generated if empty and returned as reply
 }
 
 func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
@@ -85,7 +87,7 @@ func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
 		if !args.ForceLock {
 			return errors.New("ANOTHER_LOADER_RUNNING")
 		}
-		if err := ldr.unlockFolder(); err != nil {
+		if err := ldr.removeLock(); err != nil {
 			return utils.NewErrServerError(err)
 		}
 	}
@@ -94,6 +96,21 @@ func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
 	if args.Caching != nil {
 		caching = *args.Caching
 	}
+	transactional := ldr.transactional
+	if args.Transactional != nil {
+		transactional = *args.Transactional
+	}
+	if transactional {
+		loadID := args.LoadID
+		if loadID == utils.EmptyString {
+			loadID = utils.GenUUID()
+		}
+		if err := ldr.ProcessFolderTransactional(caching, loadID); err != nil {
+			return utils.NewErrServerError(err)
+		}
+		*rply = loadID // needed by V1Rollback
+		return
+	}
 	if err := ldr.ProcessFolder(caching, utils.MetaStore, args.StopOnError); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -101,6 +118,40 @@ func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
 	return
 }
 
+// ArgsRollback are the arguments for rolling back a transactional load
+type ArgsRollback struct {
+	LoaderID string
+	LoadID   string // defaults to the last transactional load
+	Caching  *string
+}
+
+// V1Rollback restores the profiles changed by the last transactional load to their previous versions
+// the history of the transactional loads is kept in memory only, so it is lost on engine restart
+func (ldrS *LoaderService) V1Rollback(args *ArgsRollback,
+	rply *string) (err error) {
+	ldrS.RLock()
+	defer ldrS.RUnlock()
+	if args.LoaderID == "" {
+		args.LoaderID = utils.MetaDefault
+	}
+	ldr, has := ldrS.ldrs[args.LoaderID]
+	if !has {
+		return fmt.Errorf("UNKNOWN_LOADER: %s", args.LoaderID)
+	}
+	caching := config.CgrConfig().GeneralCfg().DefaultCaching
+	if args.Caching != nil {
+		caching = *args.Caching
+	}
+	if err := ldr.Rollback(caching, args.LoadID); err != nil {
+		if err == utils.ErrNotFound {
+			return err
+		}
+		return utils.NewErrServerError(err)
+	}
+	*rply = utils.OK
+	return
+}
+
 func (ldrS *LoaderService) V1Remove(args *ArgsProcessFolder,
 	rply *string) (err error) {
 	ldrS.RLock()
@@ -116,7 +167,7 @@ func (ldrS *LoaderService) V1Remove(args *ArgsProcessFolder,
 		return utils.NewErrServerError(err)
 	} else if locked {
 		if args.ForceLock {
-			if err := ldr.unlockFolder(); err != nil {
+			if err := ldr.removeLock(); err != nil {
 				return utils.NewErrServerError(err)
 			}
 		}
@@ -0,0 +1,198 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// newS3Source returns the source reading the files as objects out of a S3 compatible storage
+func newS3Source(ldr *Loader, opts map[string]interface{}) (src *s3Source) {
+	src = &s3Source{
+		ldr:    ldr,
+		bucket: utils.DefaultQueueID,
+	}
+	if val, has := opts[utils.QueueID]; has {
+		src.bucket = utils.IfaceAsString(val)
+	}
+	if val, has := opts[utils.AWSFolderPath]; has {
+		src.folderPath = utils.IfaceAsString(val)
+	}
+	if val, has := opts[utils.AWSRegion]; has {
+		src.awsRegion = utils.IfaceAsString(val)
+	}
+	if val, has := opts[utils.AWSKey]; has {
+		src.awsID = utils.IfaceAsString(val)
+	}
+	if val, has := opts[utils.AWSSecret]; has {
+		src.awsKey = utils.IfaceAsString(val)
+	}
+	if val, has := opts[utils.AWSToken]; has {
+		src.awsToken = utils.IfaceAsString(val)
+	}
+	if val, has := opts[utils.S3PathStyle]; has {
+		src.pathStyle, _ = utils.IfaceAsBool(val)
+	}
+	return
+}
+
+// s3Source reads the files out of a bucket, the tpInDir being the endpoint
+// and the tpOutDir the prefix the processed objects are moved under
+type s3Source struct {
+	sync.Mutex // protects the client
+	ldr        *Loader
+
+	bucket     string
+	folderPath string
+	awsRegion  string
+	awsID      string
+	awsKey     string
+	awsToken   string
+	pathStyle  bool
+
+	client *s3.S3
+}
+
+// getClient creates the S3 client on first use
+func (src *s3Source) getClient() (client *s3.S3, err error) {
+	src.Lock()
+	defer src.Unlock()
+	if src.client != nil {
+		return src.client, nil
+	}
+	cfg := aws.Config{Endpoint: aws.String(src.ldr.tpInDir)}
+	if len(src.awsRegion) != 0 {
+		cfg.Region = aws.String(src.awsRegion)
+	}
+	if len(src.awsID) != 0 &&
+		len(src.awsKey) != 0 {
+		cfg.Credentials = credentials.NewStaticCredentials(src.awsID, src.awsKey, src.awsToken)
+	}
+	if src.pathStyle {
+		cfg.S3ForcePathStyle = aws.Bool(true)
+	}
+	var sess *session.Session
+	if sess, err = session.NewSessionWithOptions(session.Options{Config: cfg}); err != nil {
+		return
+	}
+	src.client = s3.New(sess)
+	return src.client, nil
+}
+
+// open will open the object with the fName key
+func (src *s3Source) open(fName string) (oF *openedCSVFile, err error) {
+	var client *s3.S3
+	if client, err = src.getClient(); err != nil {
+		return
+	}
+	var obj *s3.GetObjectOutput
+	if obj, err = client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(src.bucket),
+		Key:    aws.String(path.Join(src.folderPath, fName)),
+	}); err != nil {
+		return
+	}
+	return src.ldr.newOpenedFile(fName, obj.Body), nil
+}
+
+// move will copy the objects under the tpOutDir prefix and remove the originals
+func (src *s3Source) move(fNames []string) (err error) {
+	var client *s3.S3
+	if client, err = src.getClient(); err != nil {
+		return
+	}
+	for _, fName := range fNames {
+		key := path.Join(src.folderPath, fName)
+		if _, err = client.CopyObject(&s3.CopyObjectInput{
+			Bucket:     aws.String(src.bucket),
+			CopySource: aws.String(path.Join(src.bucket, key)),
+			Key:        aws.String(strings.TrimPrefix(path.Join(src.ldr.tpOutDir, fName), utils.Slash)),
+		}); err != nil {
+			if aErr, canCast := err.(awserr.Error); canCast &&
+				aErr.Code() == s3.ErrCodeNoSuchKey { // not present in this run
+				err = nil
+				continue
+			}
+			return
+		}
+		if _, err = client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(src.bucket),
+			Key:    aws.String(key),
+		}); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// lock will create the lock object next to the files
+func (src *s3Source) lock() (err error) {
+	var client *s3.S3
+	if client, err = src.getClient(); err != nil {
+		return
+	}
+	_, err = client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(src.bucket),
+		Key:    aws.String(path.Join(src.folderPath, src.ldr.lockFilename)),
+		Body:   bytes.NewReader(nil),
+	})
+	return
+}
+
+// unlock will remove the lock object
+func (src *s3Source) unlock() (err error) {
+	var client *s3.S3
+	if client, err = src.getClient(); err != nil {
+		return
+	}
+	_, err = client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(src.bucket),
+		Key:    aws.String(path.Join(src.folderPath, src.ldr.lockFilename)),
+	})
+	return
+}
+
+// isLocked checks if the lock object exists
+func (src *s3Source) isLocked() (locked bool, err error) {
+	var client *s3.S3
+	if client, err = src.getClient(); err != nil {
+		return
+	}
+	if _, err = client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(src.bucket),
+		Key:    aws.String(path.Join(src.folderPath, src.ldr.lockFilename)),
+	}); err == nil {
+		return true, nil
+	}
+	if aErr, canCast := err.(awserr.Error); canCast &&
+		(aErr.Code() == s3.ErrCodeNoSuchKey || aErr.Code() == "NotFound") { // HeadObject has no body to carry the NoSuchKey code
+		return false, nil
+	}
+	return
+}
@@ -0,0 +1,68 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// remoteSource is implemented by the loader sources which are not local folders
+type remoteSource interface {
+	open(fName string) (*openedCSVFile, error) // opens the file/object/table with the given name
+	move(fNames []string) error                // archives the processed files
+	lock() error                               // locks the source for the other loaders
+	unlock() error                             // releases the lock
+	isLocked() (bool, error)                   // checks if a loader holds the lock
+}
+
+// recordReader reads the records one by one out of the sources not in CSV format
+type recordReader interface {
+	Read() (utils.MapStorage, error)
+}
+
+// newNDJSONReader returns a recordReader for newline delimited JSON content
+func newNDJSONReader(rdr io.Reader) *ndjsonReader {
+	return &ndjsonReader{rdr: bufio.NewReader(rdr)}
+}
+
+// ndjsonReader reads one JSON object per line
+type ndjsonReader struct {
+	rdr *bufio.Reader
+}
+
+// Read returns the next JSON object, ignoring the empty lines
+func (nR *ndjsonReader) Read() (rec utils.MapStorage, err error) {
+	for {
+		var line []byte
+		line, err = nR.rdr.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) == 0 {
+			if err != nil {
+				return
+			}
+			continue
+		}
+		rec = make(utils.MapStorage)
+		return rec, json.Unmarshal(line, &rec)
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cgrates/cgrates/utils"
+	"gorm.io/gorm/clause"
+
+	// libs for sql DBs
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// sqlDeleteBatchSize limits the number of keys in one DELETE statement
+const sqlDeleteBatchSize = 1000
+
+// sqlIdentifierRegexp matches the table and column names accepted out of the configuration, optionally prefixed by the schema
+var sqlIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// checkSQLIdentifier returns an error if the name can not be used as table or column name
+func checkSQLIdentifier(name string) (err error) {
+	if !sqlIdentifierRegexp.MatchString(name) {
+		return fmt.Errorf("invalid SQL identifier <%s>", name)
+	}
+	return
+}
+
+// newSQLSource returns the source reading the files as tables out of a SQL database
+func newSQLSource(ldr *Loader, opts map[string]interface{}) (src *sqlSource) {
+	src = &sqlSource{
+		ldr:      ldr,
+		dbName:   utils.SQLDefaultDBName,
+		sslMode:  utils.SQLDefaultSSLMode,
+		keyCol:   utils.SQLDefaultKeyColumn,
+		procKeys: make(map[string][]interface{}),
+	}
+	if val, has := opts[utils.SQLDBName]; has {
+		src.dbName = utils.IfaceAsString(val)
+	}
+	if val, has := opts[utils.SQLSSLMode]; has {
+		src.sslMode = utils.IfaceAsString(val)
+	}
+	if val, has := opts[utils.SQLKeyColumn]; has {
+		src.keyCol = utils.IfaceAsString(val)
+	}
+	return
+}
+
+// sqlSource reads each file as the table with the same name, the tpInDir being the connection URL
+// the processed rows are deleted from the tables on move, identified by the key column
+type sqlSource struct {
+	sync.Mutex // protects the db, the lock connection and the processed keys
+	ldr        *Loader
+
+	dbName  string
+	sslMode string
+	keyCol  string // the column uniquely identifying the rows
+
+	dbType   string
+	db       *gorm.DB
+	lockConn *sql.Conn                // keeps the session holding the advisory lock
+	procKeys map[string][]interface{} // the keys of the rows read, indexed on table
+}
+
+// getDB connects to the database on first use
+func (src *sqlSource) getDB() (db *gorm.DB, err error) {
+	src.Lock()
+	defer src.Unlock()
+	if src.db != nil {
+		return src.db, nil
+	}
+	var u *url.URL
+	if u, err = url.Parse(strings.TrimPrefix(src.ldr.tpInDir, utils.Meta)); err != nil {
+		return
+	}
+	password, _ := u.User.Password()
+	var dialect gorm.Dialector
+	switch u.Scheme {
+	case utils.MySQL:
+		dialect = mysql.Open(fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&loc=Local&parseTime=true&sql_mode='ALLOW_INVALID_DATES'",
+			u.User.Username(), password, u.Hostname(), u.Port(), src.dbName))
+	case utils.Postgres:
+		dialect = postgres.Open(fmt.Sprintf("host=%s port=%s dbname=%s user=%s password=%s sslmode=%s",
+			u.Hostname(), u.Port(), src.dbName, u.User.Username(), password, src.sslMode))
+	default:
+		return nil, fmt.Errorf("db type <%s> not supported", u.Scheme)
+	}
+	if db, err = gorm.Open(dialect, &gorm.Config{}); err != nil {
+		return
+	}
+	var sqlDB *sql.DB
+	if sqlDB, err = db.DB(); err != nil {
+		return
+	}
+	sqlDB.SetMaxOpenConns(10)
+	if err = sqlDB.Ping(); err != nil {
+		return
+	}
+	src.dbType = u.Scheme
+	src.db = db
+	return
+}
+
+// open will query all the rows in the fName table, ordered on the key column
+func (src *sqlSource) open(fName string) (oF *openedCSVFile, err error) {
+	if err = checkSQLIdentifier(fName); err != nil {
+		return
+	}
+	if err = checkSQLIdentifier(src.keyCol); err != nil {
+		return
+	}
+	var db *gorm.DB
+	if db, err = src.getDB(); err != nil {
+		return
+	}
+	var rows *sql.Rows
+	if rows, err = db.Table(fName).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: src.keyCol}}).Rows(); err != nil {
+		return
+	}
+	var colNames []string
+	if colNames, err = rows.Columns(); err != nil {
+		rows.Close()
+		return
+	}
+	keyIdx := -1
+	for i, colName := range colNames {
+		if colName == src.keyCol {
+			keyIdx = i
+			break
+		}
+	}
+	if keyIdx == -1 {
+		rows.Close()
+		return nil, fmt.Errorf("missing key column <%s> in table <%s>", src.keyCol, fName)
+	}
+	src.Lock()
+	src.procKeys[fName] = nil // drop the keys of a previous read not moved
+	src.Unlock()
+	rdr := &sqlRowsReader{rows: rows, colNames: colNames, keyIdx: keyIdx,
+		onRead: func(key interface{}) {
+			src.Lock()
+			src.procKeys[fName] = append(src.procKeys[fName], key)
+			src.Unlock()
+		}}
+	return &openedCSVFile{fileName: fName, rdr: rdr, recRdr: rdr}, nil
+}
+
+// move will delete the rows read out of the tables so they are not processed again
+func (src *sqlSource) move(fNames []string) (err error) {
+	var db *gorm.DB
+	if db, err = src.getDB(); err != nil {
+		return
+	}
+	src.Lock()
+	defer src.Unlock()
+	for _, fName := range fNames {
+		keys := src.procKeys[fName]
+		for len(keys) != 0 {
+			batch := keys
+			if len(batch) > sqlDeleteBatchSize {
+				batch = keys[:sqlDeleteBatchSize]
+			}
+			if err = db.Exec("DELETE FROM ? WHERE ? IN ?", clause.Table{Name: fName},
+				clause.Column{Name: src.keyCol}, batch).Error; err != nil {
+				src.procKeys[fName] = keys // keep the ones not deleted for the next move
+				return
+			}
+			keys = keys[len(batch):]
+		}
+		delete(src.procKeys, fName)
+	}
+	return
+}
+
+// lockName is the name of the advisory lock shared by the loaders using the same database
+func (src *sqlSource) lockName() string {
+	return utils.ConcatenatedKey(src.dbName, src.ldr.lockFilename)
+}
+
+// lock will take the advisory lock on a dedicated connection since the lock belongs to the session
+func (src *sqlSource) lock() (err error) {
+	var db *gorm.DB
+	if db, err = src.getDB(); err != nil {
+		return
+	}
+	var sqlDB *sql.DB
+	if sqlDB, err = db.DB(); err != nil {
+		return
+	}
+	src.Lock()
+	defer src.Unlock()
+	if src.lockConn != nil {
+		return utils.ErrExists
+	}
+	var conn *sql.Conn
+	if conn, err = sqlDB.Conn(context.Background()); err != nil {
+		return
+	}
+	query := "SELECT GET_LOCK(?, 0) = 1"
+	if src.dbType == utils.Postgres {
+		query = "SELECT pg_try_advisory_lock(hashtext($1))"
+	}
+	var locked bool
+	if err = conn.QueryRowContext(context.Background(), query, src.lockName()).Scan(&locked); err != nil {
+		conn.Close()
+		return
+	}
+	if !locked {
+		conn.Close()
+		return fmt.Errorf("lock <%s> held by another loader", src.lockName())
+	}
+	src.lockConn = conn
+	return
+}
+
+// unlock will release the advisory lock, the locks of the other sessions are released when their connection closes
+// so ForceLock can not release the lock held by another engine and the load fails until that engine releases it
+func (src *sqlSource) unlock() (err error) {
+	src.Lock()
+	defer src.Unlock()
+	if src.lockConn == nil {
+		return
+	}
+	query := "SELECT RELEASE_LOCK(?)"
+	if src.dbType == utils.Postgres {
+		query = "SELECT pg_advisory_unlock(hashtext($1))"
+	}
+	_, err = src.lockConn.ExecContext(context.Background(), query, src.lockName())
+	if errClose := src.lockConn.Close(); err == nil {
+		err = errClose
+	}
+	src.lockConn = nil
+	return
+}
+
+// isLocked checks if any session holds the advisory lock
+func (src *sqlSource) isLocked() (locked bool, err error) {
+	var db *gorm.DB
+	if db, err = src.getDB(); err != nil {
+		return
+	}
+	if src.dbType != utils.Postgres {
+		err = db.Raw("SELECT IS_USED_LOCK(?) IS NOT NULL", src.lockName()).Row().Scan(&locked)
+		return
+	}
+	src.Lock()
+	defer src.Unlock()
+	if src.lockConn != nil {
+		return true, nil
+	}
+	// postgres has no lookup by lock name so try to take it and release it on the same session
+	var sqlDB *sql.DB
+	if sqlDB, err = db.DB(); err != nil {
+		return
+	}
+	var conn *sql.Conn
+	if conn, err = sqlDB.Conn(context.Background()); err != nil {
+		return
+	}
+	defer conn.Close()
+	var taken bool
+	if err = conn.QueryRowContext(context.Background(),
+		"SELECT pg_try_advisory_lock(hashtext($1))", src.lockName()).Scan(&taken); err != nil || !taken {
+		return !taken, err
+	}
+	_, err = conn.ExecContext(context.Background(),
+		"SELECT pg_advisory_unlock(hashtext($1))", src.lockName())
+	return
+}
+
+// sqlRowsReader implements recordReader for the rows in a table
+type sqlRowsReader struct {
+	rows     *sql.Rows
+	colNames []string
+	keyIdx   int               // index of the key column
+	onRead   func(interface{}) // called with the key of each row read
+}
+
+// Read returns the next row indexed on column names
+func (sR *sqlRowsReader) Read() (rec utils.MapStorage, err error) {
+	if !sR.rows.Next() {
+		if err = sR.rows.Err(); err == nil {
+			err = io.EOF
+		}
+		return
+	}
+	columns := make([]interface{}, len(sR.colNames))
+	columnPointers := make([]interface{}, len(sR.colNames))
+	for i := range columns {
+		columnPointers[i] = &columns[i]
+	}
+	if err = sR.rows.Scan(columnPointers...); err != nil {
+		return
+	}
+	rec = make(utils.MapStorage)
+	for i, colName := range sR.colNames {
+		if bVal, isBytes := columns[i].([]byte); isBytes { // text columns are scanned as bytes by some drivers
+			columns[i] = string(bVal)
+		}
+		rec[colName] = columns[i]
+	}
+	if sR.onRead != nil {
+		sR.onRead(columns[sR.keyIdx])
+	}
+	return
+}
+
+// Close will release the rows
+func (sR *sqlRowsReader) Close() error {
+	return sR.rows.Close()
+}
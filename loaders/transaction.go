@@ -0,0 +1,501 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// txHistoryLen is the number of transactional loads kept for rollback
+const txHistoryLen = 10
+
+// txLoaderTypes is the order the staged profiles are applied in so the references are stored first
+var txLoaderTypes = []string{utils.MetaFilters, utils.MetaAttributes, utils.MetaRateProfiles,
+	utils.MetaDispatcherHosts, utils.MetaResources, utils.MetaStats, utils.MetaThresholds,
+	utils.MetaRoutes, utils.MetaChargers, utils.MetaDispatchers, utils.MetaActionProfiles,
+	utils.MetaAccountProfiles}
+
+// txCacheArgs are the cache arguments populated with the IDs for each loader type
+var txCacheArgs = map[string][]string{
+	utils.MetaAttributes:      {utils.AttributeProfileIDs},
+	utils.MetaResources:       {utils.ResourceProfileIDs, utils.ResourceIDs},
+	utils.MetaFilters:         {utils.FilterIDs},
+	utils.MetaStats:           {utils.StatsQueueProfileIDs, utils.StatsQueueIDs},
+	utils.MetaThresholds:      {utils.ThresholdProfileIDs, utils.ThresholdIDs},
+	utils.MetaRoutes:          {utils.RouteProfileIDs},
+	utils.MetaChargers:        {utils.ChargerProfileIDs},
+	utils.MetaDispatchers:     {utils.DispatcherProfileIDs},
+	utils.MetaDispatcherHosts: {utils.DispatcherHostIDs},
+	utils.MetaRateProfiles:    {utils.RateProfileIDs},
+	utils.MetaActionProfiles:  {utils.ActionProfileIDs},
+}
+
+// txCacheIDs are the filter indexes to be cleared for each loader type
+var txCacheIDs = map[string][]string{
+	utils.MetaAttributes:      {utils.CacheAttributeFilterIndexes},
+	utils.MetaResources:       {utils.CacheResourceFilterIndexes},
+	utils.MetaStats:           {utils.CacheStatFilterIndexes},
+	utils.MetaThresholds:      {utils.CacheThresholdFilterIndexes},
+	utils.MetaRoutes:          {utils.CacheRouteFilterIndexes},
+	utils.MetaChargers:        {utils.CacheChargerFilterIndexes},
+	utils.MetaDispatchers:     {utils.CacheDispatcherFilterIndexes},
+	utils.MetaRateProfiles:    {utils.CacheRateProfilesFilterIndexes, utils.CacheRateFilterIndexes},
+	utils.MetaActionProfiles:  {utils.CacheActionProfilesFilterIndexes},
+	utils.MetaAccountProfiles: {utils.CacheAccountProfilesFilterIndexes},
+}
+
+// txProfile is one of the profiles handled by the loader
+type txProfile interface {
+	TenantID() string
+}
+
+// newLoaderTransaction returns a transaction identified by loadID
+func newLoaderTransaction(loadID string, partialRates bool) *loaderTransaction {
+	if loadID == utils.EmptyString {
+		loadID = utils.GenUUID()
+	}
+	return &loaderTransaction{
+		loadID:       loadID,
+		partialRates: partialRates,
+		profiles:     make(map[string]map[string]txProfile),
+		prev:         make(map[string]map[string]txProfile),
+	}
+}
+
+// loaderTransaction stages the profiles of a transactional load so they can be
+// validated and applied at once, keeping the previous versions for rollback
+type loaderTransaction struct {
+	loadID       string
+	partialRates bool                            // the rates are added to the existing RateProfiles
+	profiles     map[string]map[string]txProfile // map[loaderType]map[tenantID]txProfile
+	prev         map[string]map[string]txProfile // previous versions, nil for the new profiles
+}
+
+// stage will keep the profile until commit
+func (tx *loaderTransaction) stage(loaderType string, prf txProfile) {
+	if _, has := tx.profiles[loaderType]; !has {
+		tx.profiles[loaderType] = make(map[string]txProfile)
+	}
+	if staged, has := tx.profiles[loaderType][prf.TenantID()]; has &&
+		tx.partialRates && loaderType == utils.MetaRateProfiles { // merge the rates of the same profile
+		for rtID, rt := range prf.(*engine.RateProfile).Rates {
+			staged.(*engine.RateProfile).Rates[rtID] = rt
+		}
+		return
+	}
+	tx.profiles[loaderType][prf.TenantID()] = prf
+}
+
+// has returns true if the profile was staged in this transaction
+func (tx *loaderTransaction) has(loaderType, tntID string) (has bool) {
+	_, has = tx.profiles[loaderType][tntID]
+	return
+}
+
+// cacheArgs returns the arguments for updating the cache for all the profiles in the transaction
+func (tx *loaderTransaction) cacheArgs() (cacheArgs map[string][]string, cacheIDs []string) {
+	cacheArgs = make(map[string][]string)
+	for _, loaderType := range txLoaderTypes {
+		if len(tx.profiles[loaderType]) == 0 {
+			continue
+		}
+		ids := make([]string, 0, len(tx.profiles[loaderType]))
+		for tntID := range tx.profiles[loaderType] {
+			ids = append(ids, tntID)
+		}
+		for _, argKey := range txCacheArgs[loaderType] {
+			cacheArgs[argKey] = ids
+		}
+		cacheIDs = append(cacheIDs, txCacheIDs[loaderType]...)
+	}
+	return
+}
+
+// ProcessFolderTransactional will stage the content in the folder and store it at once
+// with the possibility of rolling it back later based on the loadID
+func (ldr *Loader) ProcessFolderTransactional(caching, loadID string) (err error) {
+	if err = ldr.lockFolder(); err != nil {
+		return
+	}
+	defer ldr.unlockFolder()
+	ldr.tx = newLoaderTransaction(loadID, ldr.flagsTpls[utils.MetaRateProfiles].GetBool(utils.MetaPartial))
+	defer func() { ldr.tx = nil }()
+	for ldrType := range ldr.rdrs {
+		if err = ldr.processFiles(ldrType, caching, utils.MetaStore); err != nil {
+			ldr.bufLoaderData = make(map[string][]LoaderData) // drop the partially read data
+			return fmt.Errorf("loaderType: <%s>, %s", ldrType, err.Error())
+		}
+	}
+	if err = ldr.commitTransaction(ldr.tx, caching); err != nil {
+		return
+	}
+	return ldr.moveFiles()
+}
+
+// processContentTransactional will stage the content of the opened files and store it at once
+// the folder is locked by the caller so no other load uses ldr.tx meanwhile
+func (ldr *Loader) processContentTransactional(loaderType, caching string) (err error) {
+	ldr.tx = newLoaderTransaction(utils.EmptyString, ldr.flagsTpls[utils.MetaRateProfiles].GetBool(utils.MetaPartial))
+	defer func() { ldr.tx = nil }()
+	if err = ldr.processContent(loaderType, caching); err != nil {
+		ldr.bufLoaderData = make(map[string][]LoaderData) // drop the partially read data
+		return
+	}
+	return ldr.commitTransaction(ldr.tx, caching)
+}
+
+// validateTransaction checks the references of the staged profiles, either within the
+// transaction or already in the DataDB
+func (ldr *Loader) validateTransaction(tx *loaderTransaction) (err error) {
+	var broken []string
+	checkRefs := func(loaderType, tntID, tnt, refType string, ids []string) {
+		for _, id := range ids {
+			if id == utils.EmptyString ||
+				strings.HasPrefix(id, utils.Meta) { // inline filters, *none or *constant values
+				continue
+			}
+			if tx.has(refType, utils.ConcatenatedKey(tnt, id)) {
+				continue
+			}
+			if prf, err := ldr.getProfile(refType, tnt, id); err != nil || prf == nil {
+				broken = append(broken, fmt.Sprintf("%s <%s> references missing %s <%s>",
+					loaderType, tntID, refType, id))
+			}
+		}
+	}
+	for _, loaderType := range txLoaderTypes {
+		for tntID, prf := range tx.profiles[loaderType] {
+			var fltrIDs []string
+			var tnt string
+			switch p := prf.(type) {
+			case *engine.AttributeProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+				for _, attr := range p.Attributes {
+					fltrIDs = append(fltrIDs, attr.FilterIDs...)
+				}
+			case *engine.ResourceProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+			case *engine.StatQueueProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+			case *engine.ThresholdProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+				checkRefs(loaderType, tntID, tnt, utils.MetaActionProfiles, p.ActionProfileIDs)
+				for _, actID := range p.ActionIDs { // the actions are not tenant based nor loaded by LoaderS
+					if actID == utils.EmptyString ||
+						strings.HasPrefix(actID, utils.Meta) {
+						continue
+					}
+					if _, err := ldr.dm.GetActions(actID, false, utils.NonTransactional); err != nil {
+						broken = append(broken, fmt.Sprintf("%s <%s> references missing %s <%s>",
+							loaderType, tntID, utils.MetaActions, actID))
+					}
+				}
+			case *engine.RouteProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+				for _, rt := range p.Routes {
+					fltrIDs = append(fltrIDs, rt.FilterIDs...)
+					checkRefs(loaderType, tntID, tnt, utils.MetaResources, rt.ResourceIDs)
+					statIDs := make([]string, len(rt.StatIDs))
+					for i, statID := range rt.StatIDs { // StatID:MetricID
+						statIDs[i] = strings.Split(statID, utils.InInFieldSep)[0]
+					}
+					checkRefs(loaderType, tntID, tnt, utils.MetaStats, statIDs)
+				}
+			case *engine.ChargerProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+				checkRefs(loaderType, tntID, tnt, utils.MetaAttributes, p.AttributeIDs)
+			case *engine.DispatcherProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+				hostIDs := make([]string, len(p.Hosts))
+				for i, host := range p.Hosts {
+					fltrIDs = append(fltrIDs, host.FilterIDs...)
+					hostIDs[i] = host.ID
+				}
+				checkRefs(loaderType, tntID, tnt, utils.MetaDispatcherHosts, hostIDs)
+			case *engine.RateProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+				for _, rt := range p.Rates {
+					fltrIDs = append(fltrIDs, rt.FilterIDs...)
+				}
+			case *engine.ActionProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+				for _, act := range p.Actions {
+					fltrIDs = append(fltrIDs, act.FilterIDs...)
+				}
+			case *utils.AccountProfile:
+				tnt, fltrIDs = p.Tenant, p.FilterIDs
+				for _, blnc := range p.Balances {
+					fltrIDs = append(fltrIDs, blnc.FilterIDs...)
+					checkRefs(loaderType, tntID, tnt, utils.MetaAttributes, blnc.AttributeIDs)
+					checkRefs(loaderType, tntID, tnt, utils.MetaRateProfiles, blnc.RateProfileIDs)
+				}
+			}
+			checkRefs(loaderType, tntID, tnt, utils.MetaFilters, fltrIDs)
+		}
+	}
+	if len(broken) != 0 {
+		return fmt.Errorf("broken references: %s", strings.Join(broken, "; "))
+	}
+	return
+}
+
+// commitTransaction validates and stores the staged profiles, restoring the
+// previous versions in case of errors
+func (ldr *Loader) commitTransaction(tx *loaderTransaction, caching string) (err error) {
+	if err = ldr.validateTransaction(tx); err != nil {
+		return
+	}
+	if ldr.dryRun {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s-%s> DRY_RUN: transaction <%s> validated",
+				utils.LoaderS, ldr.ldrID, tx.loadID))
+		return
+	}
+	for loaderType, prfs := range tx.profiles { // keep the previous versions for rollback
+		tx.prev[loaderType] = make(map[string]txProfile)
+		for tntID := range prfs {
+			tntIDStruct := utils.NewTenantID(tntID)
+			var prev txProfile
+			if prev, err = ldr.getProfile(loaderType, tntIDStruct.Tenant, tntIDStruct.ID); err != nil {
+				return
+			}
+			tx.prev[loaderType][tntID] = prev
+		}
+	}
+	applied := newLoaderTransaction(tx.loadID, false) // profiles stored so far, for restoring on error
+	for _, loaderType := range txLoaderTypes {
+		for tntID, prf := range tx.profiles[loaderType] {
+			if err = ldr.setProfile(loaderType, prf, tx.partialRates); err != nil {
+				if rbErr := ldr.restoreProfiles(applied, tx.prev); rbErr != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s-%s> restoring profiles of transaction <%s>, error: %s",
+							utils.LoaderS, ldr.ldrID, tx.loadID, rbErr.Error()))
+				}
+				return fmt.Errorf("storing %s <%s>, error: %s", loaderType, tntID, err.Error())
+			}
+			applied.stage(loaderType, prf)
+		}
+	}
+	ldr.txMux.Lock()
+	if ldr.txs = append(ldr.txs, tx); len(ldr.txs) > txHistoryLen {
+		ldr.txs = ldr.txs[len(ldr.txs)-txHistoryLen:]
+	}
+	ldr.txMux.Unlock()
+	utils.Logger.Info(
+		fmt.Sprintf("<%s-%s> committed transaction <%s>",
+			utils.LoaderS, ldr.ldrID, tx.loadID))
+	cacheArgs, cacheIDs := tx.cacheArgs()
+	return ldr.updateCache(caching, cacheArgs, cacheIDs)
+}
+
+// Rollback restores the profiles changed by the transactional load with loadID to
+// their previous versions, only the last load can be rolled back
+func (ldr *Loader) Rollback(caching, loadID string) (err error) {
+	if err = ldr.lockFolder(); err != nil {
+		return
+	}
+	defer ldr.unlockFolder()
+	ldr.txMux.Lock()
+	defer ldr.txMux.Unlock()
+	if len(ldr.txs) == 0 {
+		return utils.ErrNotFound
+	}
+	tx := ldr.txs[len(ldr.txs)-1]
+	if loadID != utils.EmptyString && tx.loadID != loadID {
+		for _, oldTx := range ldr.txs {
+			if oldTx.loadID == loadID {
+				return fmt.Errorf("NOT_LAST_LOAD: %s", tx.loadID)
+			}
+		}
+		return utils.ErrNotFound
+	}
+	if err = ldr.restoreProfiles(tx, tx.prev); err != nil {
+		return
+	}
+	ldr.txs = ldr.txs[:len(ldr.txs)-1]
+	utils.Logger.Info(
+		fmt.Sprintf("<%s-%s> rolled back transaction <%s>",
+			utils.LoaderS, ldr.ldrID, tx.loadID))
+	cacheArgs, cacheIDs := tx.cacheArgs()
+	return ldr.updateCache(caching, cacheArgs, cacheIDs)
+}
+
+// restoreProfiles will bring the profiles in tx to their previous versions, in reverse order
+func (ldr *Loader) restoreProfiles(tx *loaderTransaction, prev map[string]map[string]txProfile) (err error) {
+	for i := len(txLoaderTypes) - 1; i >= 0; i-- {
+		loaderType := txLoaderTypes[i]
+		for tntID := range tx.profiles[loaderType] {
+			if prevPrf := prev[loaderType][tntID]; prevPrf != nil {
+				err = ldr.setProfile(loaderType, prevPrf, false)
+			} else {
+				err = ldr.removeProfile(loaderType, utils.NewTenantID(tntID))
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// getProfile returns the profile stored in DataDB or nil if not found
+func (ldr *Loader) getProfile(loaderType, tnt, id string) (prf txProfile, err error) {
+	switch loaderType {
+	case utils.MetaAttributes:
+		prf, err = ldr.dm.GetAttributeProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaResources:
+		prf, err = ldr.dm.GetResourceProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaFilters:
+		prf, err = ldr.dm.GetFilter(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaStats:
+		prf, err = ldr.dm.GetStatQueueProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaThresholds:
+		prf, err = ldr.dm.GetThresholdProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaRoutes:
+		prf, err = ldr.dm.GetRouteProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaChargers:
+		prf, err = ldr.dm.GetChargerProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaDispatchers:
+		prf, err = ldr.dm.GetDispatcherProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaDispatcherHosts:
+		prf, err = ldr.dm.GetDispatcherHost(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaRateProfiles:
+		prf, err = ldr.dm.GetRateProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaActionProfiles:
+		prf, err = ldr.dm.GetActionProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaAccountProfiles:
+		prf, err = ldr.dm.GetAccountProfile(tnt, id)
+	default:
+		return nil, fmt.Errorf("unsupported loader type: <%s>", loaderType)
+	}
+	if err != nil {
+		prf = nil // avoid returning typed nils
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+	}
+	return
+}
+
+// setProfile stores the profile in DataDB together with the objects created on load
+func (ldr *Loader) setProfile(loaderType string, prf txProfile, partialRates bool) (err error) {
+	switch p := prf.(type) {
+	case *engine.AttributeProfile:
+		return ldr.dm.SetAttributeProfile(p, true)
+	case *engine.ResourceProfile:
+		if err = ldr.dm.SetResourceProfile(p, true); err != nil {
+			return
+		}
+		var ttl *time.Duration
+		if p.UsageTTL > 0 {
+			ttl = &p.UsageTTL
+		}
+		return ldr.dm.SetResource(
+			&engine.Resource{
+				Tenant: p.Tenant,
+				ID:     p.ID,
+				Usages: make(map[string]*engine.ResourceUsage),
+			}, ttl, p.Limit, !p.Stored)
+	case *engine.Filter:
+		return ldr.dm.SetFilter(p, true)
+	case *engine.StatQueueProfile:
+		if err = ldr.dm.SetStatQueueProfile(p, true); err != nil {
+			return
+		}
+		var sq *engine.StatQueue
+		if sq, err = engine.NewStatQueue(p.Tenant, p.ID, p.Metrics,
+			p.MinItems); err != nil {
+			return
+		}
+		var ttl *time.Duration
+		if p.TTL > 0 {
+			ttl = &p.TTL
+		}
+		return ldr.dm.SetStatQueue(sq, p.Metrics,
+			p.MinItems, ttl, p.QueueLength, !p.Stored)
+	case *engine.ThresholdProfile:
+		if err = ldr.dm.SetThresholdProfile(p, true); err != nil {
+			return
+		}
+		return ldr.dm.SetThreshold(&engine.Threshold{Tenant: p.Tenant, ID: p.ID}, p.MinSleep, false)
+	case *engine.RouteProfile:
+		return ldr.dm.SetRouteProfile(p, true)
+	case *engine.ChargerProfile:
+		return ldr.dm.SetChargerProfile(p, true)
+	case *engine.DispatcherProfile:
+		return ldr.dm.SetDispatcherProfile(p, true)
+	case *engine.DispatcherHost:
+		return ldr.dm.SetDispatcherHost(p)
+	case *engine.RateProfile:
+		if partialRates {
+			return ldr.dm.SetRateProfileRates(p, true)
+		}
+		return ldr.dm.SetRateProfile(p, true)
+	case *engine.ActionProfile:
+		return ldr.dm.SetActionProfile(p, true)
+	case *utils.AccountProfile:
+		return ldr.dm.SetProvisionedAccountProfile(p, true)
+	}
+	return fmt.Errorf("unsupported loader type: <%s>", loaderType)
+}
+
+// removeProfile removes the profile from DataDB together with the objects created on load
+func (ldr *Loader) removeProfile(loaderType string, tntID *utils.TenantID) (err error) {
+	switch loaderType {
+	case utils.MetaAttributes:
+		return ldr.dm.RemoveAttributeProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true)
+	case utils.MetaResources:
+		if err = ldr.dm.RemoveResourceProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true); err != nil {
+			return
+		}
+		return ldr.dm.RemoveResource(tntID.Tenant, tntID.ID, utils.NonTransactional)
+	case utils.MetaFilters:
+		return ldr.dm.RemoveFilter(tntID.Tenant, tntID.ID, utils.NonTransactional, true)
+	case utils.MetaStats:
+		if err = ldr.dm.RemoveStatQueueProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true); err != nil {
+			return
+		}
+		return ldr.dm.RemoveStatQueue(tntID.Tenant, tntID.ID, utils.NonTransactional)
+	case utils.MetaThresholds:
+		if err = ldr.dm.RemoveThresholdProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true); err != nil {
+			return
+		}
+		return ldr.dm.RemoveThreshold(tntID.Tenant, tntID.ID, utils.NonTransactional)
+	case utils.MetaRoutes:
+		return ldr.dm.RemoveRouteProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true)
+	case utils.MetaChargers:
+		return ldr.dm.RemoveChargerProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true)
+	case utils.MetaDispatchers:
+		return ldr.dm.RemoveDispatcherProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true)
+	case utils.MetaDispatcherHosts:
+		return ldr.dm.RemoveDispatcherHost(tntID.Tenant, tntID.ID, utils.NonTransactional)
+	case utils.MetaRateProfiles:
+		return ldr.dm.RemoveRateProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true)
+	case utils.MetaActionProfiles:
+		return ldr.dm.RemoveActionProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true)
+	case utils.MetaAccountProfiles:
+		return ldr.dm.RemoveAccountProfile(tntID.Tenant, tntID.ID, utils.NonTransactional, true)
+	}
+	return fmt.Errorf("unsupported loader type: <%s>", loaderType)
+}
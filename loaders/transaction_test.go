@@ -0,0 +1,248 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newTestTxLoader(t *testing.T) (ldr *Loader, tpInDir string) {
+	var err error
+	if tpInDir, err = ioutil.TempDir("", "TestLoaderTransactional"); err != nil {
+		t.Fatal(err)
+	}
+	fldsFromIdx := func(paths ...string) (flds []*config.FCTemplate) {
+		for i, fldPath := range paths {
+			flds = append(flds, &config.FCTemplate{Tag: fldPath, Path: fldPath, Type: utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile("~*req."+utils.IfaceAsString(i), utils.InfieldSep)})
+		}
+		return
+	}
+	ldrCfg := &config.LoaderSCfg{
+		ID:             "TestLoaderTransactional",
+		Tenant:         config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep),
+		LockFileName:   ".cgr.lck",
+		FieldSeparator: utils.FieldsSep,
+		TpInDir:        tpInDir,
+		Type:           utils.MetaFileCSV,
+		Transactional:  true,
+		Data: []*config.LoaderDataType{
+			{
+				Type:     utils.MetaFilters,
+				Filename: utils.FiltersCsv,
+				Fields:   fldsFromIdx("Tenant", "ID", "Type", "Element", "Values"),
+			},
+			{
+				Type:     utils.MetaAttributes,
+				Filename: utils.AttributesCsv,
+				Fields: fldsFromIdx("Tenant", "ID", "Contexts", "FilterIDs", "ActivationInterval",
+					"AttributeFilterIDs", "Path", "Type", "Value", "Blocker", "Weight"),
+			},
+		},
+	}
+	ldr = NewLoader(engine.NewDataManager(engine.NewInternalDB(nil, nil, true),
+		config.CgrConfig().CacheCfg(), nil), ldrCfg, "UTC", nil, nil, nil)
+	return
+}
+
+func writeTestTxFiles(t *testing.T, tpInDir, fltrs, attrs string) {
+	if err := ioutil.WriteFile(path.Join(tpInDir, utils.FiltersCsv), []byte(fltrs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(tpInDir, utils.AttributesCsv), []byte(attrs), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoaderProcessFolderTransactional(t *testing.T) {
+	ldr, tpInDir := newTestTxLoader(t)
+	defer os.RemoveAll(tpInDir)
+	writeTestTxFiles(t, tpInDir,
+		"tx.cgrates.org,FLTR_1,*string,~*req.Account,1001\n",
+		"tx.cgrates.org,ATTR_1,*any,FLTR_1,,,*req.Subject,*constant,1002,false,10\n")
+	if err := ldr.ProcessFolderTransactional(utils.MetaNone, "LOAD_1"); err != nil {
+		t.Fatal(err)
+	}
+	if ap, err := ldr.dm.GetAttributeProfile("tx.cgrates.org", "ATTR_1", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if ap.Weight != 10 || len(ap.Attributes) != 1 {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(ap))
+	}
+
+	// second load updates the attribute
+	writeTestTxFiles(t, tpInDir, "",
+		"tx.cgrates.org,ATTR_1,*any,FLTR_1,,,*req.Subject,*constant,1003,false,20\n")
+	if err := ldr.ProcessFolderTransactional(utils.MetaNone, "LOAD_2"); err != nil {
+		t.Fatal(err)
+	}
+	if ap, err := ldr.dm.GetAttributeProfile("tx.cgrates.org", "ATTR_1", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if ap.Weight != 20 {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(ap))
+	}
+
+	expected := "NOT_LAST_LOAD: LOAD_2"
+	if err := ldr.Rollback(utils.MetaNone, "LOAD_1"); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+	if err := ldr.Rollback(utils.MetaNone, "LOAD_3"); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	if err := ldr.Rollback(utils.MetaNone, "LOAD_2"); err != nil {
+		t.Fatal(err)
+	}
+	if ap, err := ldr.dm.GetAttributeProfile("tx.cgrates.org", "ATTR_1", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if ap.Weight != 10 {
+		t.Errorf("Expected the previous profile, received: %s", utils.ToJSON(ap))
+	}
+
+	// rollback of the first load removes the new profiles
+	if err := ldr.Rollback(utils.MetaNone, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ldr.dm.GetAttributeProfile("tx.cgrates.org", "ATTR_1", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	if _, err := ldr.dm.GetFilter("tx.cgrates.org", "FLTR_1", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	if err := ldr.Rollback(utils.MetaNone, utils.EmptyString); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
+func TestLoaderProcessFolderTransactionalBrokenReference(t *testing.T) {
+	ldr, tpInDir := newTestTxLoader(t)
+	defer os.RemoveAll(tpInDir)
+	writeTestTxFiles(t, tpInDir,
+		"tx.cgrates.org,FLTR_1,*string,~*req.Account,1001\n",
+		"tx.cgrates.org,ATTR_1,*any,FLTR_1,,,*req.Subject,*constant,1002,false,10\n"+
+			"tx.cgrates.org,ATTR_2,*any,FLTR_MISSING,,,*req.Subject,*constant,1002,false,10\n")
+	expected := "broken references: *attributes <tx.cgrates.org:ATTR_2> references missing *filters <FLTR_MISSING>"
+	if err := ldr.ProcessFolderTransactional(utils.MetaNone, utils.EmptyString); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+	if _, err := ldr.dm.GetFilter("tx.cgrates.org", "FLTR_1", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected nothing stored, received %+v", err)
+	}
+	if _, err := ldr.dm.GetAttributeProfile("tx.cgrates.org", "ATTR_1", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected nothing stored, received %+v", err)
+	}
+	if len(ldr.txs) != 0 {
+		t.Errorf("Expected no transaction kept, received %+v", ldr.txs)
+	}
+}
+
+func TestLoaderProcessFolderTransactionalBadLine(t *testing.T) {
+	ldr, tpInDir := newTestTxLoader(t)
+	defer os.RemoveAll(tpInDir)
+	writeTestTxFiles(t, tpInDir,
+		"tx.cgrates.org,FLTR_1,*string,~*req.Account,1001\ntx.cgrates.org,FLTR_2,*string\n",
+		"")
+	if err := ldr.ProcessFolderTransactional(utils.MetaNone, utils.EmptyString); err == nil ||
+		!strings.Contains(err.Error(), "line: 2") {
+		t.Errorf("Expected error on line 2, received %+v", err)
+	}
+	if _, err := ldr.dm.GetFilter("tx.cgrates.org", "FLTR_1", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected nothing stored, received %+v", err)
+	}
+	if len(ldr.bufLoaderData) != 0 {
+		t.Errorf("Expected empty buffer, received %+v", ldr.bufLoaderData)
+	}
+}
+
+func TestLoaderValidateTransactionReferences(t *testing.T) {
+	ldr, tpInDir := newTestTxLoader(t)
+	defer os.RemoveAll(tpInDir)
+	if err := ldr.dm.SetActions("ACT_1", engine.Actions{{Id: "ACT_1"}}, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	tx := newLoaderTransaction(utils.EmptyString, false)
+	tx.stage(utils.MetaResources, &engine.ResourceProfile{Tenant: "cgrates.org", ID: "RES_1"})
+	tx.stage(utils.MetaStats, &engine.StatQueueProfile{Tenant: "cgrates.org", ID: "STAT_1"})
+	tx.stage(utils.MetaDispatcherHosts, &engine.DispatcherHost{Tenant: "cgrates.org",
+		RemoteHost: &config.RemoteHost{ID: "HOST_1"}})
+	tx.stage(utils.MetaRoutes, &engine.RouteProfile{Tenant: "cgrates.org", ID: "ROUTE_1",
+		Routes: []*engine.Route{{ID: "RT_1", ResourceIDs: []string{"RES_1"}, StatIDs: []string{"STAT_1:*acd"}}}})
+	tx.stage(utils.MetaDispatchers, &engine.DispatcherProfile{Tenant: "cgrates.org", ID: "DSP_1",
+		Hosts: engine.DispatcherHostProfiles{{ID: "HOST_1"}}})
+	tx.stage(utils.MetaThresholds, &engine.ThresholdProfile{Tenant: "cgrates.org", ID: "TH_1",
+		ActionIDs: []string{"ACT_1"}})
+	if err := ldr.validateTransaction(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	tx.stage(utils.MetaRoutes, &engine.RouteProfile{Tenant: "cgrates.org", ID: "ROUTE_1",
+		Routes: []*engine.Route{{ID: "RT_1", ResourceIDs: []string{"RES_2"}, StatIDs: []string{"STAT_2:*acd"}}}})
+	tx.stage(utils.MetaDispatchers, &engine.DispatcherProfile{Tenant: "cgrates.org", ID: "DSP_1",
+		Hosts: engine.DispatcherHostProfiles{{ID: "HOST_2"}}})
+	tx.stage(utils.MetaThresholds, &engine.ThresholdProfile{Tenant: "cgrates.org", ID: "TH_1",
+		ActionIDs: []string{"ACT_2"}, ActionProfileIDs: []string{"ACTPRF_1"}})
+	expected := "broken references: " +
+		"*thresholds <cgrates.org:TH_1> references missing *action_profiles <ACTPRF_1>; " +
+		"*thresholds <cgrates.org:TH_1> references missing *actions <ACT_2>; " +
+		"*routes <cgrates.org:ROUTE_1> references missing *resources <RES_2>; " +
+		"*routes <cgrates.org:ROUTE_1> references missing *stats <STAT_2>; " +
+		"*dispatchers <cgrates.org:DSP_1> references missing *dispatcher_hosts <HOST_2>"
+	if err := ldr.validateTransaction(tx); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+}
+
+func TestLoaderServiceV1LoadTransactionalLoadID(t *testing.T) {
+	ldr, tpInDir := newTestTxLoader(t)
+	defer os.RemoveAll(tpInDir)
+	ldrS := &LoaderService{ldrs: map[string]*Loader{ldr.ldrID: ldr}}
+	writeTestTxFiles(t, tpInDir,
+		"tx.cgrates.org,FLTR_1,*string,~*req.Account,1001\n",
+		"tx.cgrates.org,ATTR_1,*any,FLTR_1,,,*req.Subject,*constant,1002,false,10\n")
+	var loadID string
+	if err := ldrS.V1Load(&ArgsProcessFolder{LoaderID: ldr.ldrID}, &loadID); err != nil {
+		t.Fatal(err)
+	} else if loadID == utils.EmptyString || loadID == utils.OK {
+		t.Fatalf("Expected the generated LoadID, received: %q", loadID)
+	}
+	var reply string
+	if err := ldrS.V1Rollback(&ArgsRollback{LoaderID: ldr.ldrID, LoadID: loadID}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %q, received %q", utils.OK, reply)
+	}
+	if _, err := ldr.dm.GetAttributeProfile("tx.cgrates.org", "ATTR_1", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+
+	writeTestTxFiles(t, tpInDir,
+		"tx.cgrates.org,FLTR_1,*string,~*req.Account,1001\n",
+		"tx.cgrates.org,ATTR_1,*any,FLTR_1,,,*req.Subject,*constant,1002,false,10\n")
+	if err := ldrS.V1Load(&ArgsProcessFolder{LoaderID: ldr.ldrID, LoadID: "LOAD_1"}, &loadID); err != nil {
+		t.Fatal(err)
+	} else if loadID != "LOAD_1" {
+		t.Errorf("Expected %q, received %q", "LOAD_1", loadID)
+	}
+}
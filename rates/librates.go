@@ -21,6 +21,8 @@ package rates
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ericlagergren/decimal"
@@ -275,3 +277,101 @@ func computeRateSIntervals(rts []*orderedRate, intervalStart, usage time.Duratio
 	}
 	return
 }
+
+// applyVolumeTiers splits the increments on the volume tiers they fall into, vol being the volume consumed before the event
+// an increment belongs to the tier active at its start and the FixedFee increments are not influenced by the tiers
+func applyVolumeTiers(rtIvls []*engine.RateSInterval, vTiers []*engine.VolumeTier, vol time.Duration) (err error) {
+	if len(vTiers) == 0 {
+		return
+	}
+	if vTiers[0].VolumeStart > vol {
+		return fmt.Errorf("volumeStart for volume tiers: <%s> higher than volume: %v",
+			vTiers[0].VolumeStart, vol)
+	}
+	for _, rtIvl := range rtIvls {
+		rIcmts := make([]*engine.RateSIncrement, 0, len(rtIvl.Increments))
+		for _, rIcmt := range rtIvl.Increments {
+			if rIcmt.Usage == utils.InvalidDuration { // FixedFee
+				rIcmts = append(rIcmts, rIcmt)
+				continue
+			}
+			intIncrm, ok := rIcmt.Rate.IntervalRates[rIcmt.IntervalRateIndex].Increment.Int64()
+			if !ok {
+				return fmt.Errorf("<%s> cannot convert <%+v> increment to Int64", utils.RateS,
+					rIcmt.Rate.IntervalRates[rIcmt.IntervalRateIndex].Increment)
+			}
+			incrm := time.Duration(intIncrm)
+			for {
+				vTierIdx := 0
+				for i, vTier := range vTiers {
+					if vTier.VolumeStart > vol+rIcmt.UsageStart {
+						break
+					}
+					vTierIdx = i
+				}
+				rIcmt.VolumeTierIndex = vTierIdx
+				rIcmt.VolumeFactor = vTiers[vTierIdx].Factor
+				if rIcmt.VolumeFactor == nil {
+					rIcmt.VolumeFactor = utils.NewDecimal(1, 0)
+				}
+				rIcmts = append(rIcmts, rIcmt)
+				if vTierIdx == len(vTiers)-1 {
+					break
+				}
+				// increments starting before the next tier stay within the current one
+				nxtStart := vTiers[vTierIdx+1].VolumeStart - vol - rIcmt.UsageStart
+				cmpFactor := int64(nxtStart / incrm)
+				if nxtStart%incrm != 0 {
+					cmpFactor++
+				}
+				if cmpFactor >= rIcmt.CompressFactor {
+					break
+				}
+				nxtIcmt := &engine.RateSIncrement{
+					UsageStart:        rIcmt.UsageStart + time.Duration(cmpFactor)*incrm,
+					Rate:              rIcmt.Rate,
+					IntervalRateIndex: rIcmt.IntervalRateIndex,
+					CompressFactor:    rIcmt.CompressFactor - cmpFactor,
+				}
+				nxtIcmt.Usage = rIcmt.Usage - (nxtIcmt.UsageStart - rIcmt.UsageStart)
+				rIcmt.Usage -= nxtIcmt.Usage
+				rIcmt.CompressFactor = cmpFactor
+				rIcmt = nxtIcmt
+			}
+		}
+		rtIvl.Increments = rIcmts
+	}
+	return
+}
+
+// orderedRatesKey builds the key used to reuse the ordered rates of a day between events
+func orderedRatesKey(rtPfl *engine.RateProfile, aRts []*engine.Rate, wghts []float64, dayStart time.Time) string {
+	rtKeys := make([]string, len(aRts))
+	for i, rt := range aRts {
+		rtKeys[i] = fmt.Sprintf("%s:%v", rt.ID, wghts[i])
+	}
+	sort.Strings(rtKeys)
+	return utils.ConcatenatedKey(rtPfl.TenantID(), strings.Join(rtKeys, utils.InfieldSep),
+		strconv.FormatInt(dayStart.UnixNano(), 10))
+}
+
+// sliceOrderedRates returns the ordered rates for the usage starting at sOffset out of the ones ordered from offset 0
+func sliceOrderedRates(ordRts []*orderedRate, sOffset, usage time.Duration) (evRts []*orderedRate) {
+	if usage == 0 { // no rate is active on an empty interval
+		return
+	}
+	for i, ordRt := range ordRts {
+		if ordRt.Duration >= sOffset+usage {
+			break
+		}
+		if i != len(ordRts)-1 && ordRts[i+1].Duration <= sOffset {
+			continue // replaced by the next rate before the usage starts
+		}
+		var usageIndx time.Duration
+		if ordRt.Duration > sOffset {
+			usageIndx = ordRt.Duration - sOffset
+		}
+		evRts = append(evRts, &orderedRate{usageIndx, ordRt.Rate})
+	}
+	return
+}
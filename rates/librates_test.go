@@ -5041,3 +5041,98 @@ func TestComputeRateSIntervalsPauseBetweenRates(t *testing.T) {
 		t.Errorf("Expected %+v, \nreceived %+v", utils.ToJSON(expOrdRts), utils.ToJSON(rcvOrdRts))
 	}
 }
+
+func TestApplyVolumeTiersMidIncrement(t *testing.T) {
+	rt := &engine.Rate{
+		ID: "RATE1",
+		IntervalRates: []*engine.IntervalRate{
+			{
+				RecurrentFee: utils.NewDecimal(1, 0),
+				Unit:         utils.NewDecimal(int64(time.Minute), 0),
+				Increment:    utils.NewDecimal(int64(time.Minute), 0),
+			},
+		},
+	}
+	rtIvls := []*engine.RateSInterval{{
+		Increments: []*engine.RateSIncrement{{
+			Rate:           rt,
+			CompressFactor: 5,
+			Usage:          5 * time.Minute,
+		}},
+		CompressFactor: 1,
+	}}
+	vTiers := []*engine.VolumeTier{
+		{VolumeStart: 0},
+		{VolumeStart: 90 * time.Second, Factor: utils.NewDecimal(5, 1)},
+	}
+	// the increment started before the next tier is charged with the previous one
+	exp := []*engine.RateSIncrement{
+		{
+			Rate:            rt,
+			VolumeTierIndex: 0,
+			VolumeFactor:    utils.NewDecimal(1, 0),
+			CompressFactor:  2,
+			Usage:           2 * time.Minute,
+		},
+		{
+			UsageStart:      2 * time.Minute,
+			Rate:            rt,
+			VolumeTierIndex: 1,
+			VolumeFactor:    utils.NewDecimal(5, 1),
+			CompressFactor:  3,
+			Usage:           3 * time.Minute,
+		},
+	}
+	if err := applyVolumeTiers(rtIvls, vTiers, 0); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rtIvls[0].Increments) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rtIvls[0].Increments))
+	}
+	if cost, _ := engine.CostForIntervals(rtIvls).Float64(); cost != 3.5 {
+		t.Errorf("Expected cost 3.5, received %v", cost)
+	}
+}
+
+func TestSliceOrderedRates(t *testing.T) {
+	rtDay := &engine.Rate{
+		ID:              "RT_DAY",
+		ActivationTimes: "* 8-19 * * *",
+	}
+	rtAlways := &engine.Rate{
+		ID:              "RT_ALWAYS",
+		ActivationTimes: "* * * * *",
+	}
+	aRts := []*engine.Rate{rtDay, rtAlways}
+	for _, rt := range aRts {
+		if err := rt.Compile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wghts := []float64{10, 0}
+	dayStart := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	dayRts, err := orderRatesOnIntervals(aRts, wghts, dayStart, 24*time.Hour, true, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the rates sliced out of the day are the same as the ones ordered for the event
+	for _, ev := range []struct {
+		sTime time.Time
+		usage time.Duration
+	}{
+		{dayStart.Add(7 * time.Hour), 30 * time.Minute},
+		{dayStart.Add(7*time.Hour + 50*time.Minute), 20 * time.Minute},
+		{dayStart.Add(8 * time.Hour), time.Hour},
+		{dayStart.Add(19*time.Hour + 50*time.Minute), 20 * time.Minute},
+		{dayStart.Add(7 * time.Hour), 14 * time.Hour},
+		{dayStart.Add(12 * time.Hour), 0},
+	} {
+		expOrdRts, err := orderRatesOnIntervals(aRts, wghts, ev.sTime, ev.usage, true, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rcv := sliceOrderedRates(dayRts, ev.sTime.Sub(dayStart), ev.usage); !reflect.DeepEqual(expOrdRts, rcv) {
+			t.Errorf("For %v with usage %v expected %s, received %s", ev.sTime, ev.usage,
+				utils.ToJSON(expOrdRts), utils.ToJSON(rcv))
+		}
+	}
+}
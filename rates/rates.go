@@ -20,8 +20,11 @@ package rates
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ericlagergren/decimal"
+
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
@@ -128,8 +131,37 @@ func (rS *RateS) matchingRateProfileForEvent(tnt string, rPfIDs []string, args *
 	return rpWw.RateProfile, nil
 }
 
+// volumeUsage returns the usage consumed within the billing period, before the event
+func (rS *RateS) volumeUsage(rtPfl *engine.RateProfile, tnt string, evNm utils.MapStorage) (vol time.Duration, err error) {
+	if len(rtPfl.VolumeTiers) == 0 ||
+		rtPfl.VolumeUsage == utils.EmptyString {
+		return
+	}
+	var rsrs config.RSRParsers
+	if rsrs, err = config.NewRSRParsers(rtPfl.VolumeUsage, rS.cfg.GeneralCfg().RSRSep); err != nil {
+		return
+	}
+	var out string
+	if out, err = rsrs.ParseDataProvider(engine.NewDynamicDP(rS.cfg, tnt, evNm)); err != nil {
+		return
+	}
+	if vol, err = utils.IfaceAsDuration(out); err != nil {
+		return
+	}
+	if vol < 0 {
+		return 0, fmt.Errorf("negative volume usage: <%s> for rate profile: <%s>", vol, rtPfl.TenantID())
+	}
+	return
+}
+
 // rateProfileCostForEvent computes the rateProfileCost for an event based on a preselected rate profile
 func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.ArgsCostForEvent, verbosity int) (rpCost *engine.RateProfileCost, err error) {
+	return rS.rateProfileCost(rtPfl, args, verbosity, nil)
+}
+
+// rateProfileCost computes the rateProfileCost reusing the ordered rates of the batch if not nil
+func (rS *RateS) rateProfileCost(rtPfl *engine.RateProfile, args *utils.ArgsCostForEvent, verbosity int,
+	batch *rateBatch) (rpCost *engine.RateProfileCost, err error) {
 	evNm := utils.MapStorage{
 		utils.MetaReq:  args.CGREvent.Event,
 		utils.MetaOpts: args.Opts,
@@ -175,12 +207,22 @@ func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.
 	if usage, err = args.Usage(); err != nil {
 		return
 	}
+	var vol time.Duration
+	if vol, err = rS.volumeUsage(rtPfl, args.CGREvent.Tenant, evNm); err != nil {
+		return
+	}
 	var ordRts []*orderedRate
-	if ordRts, err = orderRatesOnIntervals(aRates, wghts, sTime, usage, true, verbosity); err != nil {
+	if batch != nil {
+		ordRts, err = batch.orderedRates(rtPfl, aRates, wghts, sTime, usage, verbosity)
+	} else {
+		ordRts, err = orderRatesOnIntervals(aRates, wghts, sTime, usage, true, verbosity)
+	}
+	if err != nil {
 		return
 	}
 	rpCost = &engine.RateProfileCost{
-		ID: rtPfl.ID,
+		ID:          rtPfl.ID,
+		VolumeUsage: vol,
 	}
 	var ok bool
 	if rtPfl.MinCost != nil {
@@ -197,6 +239,9 @@ func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.
 	if rpCost.RateSIntervals, err = computeRateSIntervals(ordRts, 0, usage); err != nil {
 		return nil, err
 	}
+	if err = applyVolumeTiers(rpCost.RateSIntervals, rtPfl.VolumeTiers, vol); err != nil {
+		return nil, err
+	}
 	// in case we have error it is returned in the function from above
 	// this came to light in coverage tests
 	rpCost.Cost, _ = engine.CostForIntervals(rpCost.RateSIntervals).Float64()
@@ -227,3 +272,239 @@ func (rS *RateS) V1CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.Rat
 	*rpCost = *rcvCost
 	return
 }
+
+// V1CostForEvents rates a batch of events, reusing the ordered rates between them
+func (rS *RateS) V1CostForEvents(args *utils.ArgsCostForEvents, rpCosts *engine.RateProfileCosts) (err error) {
+	if len(args.CGREvents) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventsField)
+	}
+	batch := newRateBatch()
+	rcv := &engine.RateProfileCosts{
+		Costs: make([]*engine.RateProfileCost, len(args.CGREvents)),
+	}
+	cost := new(decimal.Big)
+	for i, evArgs := range args.AsArgsCostForEvent(rS.cfg.GeneralCfg().DefaultTenant) {
+		var rpCost *engine.RateProfileCost
+		var usage time.Duration
+		if rpCost, usage, err = rS.eventCost(evArgs, nil, batch); err != nil {
+			if rcv.Errors == nil {
+				rcv.Errors = make(map[int]string)
+			}
+			rcv.Errors[i] = err.Error()
+			err = nil
+			continue
+		}
+		rcv.Costs[i] = rpCost
+		rcv.Usage += usage
+		cost = utils.SumBig(cost, utils.NewDecimalFromFloat64(rpCost.Cost).Big)
+	}
+	rcv.Cost, _ = cost.Float64()
+	*rpCosts = *rcv
+	return
+}
+
+// V1CompareRateProfiles rates the same events with each of the RateProfiles, returning the totals
+// the candidate profiles are applied without checking their filters
+func (rS *RateS) V1CompareRateProfiles(args *utils.ArgsCostForEvents, rpTotals *[]*engine.RateProfileTotal) (err error) {
+	if len(args.RateProfileIDs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.RateProfileIDs)
+	}
+	if len(args.CGREvents) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventsField)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	evsArgs := args.AsArgsCostForEvent(tnt)
+	batch := newRateBatch()
+	totals := make([]*engine.RateProfileTotal, len(args.RateProfileIDs))
+	for i, rpID := range args.RateProfileIDs {
+		var rtPfl *engine.RateProfile
+		if rtPfl, err = rS.dm.GetRateProfile(tnt, rpID,
+			true, true, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				return utils.ErrPrefixNotFound(rpID)
+			}
+			return utils.NewErrServerError(err)
+		}
+		total := &engine.RateProfileTotal{RateProfileID: rpID}
+		cost := new(decimal.Big)
+		for j, evArgs := range evsArgs {
+			var rpCost *engine.RateProfileCost
+			var usage time.Duration
+			if rpCost, usage, err = rS.eventCost(evArgs, rtPfl, batch); err != nil {
+				if total.Errors == nil {
+					total.Errors = make(map[int]string)
+				}
+				total.Errors[j] = err.Error()
+				err = nil
+				continue
+			}
+			total.Events++
+			total.Usage += usage
+			cost = utils.SumBig(cost, utils.NewDecimalFromFloat64(rpCost.Cost).Big)
+		}
+		total.Cost, _ = cost.Float64()
+		totals[i] = total
+	}
+	*rpTotals = totals
+	return
+}
+
+// eventCost rates one event out of a batch with the given RateProfile or with the matching one if nil
+func (rS *RateS) eventCost(args *utils.ArgsCostForEvent, rtPfl *engine.RateProfile,
+	batch *rateBatch) (rpCost *engine.RateProfileCost, usage time.Duration, err error) {
+	if usage, err = args.Usage(); err != nil {
+		return
+	}
+	if rtPfl == nil {
+		if rtPfl, err = rS.batchMatchingRateProfile(batch, args); err != nil {
+			return
+		}
+	}
+	rpCost, err = rS.rateProfileCost(rtPfl, args, rS.cfg.RateSCfg().Verbosity, batch)
+	return
+}
+
+// newRateBatch returns an empty rateBatch
+func newRateBatch() *rateBatch {
+	return &rateBatch{
+		matchFlds: make(map[string]config.RSRParsers),
+		matches:   make(map[string]*engine.RateProfile),
+		dayRts:    make(map[string][]*orderedRate),
+	}
+}
+
+// rateBatch holds the data reused between the events of a batch
+type rateBatch struct {
+	matchFlds map[string]config.RSRParsers   // the fields the profile filters depend on, nil if the match can not be reused
+	matches   map[string]*engine.RateProfile // matched profiles indexed on the values of the filtered fields
+	dayRts    map[string][]*orderedRate      // rates ordered over one day, nil if they could not be ordered
+}
+
+// orderedRates returns the ordered rates of the event out of the ones ordered for its day
+// the events crossing the end of the day are ordered on their own
+func (b *rateBatch) orderedRates(rtPfl *engine.RateProfile, aRts []*engine.Rate, wghts []float64,
+	sTime time.Time, usage time.Duration, verbosity int) (ordRts []*orderedRate, err error) {
+	dayStart := time.Date(sTime.Year(), sTime.Month(), sTime.Day(), 0, 0, 0, 0, sTime.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	if sTime.Add(usage).After(dayEnd) {
+		return orderRatesOnIntervals(aRts, wghts, sTime, usage, true, verbosity)
+	}
+	dayKey := orderedRatesKey(rtPfl, aRts, wghts, dayStart)
+	dayRts, has := b.dayRts[dayKey]
+	if !has {
+		if dayRts, err = orderRatesOnIntervals(aRts, wghts, dayStart, dayEnd.Sub(dayStart),
+			true, verbosity); err != nil {
+			dayRts, err = nil, nil // too many activations within the day
+		}
+		b.dayRts[dayKey] = dayRts
+	}
+	if dayRts == nil {
+		return orderRatesOnIntervals(aRts, wghts, sTime, usage, true, verbosity)
+	}
+	return sliceOrderedRates(dayRts, sTime.Sub(dayStart), usage), nil
+}
+
+// batchMatchingRateProfile returns the RateProfile matching the event
+// reusing the match of the previous events with the same values for the fields the filters depend on
+func (rS *RateS) batchMatchingRateProfile(batch *rateBatch, args *utils.ArgsCostForEvent) (rtPfl *engine.RateProfile, err error) {
+	fldsKey := utils.ConcatenatedKey(args.Tenant, strings.Join(args.RateProfileIDs, utils.InfieldSep))
+	flds, has := batch.matchFlds[fldsKey]
+	if !has {
+		if flds, err = rS.matchFields(args.Tenant, args.RateProfileIDs); err != nil {
+			return
+		}
+		batch.matchFlds[fldsKey] = flds
+	}
+	if flds == nil {
+		return rS.matchingRateProfileForEvent(args.Tenant, args.RateProfileIDs, args)
+	}
+	evNm := utils.MapStorage{
+		utils.MetaReq:  args.CGREvent.Event,
+		utils.MetaOpts: args.Opts,
+	}
+	vals := make([]string, len(flds)+1)
+	if args.CGREvent.Time != nil { // used by the ActivationInterval
+		vals[0] = args.CGREvent.Time.String()
+	}
+	for i, fld := range flds {
+		var errFld error
+		if vals[i+1], errFld = fld.ParseDataProvider(evNm); errFld != nil {
+			vals[i+1] = utils.ErrPrefix(errFld, fld.Rules).Error()
+		}
+	}
+	matchKey := utils.ConcatenatedKey(fldsKey, utils.ToJSON(vals))
+	if rtPfl, has = batch.matches[matchKey]; has {
+		if rtPfl == nil {
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	if rtPfl, err = rS.matchingRateProfileForEvent(args.Tenant, args.RateProfileIDs, args); err != nil &&
+		err != utils.ErrNotFound {
+		return
+	}
+	batch.matches[matchKey] = rtPfl
+	return
+}
+
+// matchFields returns the event fields the filters of the candidate profiles depend on
+// nil if the filters use data outside the event
+func (rS *RateS) matchFields(tnt string, rPfIDs []string) (flds config.RSRParsers, err error) {
+	if len(rPfIDs) == 0 { // all the profiles of the tenant are candidates
+		var keys []string
+		if keys, err = rS.dm.DataDB().GetKeysForPrefix(utils.RateProfilePrefix + tnt + utils.ConcatenatedKeySep); err != nil {
+			return
+		}
+		rPfIDs = make([]string, len(keys))
+		for i, key := range keys {
+			rPfIDs[i] = strings.TrimPrefix(key, utils.RateProfilePrefix+tnt+utils.ConcatenatedKeySep)
+		}
+	}
+	fldRules := make(utils.StringSet)
+	for _, rPfID := range rPfIDs {
+		var rPf *engine.RateProfile
+		if rPf, err = rS.dm.GetRateProfile(tnt, rPfID,
+			true, true, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+				continue
+			}
+			return
+		}
+		fltrIDs := append([]string{}, rPf.FilterIDs...)
+		for _, dWght := range rPf.Weights {
+			fltrIDs = append(fltrIDs, dWght.FilterIDs...)
+		}
+		for _, fltrID := range fltrIDs {
+			var fltr *engine.Filter
+			if fltr, err = rS.dm.GetFilter(tnt, fltrID,
+				true, true, utils.NonTransactional); err != nil {
+				if err == utils.ErrNotFound {
+					err = utils.ErrPrefixNotFound(fltrID)
+				}
+				return
+			}
+			for _, rule := range fltr.Rules {
+				for _, fldRule := range append([]string{rule.Element}, rule.Values...) {
+					if !strings.HasPrefix(fldRule, utils.DynamicDataPrefix) {
+						continue
+					}
+					if !strings.HasPrefix(fldRule, utils.DynamicDataPrefix+utils.MetaReq+utils.NestingSep) &&
+						!strings.HasPrefix(fldRule, utils.DynamicDataPrefix+utils.MetaOpts+utils.NestingSep) {
+						return // the match depends on other subsystems
+					}
+					fldRules.Add(fldRule)
+				}
+			}
+		}
+	}
+	flds = make(config.RSRParsers, 0, len(fldRules))
+	for _, fldRule := range fldRules.AsOrderedSlice() {
+		var fld *config.RSRParser
+		if fld, err = config.NewRSRParser(fldRule); err != nil {
+			return nil, err
+		}
+		flds = append(flds, fld)
+	}
+	return
+}
@@ -970,3 +970,289 @@ func TestRateProfileCostForEventMaximumIterations(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestRateProfileCostForEventVolumeTiers(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	rPrf := &engine.RateProfile{
+		Tenant:      "cgrates.org",
+		ID:          "RATE_VOLUME",
+		FilterIDs:   []string{"*string:~*req.Account:1001"},
+		VolumeUsage: "~*req.PeriodUsage",
+		VolumeTiers: []*engine.VolumeTier{
+			{
+				VolumeStart: 0,
+				Factor:      utils.NewDecimal(1, 0),
+			},
+			{
+				VolumeStart: 10 * time.Minute,
+				Factor:      utils.NewDecimal(5, 1),
+			},
+		},
+		Rates: map[string]*engine.Rate{
+			"RATE1": {
+				ID:              "RATE1",
+				ActivationTimes: "* * * * *",
+				IntervalRates: []*engine.IntervalRate{
+					{
+						IntervalStart: 0,
+						FixedFee:      utils.NewDecimal(5, 1),
+						RecurrentFee:  utils.NewDecimal(2, 1),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+				},
+			},
+		},
+	}
+	if err := rateS.dm.SetRateProfile(rPrf, true); err != nil {
+		t.Error(err)
+	}
+	args := &utils.ArgsCostForEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "VOLUME_EVENT",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				"PeriodUsage":      "8m",
+			},
+			Opts: map[string]interface{}{
+				utils.OptsRatesUsage: "5m",
+			},
+		},
+	}
+	rcv, err := rateS.rateProfileCostForEvent(rPrf, args, rateS.cfg.RateSCfg().Verbosity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv.Cost != 1.2 {
+		t.Errorf("Expected cost 1.2, received %+v", rcv.Cost)
+	}
+	if rcv.VolumeUsage != 8*time.Minute {
+		t.Errorf("Expected volume usage %v, received %v", 8*time.Minute, rcv.VolumeUsage)
+	}
+	if len(rcv.RateSIntervals) != 1 || len(rcv.RateSIntervals[0].Increments) != 3 {
+		t.Fatalf("Unexpected intervals: %s", utils.ToJSON(rcv.RateSIntervals))
+	}
+	for i, exp := range []struct {
+		usageStart time.Duration
+		usage      time.Duration
+		tier       int
+		cmpFactor  int64
+	}{
+		{0, utils.InvalidDuration, 0, 1}, // the FixedFee is charged once per event
+		{0, 2 * time.Minute, 0, 2},
+		{2 * time.Minute, 3 * time.Minute, 1, 3},
+	} {
+		incr := rcv.RateSIntervals[0].Increments[i]
+		if incr.UsageStart != exp.usageStart ||
+			incr.Usage != exp.usage ||
+			incr.IntervalRateIndex != 0 ||
+			incr.VolumeTierIndex != exp.tier ||
+			incr.CompressFactor != exp.cmpFactor {
+			t.Errorf("Unexpected increment %d: %s", i, utils.ToJSON(incr))
+		}
+	}
+
+	// with the volume passed the last tier the FixedFee is not charged again on the tier
+	args.CGREvent.Event["PeriodUsage"] = "20m"
+	if rcv, err = rateS.rateProfileCostForEvent(rPrf, args, rateS.cfg.RateSCfg().Verbosity); err != nil {
+		t.Fatal(err)
+	} else if rcv.Cost != 1 {
+		t.Errorf("Expected cost 1, received %+v", rcv.Cost)
+	}
+
+	args.CGREvent.Event["PeriodUsage"] = "-1m"
+	expected := "negative volume usage: <-1m0s> for rate profile: <cgrates.org:RATE_VOLUME>"
+	if _, err := rateS.rateProfileCostForEvent(rPrf, args,
+		rateS.cfg.RateSCfg().Verbosity); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+
+	args.CGREvent.Event["PeriodUsage"] = "0s"
+	rPrf.VolumeTiers[0].VolumeStart = time.Minute
+	expected = "volumeStart for volume tiers: <1m0s> higher than volume: 0s"
+	if _, err := rateS.rateProfileCostForEvent(rPrf, args,
+		rateS.cfg.RateSCfg().Verbosity); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+}
+
+func TestV1CostForEventsAndCompareRateProfiles(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	newRPrf := func(id string, fee int64) *engine.RateProfile {
+		return &engine.RateProfile{
+			Tenant:    "cgrates.org",
+			ID:        id,
+			FilterIDs: []string{"*string:~*req.Account:1001"},
+			Rates: map[string]*engine.Rate{
+				"RATE1": {
+					ID:              "RATE1",
+					ActivationTimes: "* * * * *",
+					IntervalRates: []*engine.IntervalRate{
+						{
+							IntervalStart: 0,
+							RecurrentFee:  utils.NewDecimal(fee, 1),
+							Unit:          minDecimal,
+							Increment:     minDecimal,
+						},
+					},
+				},
+			},
+		}
+	}
+	for _, rPrf := range []*engine.RateProfile{newRPrf("RP_CURRENT", 2), newRPrf("RP_CANDIDATE", 1)} {
+		if err := rateS.dm.SetRateProfile(rPrf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := &utils.ArgsCostForEvents{
+		RateProfileIDs: []string{"RP_CURRENT"},
+		CGREvents: []*utils.CGREvent{
+			{
+				ID: "EV1",
+				Event: map[string]interface{}{
+					utils.AccountField: "1001",
+					utils.Usage:        "2m",
+				},
+			},
+			{
+				ID: "EV2",
+				Event: map[string]interface{}{
+					utils.AccountField: "1001",
+					utils.Usage:        "3m",
+				},
+			},
+			{
+				ID: "EV3",
+				Event: map[string]interface{}{
+					utils.AccountField: "1002",
+				},
+			},
+		},
+	}
+	var rpCosts engine.RateProfileCosts
+	if err := rateS.V1CostForEvents(args, &rpCosts); err != nil {
+		t.Fatal(err)
+	}
+	if rpCosts.Cost != 1 || rpCosts.Usage != 5*time.Minute {
+		t.Errorf("Unexpected totals: %s", utils.ToJSON(rpCosts))
+	}
+	if len(rpCosts.Costs) != 3 || rpCosts.Costs[0].Cost != 0.4 ||
+		rpCosts.Costs[1].Cost != 0.6 || rpCosts.Costs[2] != nil {
+		t.Errorf("Unexpected costs: %s", utils.ToJSON(rpCosts.Costs))
+	}
+	expErrs := map[int]string{2: utils.ErrNotFound.Error()}
+	if !reflect.DeepEqual(expErrs, rpCosts.Errors) {
+		t.Errorf("Expected %+v, received %+v", expErrs, rpCosts.Errors)
+	}
+
+	args.RateProfileIDs = []string{"RP_CURRENT", "RP_CANDIDATE"}
+	args.CGREvents = args.CGREvents[:2]
+	var rpTotals []*engine.RateProfileTotal
+	if err := rateS.V1CompareRateProfiles(args, &rpTotals); err != nil {
+		t.Fatal(err)
+	}
+	expTotals := []*engine.RateProfileTotal{
+		{
+			RateProfileID: "RP_CURRENT",
+			Cost:          1,
+			Usage:         5 * time.Minute,
+			Events:        2,
+		},
+		{
+			RateProfileID: "RP_CANDIDATE",
+			Cost:          0.5,
+			Usage:         5 * time.Minute,
+			Events:        2,
+		},
+	}
+	if !reflect.DeepEqual(expTotals, rpTotals) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expTotals), utils.ToJSON(rpTotals))
+	}
+
+	args.RateProfileIDs = []string{"RP_MISSING"}
+	expected := "NOT_FOUND:RP_MISSING"
+	if err := rateS.V1CompareRateProfiles(args, &rpTotals); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+	args.CGREvents = nil
+	expected = "MANDATORY_IE_MISSING: [CGREvents]"
+	if err := rateS.V1CostForEvents(args, &rpCosts); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+}
+
+func TestRateSBatchMatchingRateProfile(t *testing.T) {
+	engine.Cache.Clear(nil)
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	rPrf := &engine.RateProfile{
+		Tenant:    "cgrates.org",
+		ID:        "RP_BATCH",
+		FilterIDs: []string{"*string:~*req.Account:1001"},
+		Rates:     map[string]*engine.Rate{},
+	}
+	if err := rateS.dm.SetRateProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	newArgs := func(acnt, usage string) *utils.ArgsCostForEvent {
+		return &utils.ArgsCostForEvent{
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "EV",
+				Event: map[string]interface{}{
+					utils.AccountField: acnt,
+					utils.Usage:        usage,
+				},
+			},
+		}
+	}
+	batch := newRateBatch()
+	for _, usage := range []string{"1m", "2m", "3m"} {
+		if rcv, err := rateS.batchMatchingRateProfile(batch, newArgs("1001", usage)); err != nil {
+			t.Error(err)
+		} else if rcv.ID != "RP_BATCH" {
+			t.Errorf("Expected RP_BATCH, received %s", utils.ToJSON(rcv))
+		}
+	}
+	if _, err := rateS.batchMatchingRateProfile(batch, newArgs("1002", "1m")); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	if _, err := rateS.batchMatchingRateProfile(batch, newArgs("1002", "2m")); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	// the usage is not filtered so the events of the same account share the match
+	if len(batch.matches) != 2 {
+		t.Errorf("Expected 2 matches, received %s", utils.ToJSON(batch.matches))
+	}
+
+	// the filters querying other subsystems can not be reused
+	rPrf.FilterIDs = []string{"*gte:~*stats.SQ1.*tcd:1m"}
+	if err := rateS.dm.SetRateProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	if flds, err := rateS.matchFields("cgrates.org", nil); err != nil {
+		t.Error(err)
+	} else if flds != nil {
+		t.Errorf("Expected no fields, received %s", utils.ToJSON(flds))
+	}
+}
@@ -59,6 +59,15 @@ type AnalyzerService struct {
 	rpc      *v1.AnalyzerSv1
 	connChan chan rpcclient.ClientConnector
 	srvDep   map[string]*sync.WaitGroup
+	connMgr  *engine.ConnManager
+}
+
+// SetConnManager sets the connManager used by the AnalyzerS to replay the API calls
+// needs to be called before the service is started
+func (anz *AnalyzerService) SetConnManager(cM *engine.ConnManager) {
+	anz.Lock()
+	anz.connMgr = cM
+	anz.Unlock()
 }
 
 // Start should handle the sercive start
@@ -73,6 +82,7 @@ func (anz *AnalyzerService) Start() (err error) {
 		utils.Logger.Crit(fmt.Sprintf("<%s> Could not init, error: %s", utils.AnalyzerS, err.Error()))
 		return
 	}
+	anz.anz.SetConnManager(anz.connMgr)
 	anz.stopChan = make(chan struct{})
 	go func(a *analyzers.AnalyzerService) {
 		if err := a.ListenAndServe(anz.stopChan); err != nil {
@@ -87,6 +87,7 @@ func (dspS *DispatcherService) Start() (err error) {
 	defer dspS.Unlock()
 
 	dspS.dspS = dispatchers.NewDispatcherService(datadb, dspS.cfg, fltrS, dspS.connMgr)
+	dspS.dspS.StartLoop()
 
 	// for the moment we dispable Apier through dispatcher
 	// until we figured out a better sollution in case of gob server
@@ -59,7 +59,7 @@ func TestDispatcherSCoverage(t *testing.T) {
 		anz:         anz,
 		srvDep:      srvDep,
 	}
-	srv2.dspS = &dispatchers.DispatcherService{}
+	srv2.dspS = dispatchers.NewDispatcherService(nil, cfg, nil, nil)
 	if !srv2.IsRunning() {
 		t.Errorf("Expected service to be running")
 	}
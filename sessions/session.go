@@ -132,6 +132,66 @@ func (s *Session) Clone() (cln *Session) {
 	return
 }
 
+// asStoredSession returns the session in the format backed up into DataDB
+// not thread safe
+func (s *Session) asStoredSession() (ss *engine.StoredSession) {
+	ss = &engine.StoredSession{
+		CGRID:         s.CGRID,
+		Tenant:        s.Tenant,
+		ResourceID:    s.ResourceID,
+		ClientConnID:  s.ClientConnID,
+		EventStart:    s.EventStart.Clone(),
+		DebitInterval: s.DebitInterval,
+		Chargeable:    s.chargeable,
+		SRuns:         make([]*engine.StoredSRun, len(s.SRuns)),
+		OptsStart:     s.OptsStart.Clone(),
+		UpdatedAt:     time.Now(),
+	}
+	for i, sr := range s.SRuns {
+		clsr := sr.Clone()
+		ss.SRuns[i] = &engine.StoredSRun{
+			Event:         clsr.Event,
+			CD:            clsr.CD,
+			EventCost:     clsr.EventCost,
+			ExtraDuration: clsr.ExtraDuration,
+			LastUsage:     clsr.LastUsage,
+			TotalUsage:    clsr.TotalUsage,
+			NextAutoDebit: clsr.NextAutoDebit,
+		}
+	}
+	return
+}
+
+// newSessionFromStoredSession restores a Session out of its DataDB backup
+func newSessionFromStoredSession(ss *engine.StoredSession) (s *Session) {
+	s = &Session{
+		CGRID:         ss.CGRID,
+		Tenant:        ss.Tenant,
+		ResourceID:    ss.ResourceID,
+		ClientConnID:  ss.ClientConnID,
+		EventStart:    ss.EventStart,
+		DebitInterval: ss.DebitInterval,
+		SRuns:         make([]*SRun, len(ss.SRuns)),
+		OptsStart:     ss.OptsStart,
+		chargeable:    ss.Chargeable,
+	}
+	if s.EventStart == nil {
+		s.EventStart = make(engine.MapEvent)
+	}
+	for i, ssr := range ss.SRuns {
+		s.SRuns[i] = &SRun{
+			Event:         ssr.Event,
+			CD:            ssr.CD,
+			EventCost:     ssr.EventCost,
+			ExtraDuration: ssr.ExtraDuration,
+			LastUsage:     ssr.LastUsage,
+			TotalUsage:    ssr.TotalUsage,
+			NextAutoDebit: ssr.NextAutoDebit,
+		}
+	}
+	return
+}
+
 // AsExternalSessions returns the session as a list of ExternalSession using all SRuns (thread safe)
 func (s *Session) AsExternalSessions(tmz, nodeID string) (aSs []*ExternalSession) {
 	s.RLock()
@@ -772,3 +772,45 @@ func TestUpdateSRuns(t *testing.T) {
 			utils.MetaPostpaid, s.SRuns[0].Event[utils.RequestType])
 	}
 }
+
+func TestSessionAsStoredSession(t *testing.T) {
+	s := &Session{
+		CGRID:         "CGRID1",
+		Tenant:        "cgrates.org",
+		ResourceID:    "RES1",
+		ClientConnID:  "CONN1",
+		EventStart:    engine.MapEvent{utils.OriginID: "ORIGIN1"},
+		DebitInterval: 5 * time.Second,
+		OptsStart:     engine.MapEvent{utils.OptsSessionsTTL: "10s"},
+		chargeable:    true,
+		SRuns: []*SRun{{
+			Event: engine.MapEvent{utils.RunID: utils.MetaDefault},
+			CD: &engine.CallDescriptor{
+				CgrID:     "CGRID1",
+				RunID:     utils.MetaDefault,
+				LoopIndex: 2,
+			},
+			EventCost:     &engine.EventCost{CGRID: "CGRID1", RunID: utils.MetaDefault},
+			ExtraDuration: time.Second,
+			LastUsage:     5 * time.Second,
+			TotalUsage:    10 * time.Second,
+			NextAutoDebit: utils.TimePointer(time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)),
+		}},
+	}
+	ss := s.asStoredSession()
+	if ss.UpdatedAt.IsZero() {
+		t.Error("expecting UpdatedAt to be populated")
+	}
+	if !ss.Chargeable {
+		t.Error("expecting the session to be chargeable")
+	}
+	if ss.SRuns[0].CD == s.SRuns[0].CD {
+		t.Error("expecting the CallDescriptor to be decoupled from the session")
+	}
+	exp := s.Clone()
+	exp.OptsStart = s.OptsStart
+	exp.chargeable = true
+	if rcv := newSessionFromStoredSession(ss); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
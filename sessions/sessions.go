@@ -22,7 +22,9 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net/url"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -93,24 +95,84 @@ type SessionS struct {
 	pSessionsRIdx map[string][]*riFieldNameVal                     // reverse indexes for passive sessions, used on remove
 }
 
+// collectMetrics adds the number of active and passive sessions per tenant
+func (sS *SessionS) collectMetrics(pm *utils.PrometheusMetrics, _ url.Values) {
+	for _, ssType := range []struct {
+		name  string
+		help  string
+		mux   *sync.RWMutex
+		ssMap *map[string]*Session // the map can be replaced, read it under lock
+	}{
+		{"cgrates_sessions_active", "Number of active sessions.", &sS.aSsMux, &sS.aSessions},
+		{"cgrates_sessions_passive", "Number of passive sessions.", &sS.pSsMux, &sS.pSessions},
+	} {
+		tntSs := make(map[string]int)
+		ssType.mux.RLock()
+		for _, s := range *ssType.ssMap {
+			s.RLock()
+			tntSs[s.Tenant]++
+			s.RUnlock()
+		}
+		ssType.mux.RUnlock()
+		tnts := make([]string, 0, len(tntSs))
+		for tnt := range tntSs {
+			tnts = append(tnts, tnt)
+		}
+		sort.Strings(tnts)
+		for _, tnt := range tnts {
+			pm.Add(ssType.name, utils.PrometheusGauge, ssType.help,
+				map[string]string{"tenant": tnt}, float64(tntSs[tnt]))
+		}
+	}
+}
+
 // ListenAndServe starts the service and binds it to the listen loop
 func (sS *SessionS) ListenAndServe(stopChan chan struct{}) {
 	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.SessionS))
+	engine.Metrics.RegisterCollector(utils.SessionS, sS.collectMetrics)
+	if sS.cgrCfg.SessionSCfg().BackupInterval != 0 {
+		sS.restoreSessions()
+	}
+	var syncChan, backupChan <-chan time.Time
 	if sS.cgrCfg.SessionSCfg().ChannelSyncInterval != 0 {
-		for { // Schedule sync channels to run repeately
-			select {
-			case <-stopChan:
-				return
-			case <-time.After(sS.cgrCfg.SessionSCfg().ChannelSyncInterval):
-				sS.syncSessions()
-			}
+		syncTicker := time.NewTicker(sS.cgrCfg.SessionSCfg().ChannelSyncInterval)
+		defer syncTicker.Stop()
+		syncChan = syncTicker.C
+	}
+	if sS.cgrCfg.SessionSCfg().BackupInterval > 0 {
+		backupTicker := time.NewTicker(sS.cgrCfg.SessionSCfg().BackupInterval)
+		defer backupTicker.Stop()
+		backupChan = backupTicker.C
+	}
+	if syncChan == nil && backupChan == nil {
+		return
+	}
+	for { // Schedule sync channels and backups to run repeately
+		select {
+		case <-stopChan:
+			return
+		case <-syncChan:
+			sS.syncSessions()
+		case <-backupChan:
+			sS.backupSessions()
 		}
 	}
-	return
 }
 
 // Shutdown is called by engine to clear states
 func (sS *SessionS) Shutdown() (err error) {
+	engine.Metrics.UnregisterCollector(utils.SessionS)
+	if sS.cgrCfg.SessionSCfg().BackupInterval != 0 {
+		// keep the sessions in DataDB so they can be restored on next start
+		for _, s := range sS.getSessions("", false) {
+			s.Lock()
+			s.stopSTerminator()
+			s.stopDebitLoops()
+			sS.storeSessionBackup(s)
+			s.Unlock()
+		}
+		return
+	}
 	var hasErr bool
 	for _, s := range sS.getSessions("", false) { // Force sessions shutdown
 		if err = sS.terminateSession(s, nil, nil, nil, false); err != nil {
@@ -539,7 +601,7 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 	now := time.Now()
 	if s.SRuns[sRunIdx].NextAutoDebit != nil &&
 		now.Before(*s.SRuns[sRunIdx].NextAutoDebit) {
-		time.Sleep(now.Sub(*s.SRuns[sRunIdx].NextAutoDebit))
+		time.Sleep(s.SRuns[sRunIdx].NextAutoDebit.Sub(now))
 	}
 	for {
 		s.Lock()
@@ -575,6 +637,7 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 		}
 		debitStop := s.debitStop // avoid concurrency with endSession
 		s.SRuns[sRunIdx].NextAutoDebit = utils.TimePointer(time.Now().Add(dbtIvl))
+		sS.backupSession(s)
 		if maxDebit < dbtIvl && sS.cgrCfg.SessionSCfg().MinDurLowBalance != time.Duration(0) { // warn client for low balance
 			if sS.cgrCfg.SessionSCfg().MinDurLowBalance >= dbtIvl {
 				utils.Logger.Warning(fmt.Sprintf("<%s> can not run warning for the session: <%s> since the remaining time:<%s> is higher than the debit interval:<%s>.",
@@ -1345,15 +1408,31 @@ func (sS *SessionS) getRelocateSession(cgrID string, initOriginID,
 // syncSessions synchronizes the active sessions with the one in the clients
 // it will force-disconnect the one found in SessionS but not in clients
 func (sS *SessionS) syncSessions() {
-	queriedCGRIDs := engine.NewSafEvent(nil) // need this to be
+	queriedCGRIDs, _ := sS.queryClientsCGRIDs()
+	var toBeRemoved []string
+	sS.aSsMux.RLock()
+	for cgrid := range sS.aSessions {
+		if !queriedCGRIDs.HasField(cgrid) {
+			toBeRemoved = append(toBeRemoved, cgrid)
+		}
+	}
+	sS.aSsMux.RUnlock()
+	sS.terminateSyncSessions(toBeRemoved)
+}
+
+// queryClientsCGRIDs returns the CGRIDs of the sessions active in the clients
+// answered is true if at least one of the clients replied
+func (sS *SessionS) queryClientsCGRIDs() (queriedCGRIDs *engine.SafEvent, answered bool) {
+	queriedCGRIDs = engine.NewSafEvent(nil) // need this to be
 	var err error
 	for _, clnt := range sS.biJClients() {
-		errChan := make(chan error)
+		errChan := make(chan error, 1)
 		go func() {
 			var queriedSessionIDs []*SessionID
 			if err := clnt.conn.Call(utils.SessionSv1GetActiveSessionIDs,
 				utils.EmptyString, &queriedSessionIDs); err != nil {
 				errChan <- err
+				return
 			}
 			for _, sessionID := range queriedSessionIDs {
 				queriedCGRIDs.Set(sessionID.CGRID(), struct{}{})
@@ -1365,21 +1444,15 @@ func (sS *SessionS) syncSessions() {
 			if err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> error <%s> quering session ids", utils.SessionS, err.Error()))
+				continue
 			}
+			answered = true
 		case <-time.After(sS.cgrCfg.GeneralCfg().ReplyTimeout):
 			utils.Logger.Warning(
 				fmt.Sprintf("<%s> timeout quering session ids ", utils.SessionS))
 		}
 	}
-	var toBeRemoved []string
-	sS.aSsMux.RLock()
-	for cgrid := range sS.aSessions {
-		if !queriedCGRIDs.HasField(cgrid) {
-			toBeRemoved = append(toBeRemoved, cgrid)
-		}
-	}
-	sS.aSsMux.RUnlock()
-	sS.terminateSyncSessions(toBeRemoved)
+	return
 }
 
 // Extracted from syncSessions in order to test all cases
@@ -1399,6 +1472,98 @@ func (sS *SessionS) terminateSyncSessions(toBeRemoved []string) {
 	}
 }
 
+// storeSessionBackup checkpoints the session into DataDB
+// not thread safe
+func (sS *SessionS) storeSessionBackup(s *Session) {
+	if err := sS.dm.SetSessionBackup(sS.cgrCfg.GeneralCfg().NodeID,
+		s.asStoredSession()); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed backing up session: <%s>, err: <%s>",
+				utils.SessionS, s.CGRID, err.Error()))
+	}
+}
+
+// backupSession checkpoints the session if the backup is done on each change
+// not thread safe
+func (sS *SessionS) backupSession(s *Session) {
+	if sS.cgrCfg.SessionSCfg().BackupInterval >= 0 {
+		return
+	}
+	sS.storeSessionBackup(s)
+}
+
+// backupSessions checkpoints all the active sessions
+func (sS *SessionS) backupSessions() {
+	for _, s := range sS.getSessions(utils.EmptyString, false) {
+		s.RLock()
+		sS.storeSessionBackup(s)
+		s.RUnlock()
+	}
+}
+
+// removeSessionBackup removes the session from DataDB backup
+func (sS *SessionS) removeSessionBackup(cgrID string) {
+	if sS.cgrCfg.SessionSCfg().BackupInterval == 0 {
+		return
+	}
+	if err := sS.dm.RemoveSessionsBackup(sS.cgrCfg.GeneralCfg().NodeID,
+		cgrID); err != nil && err != utils.ErrNotFound {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed removing backup of session: <%s>, err: <%s>",
+				utils.SessionS, cgrID, err.Error()))
+	}
+}
+
+// restoreSessions loads the sessions backed up in DataDB, resuming their
+// debit loops and terminators, then reconciles them with the clients
+func (sS *SessionS) restoreSessions() {
+	storedSessions, err := sS.dm.GetSessionsBackup(sS.cgrCfg.GeneralCfg().NodeID)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed restoring sessions from backup, err: <%s>",
+					utils.SessionS, err.Error()))
+		}
+		return
+	}
+	restored := make([]string, 0, len(storedSessions))
+	for _, ss := range storedSessions {
+		if sS.isIndexed(&Session{CGRID: ss.CGRID}, false) {
+			continue // already initiated again by the client
+		}
+		s := newSessionFromStoredSession(ss)
+		s.Lock()
+		sS.registerSession(s, false)
+		sS.initSessionDebitLoops(s)
+		sS.setSTerminator(s, nil)
+		s.Unlock()
+		restored = append(restored, s.CGRID)
+	}
+	utils.Logger.Info(
+		fmt.Sprintf("<%s> restored %d sessions from backup", utils.SessionS, len(restored)))
+	if len(restored) != 0 {
+		go func() { // give the clients the time to connect back
+			time.Sleep(sS.cgrCfg.GeneralCfg().ConnectTimeout)
+			sS.syncRestoredSessions(restored)
+		}()
+	}
+}
+
+// syncRestoredSessions terminates the restored sessions which are no longer active in the clients
+func (sS *SessionS) syncRestoredSessions(cgrIDs []string) {
+	queriedCGRIDs, answered := sS.queryClientsCGRIDs()
+	if !answered { // no client to confirm the sessions, leave them to TTL and channel sync
+		return
+	}
+	var toBeRemoved []string
+	for _, cgrID := range cgrIDs {
+		if !queriedCGRIDs.HasField(cgrID) {
+			toBeRemoved = append(toBeRemoved, cgrID)
+		}
+	}
+	sS.terminateSyncSessions(toBeRemoved)
+}
+
 // initSessionDebitLoops will init the debit loops for a session
 // not thread-safe, it should be protected in another layer
 func (sS *SessionS) initSessionDebitLoops(s *Session) {
@@ -1467,6 +1632,7 @@ func (sS *SessionS) initSession(cgrEv *utils.CGREvent, clntConnID,
 		s.Lock() // avoid endsession before initialising
 		sS.initSessionDebitLoops(s)
 		sS.registerSession(s, false)
+		sS.backupSession(s)
 		s.Unlock()
 	}
 	return
@@ -1478,6 +1644,7 @@ func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMs
 		defer sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
 		s.Lock()
 		defer s.Unlock()
+		defer sS.backupSession(s) // checkpoint the session after the debits
 
 		// update fields from new event
 		for k, v := range updtEv {
@@ -1542,6 +1709,7 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 		sS.unregisterSession(s.CGRID, false)
 		s.stopSTerminator()
 		s.stopDebitLoops()
+		sS.removeSessionBackup(s.CGRID)
 	}
 	for sRunIdx, sr := range s.SRuns {
 		sUsage := sr.TotalUsage
@@ -2687,3 +2687,83 @@ func TestBiJClntID(t *testing.T) {
 		t.Errorf("Expected %+v, received %+v", expected, rcv)
 	}
 }
+
+type testMockClientActiveSessions struct {
+	sIDs []*SessionID
+}
+
+func (sT *testMockClientActiveSessions) Call(method string, args interface{}, rply interface{}) error {
+	*rply.(*[]*SessionID) = sT.sIDs
+	return nil
+}
+
+func TestSessionSBackupAndRestoreSessions(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().BackupInterval = -1
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, nil)
+	for _, originID := range []string{"ORIGIN1", "ORIGIN2"} {
+		s := &Session{
+			CGRID:      utils.Sha1(originID, "127.0.0.1"),
+			Tenant:     "cgrates.org",
+			EventStart: engine.MapEvent{utils.OriginID: originID, utils.OriginHost: "127.0.0.1"},
+			SRuns: []*SRun{{
+				Event:      engine.MapEvent{utils.RequestType: utils.MetaPostpaid},
+				CD:         &engine.CallDescriptor{CgrID: utils.Sha1(originID, "127.0.0.1")},
+				TotalUsage: 2 * time.Second,
+			}},
+			chargeable: true,
+		}
+		s.Lock()
+		sS.registerSession(s, false)
+		sS.backupSession(s)
+		s.Unlock()
+	}
+	if ss, err := dm.GetSessionsBackup(cfg.GeneralCfg().NodeID); err != nil {
+		t.Fatal(err)
+	} else if len(ss) != 2 {
+		t.Errorf("Expected 2 sessions backed up, received: %s", utils.ToJSON(ss))
+	}
+
+	// new SessionS as after an engine restart
+	rS := NewSessionS(cfg, dm, nil)
+	cgrID1 := utils.Sha1("ORIGIN1", "127.0.0.1")
+	cgrID2 := utils.Sha1("ORIGIN2", "127.0.0.1")
+	rS.RegisterIntBiJConn(&testMockClientActiveSessions{
+		sIDs: []*SessionID{{OriginHost: "127.0.0.1", OriginID: "ORIGIN1"}}},
+		utils.EmptyString)
+	rS.restoreSessions()
+	if ss := rS.getSessions(utils.EmptyString, false); len(ss) != 2 {
+		t.Fatalf("Expected 2 restored sessions, received: %d", len(ss))
+	} else if rcv := rS.getSessions(cgrID1, false); len(rcv) != 1 ||
+		rcv[0].SRuns[0].TotalUsage != 2*time.Second {
+		t.Errorf("Unexpected restored session: %s", utils.ToJSON(rcv))
+	}
+
+	// ORIGIN2 is not known by the client anymore so it should be terminated
+	rS.syncRestoredSessions([]string{cgrID1, cgrID2})
+	if ss := rS.getSessions(cgrID2, false); len(ss) != 0 {
+		t.Errorf("Expected orphan session to be terminated, received: %s", utils.ToJSON(ss))
+	}
+	if ss, err := dm.GetSessionsBackup(cfg.GeneralCfg().NodeID); err != nil {
+		t.Fatal(err)
+	} else if len(ss) != 1 || ss[0].CGRID != cgrID1 {
+		t.Errorf("Expected only %q in backup, received: %s", cgrID1, utils.ToJSON(ss))
+	}
+
+	// shutdown keeps the session for the next start
+	if err := rS.Shutdown(); err != nil {
+		t.Error(err)
+	}
+	if ss, err := dm.GetSessionsBackup(cfg.GeneralCfg().NodeID); err != nil {
+		t.Error(err)
+	} else if len(ss) != 1 {
+		t.Errorf("Expected the session to be kept in backup, received: %s", utils.ToJSON(ss))
+	}
+	rS.removeSessionBackup(cgrID1)
+	if _, err := dm.GetSessionsBackup(cfg.GeneralCfg().NodeID); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -35,6 +35,7 @@ type AccountProfile struct {
 	Opts               map[string]interface{}
 	Balances           map[string]*Balance
 	ThresholdIDs       []string
+	Reservations       map[string]*AccountReservation // units held until commit or release
 }
 
 // BalancesAltered detects altering of the Balances by comparing the Balance values with the ones from backup
@@ -199,9 +200,72 @@ func (aP *AccountProfile) Clone() (acnt *AccountProfile) {
 			acnt.ThresholdIDs[i] = value
 		}
 	}
+	if aP.Reservations != nil {
+		acnt.Reservations = make(map[string]*AccountReservation, len(aP.Reservations))
+		for i, value := range aP.Reservations {
+			acnt.Reservations[i] = value.Clone()
+		}
+	}
 	return
 }
 
+// AccountReservation holds the units debited out of the account balances
+// until the reservation is committed or released
+type AccountReservation struct {
+	ID         string
+	Event      *CGREvent           // the reserved event, used to debit the committed usage
+	Units      map[string]*Decimal // units held per balance
+	ExpiryTime *time.Time          // released automatically after this time
+}
+
+// Clone returns a clone of the AccountReservation
+func (aR *AccountReservation) Clone() (cln *AccountReservation) {
+	cln = &AccountReservation{ID: aR.ID}
+	if aR.Event != nil {
+		cln.Event = aR.Event.Clone()
+	}
+	if aR.Units != nil {
+		cln.Units = make(map[string]*Decimal, len(aR.Units))
+		for blncID, units := range aR.Units {
+			cln.Units[blncID] = units.Clone()
+		}
+	}
+	if aR.ExpiryTime != nil {
+		cln.ExpiryTime = TimePointer(*aR.ExpiryTime)
+	}
+	return
+}
+
+// Expired checks if the reservation expired at tm
+func (aR *AccountReservation) Expired(tm time.Time) bool {
+	return aR.ExpiryTime != nil && !tm.Before(*aR.ExpiryTime)
+}
+
+// KeepReservations replaces the reservations of the provisioned profile with the ones held on the stored profile
+// the balance units are adjusted so the held units can be given back on release
+func (aP *AccountProfile) KeepReservations(stored *AccountProfile) {
+	for _, rsv := range aP.Reservations {
+		for blncID, units := range rsv.Units {
+			if blnc, has := aP.Balances[blncID]; has && blnc.Units != nil {
+				blnc.Units = &Decimal{SumBig(blnc.Units.Big, units.Big)}
+			}
+		}
+	}
+	aP.Reservations = nil
+	if len(stored.Reservations) == 0 {
+		return
+	}
+	aP.Reservations = make(map[string]*AccountReservation, len(stored.Reservations))
+	for rsvID, rsv := range stored.Reservations {
+		aP.Reservations[rsvID] = rsv.Clone()
+		for blncID, units := range rsv.Units {
+			if blnc, has := aP.Balances[blncID]; has && blnc.Units != nil {
+				blnc.Units = &Decimal{SubstractBig(blnc.Units.Big, units.Big)}
+			}
+		}
+	}
+}
+
 //Clone returns a clone of the ActivationInterval
 func (aI *ActivationInterval) Clone() *ActivationInterval {
 	if aI == nil {
@@ -500,3 +564,40 @@ type ArgsActRemoveBalances struct {
 	BalanceIDs []string
 	Opts       map[string]interface{}
 }
+
+// ArgsAccountsReservation is used to commit or release a reservation
+type ArgsAccountsReservation struct {
+	Tenant        string
+	ReservationID string
+	AccountIDs    []string       // the accounts holding the reservation
+	Usage         *time.Duration // the usage to commit, defaults to the reserved one
+	Opts          map[string]interface{}
+}
+
+// AccountsReservation is the reply of AccountSv1.ReserveAbstracts
+type AccountsReservation struct {
+	ReservationID string
+	AccountIDs    []string // the accounts holding units for this reservation
+	ExpiryTime    *time.Time
+	EventCharges  *ExtEventCharges
+}
+
+// ArgsActRolloverBalances is used by the *rollover_balances and *prorate_balances actions
+// empty BalanceIDs will select all the balances with *initialUnits option
+type ArgsActRolloverBalances struct {
+	Tenant     string
+	AccountID  string
+	BalanceIDs []string
+	Prorate    bool       // allocate only the units for the remaining of the cycle
+	Time       *time.Time // the moment of the rollover, defaults to now
+	Opts       map[string]interface{}
+}
+
+// ArgsActResetAccount is used by the *reset_account action
+// empty BalanceIDs will reset all the balances of the account
+type ArgsActResetAccount struct {
+	Tenant     string
+	AccountID  string
+	BalanceIDs []string
+	Opts       map[string]interface{}
+}
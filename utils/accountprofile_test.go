@@ -710,3 +710,40 @@ func TestAPBalances(t *testing.T) {
 		t.Errorf("\nReceived: <%+v>,\nExpected: <%+v>", ToJSON(received), ToJSON(expected))
 	}
 }
+
+func TestAPKeepReservations(t *testing.T) {
+	stored := &AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*Balance{
+			"CB": {ID: "CB", Units: NewDecimal(70, 0)},
+		},
+		Reservations: map[string]*AccountReservation{
+			"RSV1": {ID: "RSV1", Units: map[string]*Decimal{"CB": NewDecimal(30, 0)}},
+		},
+	}
+	// a snapshot holding other reservations is restored with the ones held now
+	aP := &AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "1001",
+		Balances: map[string]*Balance{
+			"CB": {ID: "CB", Units: NewDecimal(90, 0)},
+		},
+		Reservations: map[string]*AccountReservation{
+			"RSV0": {ID: "RSV0", Units: map[string]*Decimal{"CB": NewDecimal(10, 0)}},
+		},
+	}
+	aP.KeepReservations(stored)
+	if aP.Balances["CB"].Units.Compare(NewDecimal(70, 0)) != 0 {
+		t.Errorf("Expected %v, received %s", 70, aP.Balances["CB"].Units)
+	}
+	if len(aP.Reservations) != 1 || aP.Reservations["RSV1"] == nil {
+		t.Errorf("Unexpected reservations: %s", ToJSON(aP.Reservations))
+	}
+	aP.KeepReservations(new(AccountProfile))
+	if aP.Balances["CB"].Units.Compare(NewDecimal(100, 0)) != 0 {
+		t.Errorf("Expected %v, received %s", 100, aP.Balances["CB"].Units)
+	} else if aP.Reservations != nil {
+		t.Errorf("Unexpected reservations: %s", ToJSON(aP.Reservations))
+	}
+}
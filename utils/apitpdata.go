@@ -892,6 +892,7 @@ type TPResourceProfile struct {
 	Stored             bool
 	Weight             float64  // Weight to sort the ResourceLimits
 	ThresholdIDs       []string // Thresholds to check after changing Limit
+	RateWindow         string   // rolling window for rate limiting, empty for concurrent usages
 }
 
 // TPActivationInterval represents an activation interval for an item
@@ -1014,6 +1015,9 @@ type TPStatProfile struct {
 	Weight             float64
 	MinItems           int
 	ThresholdIDs       []string
+	BucketWidth        string
+	Window             string
+	WindowType         string
 }
 
 // TPThresholdProfile is used in APIs to manage remotely offline ThresholdProfile
@@ -1029,6 +1033,7 @@ type TPThresholdProfile struct {
 	Blocker            bool    // blocker flag to stop processing on filters matched
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
+	ActionProfileIDs   []string
 	Async              bool
 }
 
@@ -1470,9 +1475,16 @@ type TPRateProfile struct {
 	MinCost            float64
 	MaxCost            float64
 	MaxCostStrategy    string
+	VolumeUsage        string
+	VolumeTiers        []*TPVolumeTier
 	Rates              map[string]*TPRate
 }
 
+type TPVolumeTier struct {
+	VolumeStart string
+	Factor      float64
+}
+
 type TPRate struct {
 	ID              string   // RateID
 	FilterIDs       []string // RateFilterIDs
@@ -1527,6 +1539,36 @@ func (args *ArgsCostForEvent) Usage() (usage time.Duration, err error) {
 	return
 }
 
+// ArgsCostForEvents is used to rate a batch of events
+type ArgsCostForEvents struct {
+	Tenant         string
+	RateProfileIDs []string // candidate profiles for the events
+	CGREvents      []*CGREvent
+	Opts           map[string]interface{} // defaults for the options missing in events
+}
+
+// AsArgsCostForEvent returns the ArgsCostForEvent for each of the events
+func (args *ArgsCostForEvents) AsArgsCostForEvent(dfltTnt string) (evArgs []*ArgsCostForEvent) {
+	tnt := FirstNonEmpty(args.Tenant, dfltTnt)
+	evArgs = make([]*ArgsCostForEvent, len(args.CGREvents))
+	for i, cgrEv := range args.CGREvents {
+		ev := *cgrEv
+		ev.Tenant = FirstNonEmpty(ev.Tenant, tnt)
+		ev.Opts = make(map[string]interface{}, len(cgrEv.Opts)+len(args.Opts))
+		for k, v := range args.Opts {
+			ev.Opts[k] = v
+		}
+		for k, v := range cgrEv.Opts {
+			ev.Opts[k] = v
+		}
+		evArgs[i] = &ArgsCostForEvent{
+			RateProfileIDs: args.RateProfileIDs,
+			CGREvent:       &ev,
+		}
+	}
+	return
+}
+
 type TPActionProfile struct {
 	TPid               string
 	Tenant             string
@@ -1666,3 +1708,22 @@ type ArgActionSv1ScheduleActions struct {
 	*CGREvent
 	ActionProfileIDs []string
 }
+
+// ActionDiff describes what an action would change if executed
+// returned by ActionSv1.DryRunActions
+type ActionDiff struct {
+	ActionProfileID string
+	ActionID        string
+	Type            string
+	TargetType      string
+	TargetID        string
+	Changes         map[string]*ValueDiff // the modified fields of the target
+	Payload         interface{}           // the data that would be sent(e.g. exported event)
+	Error           string                // the error simulating the action
+}
+
+// ValueDiff holds the value of a field before and after a change
+type ValueDiff struct {
+	Old interface{}
+	New interface{}
+}
@@ -1254,3 +1254,48 @@ func TestATDUsage(t *testing.T) {
 		t.Errorf("\nReceived: <%+v>, \nExpected: <%+v>", err.Error(), expected)
 	}
 }
+
+func TestArgsCostForEventsAsArgsCostForEvent(t *testing.T) {
+	args := &ArgsCostForEvents{
+		RateProfileIDs: []string{"RP1"},
+		CGREvents: []*CGREvent{
+			{
+				ID:    "EV1",
+				Event: map[string]interface{}{AccountField: "1001"},
+				Opts:  map[string]interface{}{OptsRatesUsage: "2m"},
+			},
+			{
+				Tenant: "itsyscom.com",
+				ID:     "EV2",
+				Event:  map[string]interface{}{AccountField: "1002"},
+			},
+		},
+		Opts: map[string]interface{}{OptsRatesUsage: "1m"},
+	}
+	exp := []*ArgsCostForEvent{
+		{
+			RateProfileIDs: []string{"RP1"},
+			CGREvent: &CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "EV1",
+				Event:  map[string]interface{}{AccountField: "1001"},
+				Opts:   map[string]interface{}{OptsRatesUsage: "2m"},
+			},
+		},
+		{
+			RateProfileIDs: []string{"RP1"},
+			CGREvent: &CGREvent{
+				Tenant: "itsyscom.com",
+				ID:     "EV2",
+				Event:  map[string]interface{}{AccountField: "1002"},
+				Opts:   map[string]interface{}{OptsRatesUsage: "1m"},
+			},
+		},
+	}
+	if rcv := args.AsArgsCostForEvent("cgrates.org"); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", ToJSON(exp), ToJSON(rcv))
+	}
+	if args.CGREvents[0].Tenant != EmptyString || args.CGREvents[1].Opts != nil {
+		t.Errorf("Events should not be modified: %s", ToJSON(args.CGREvents))
+	}
+}
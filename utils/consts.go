@@ -39,12 +39,13 @@ var (
 		MetaAMQPV1jsonMap: ContentJSON,
 		MetaSQSjsonMap:    ContentJSON,
 		MetaKafkajsonMap:  ContentJSON,
+		MetaNatsjsonMap:   ContentJSON,
 		MetaS3jsonMap:     ContentJSON,
 	}
 
 	extraDBPartition = NewStringSet([]string{CacheDispatchers,
-		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRPCResponses, CacheClosedSessions,
-		CacheCDRIDs, CacheRPCConnections, CacheUCH, CacheSTIR, CacheEventCharges, MetaAPIBan,
+		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRadiusPackets, CacheRPCResponses,
+		CacheClosedSessions, CacheCDRIDs, CacheRPCConnections, CacheUCH, CacheSTIR, CacheEventCharges, MetaAPIBan,
 		CacheCapsEvents, CacheVersions, CacheReplicationHosts})
 
 	dataDBPartition = NewStringSet([]string{CacheDestinations, CacheReverseDestinations, CacheRatingPlans,
@@ -56,7 +57,7 @@ var (
 		CacheAttributeFilterIndexes, CacheChargerFilterIndexes, CacheDispatcherFilterIndexes, CacheLoadIDs,
 		CacheRatingProfilesTmp, CacheRateProfiles, CacheRateProfilesFilterIndexes, CacheRateFilterIndexes,
 		CacheActionProfilesFilterIndexes, CacheAccountProfilesFilterIndexes, CacheReverseFilterIndexes,
-		CacheActionPlans, CacheAccountActionPlans, CacheAccountProfiles, CacheAccounts})
+		CacheActionPlans, CacheAccountActionPlans, CacheAccountProfiles, CacheAccounts, CacheSessionsBackup, CacheRerateJobs})
 
 	storDBPartition = NewStringSet([]string{CacheTBLTPTimings, CacheTBLTPDestinations, CacheTBLTPRates, CacheTBLTPDestinationRates,
 		CacheTBLTPRatingPlans, CacheTBLTPRatingProfiles, CacheTBLTPSharedGroups, CacheTBLTPActions,
@@ -408,6 +409,8 @@ const (
 	ThresholdProfilePrefix    = "thp_"
 	StatQueuePrefix           = "stq_"
 	LoadIDPrefix              = "lid_"
+	SessionsBackupPrefix      = "sbk_"
+	RerateJobPrefix           = "rrj_"
 	LoadInstKey               = "load_history"
 	CreateCDRsTablesSQL       = "create_cdrs_tables.sql"
 	CreateTariffPlanTablesSQL = "create_tariffplan_tables.sql"
@@ -423,6 +426,7 @@ const (
 	MetaAMQPV1jsonMap         = "*amqpv1_json_map"
 	MetaSQSjsonMap            = "*sqs_json_map"
 	MetaKafkajsonMap          = "*kafka_json_map"
+	MetaNatsjsonMap           = "*nats_json_map"
 	MetaSQL                   = "*sql"
 	MetaMySQL                 = "*mysql"
 	MetaS3jsonMap             = "*s3_json_map"
@@ -467,6 +471,8 @@ const (
 	ActiveGoroutines         = "ActiveGoroutines"
 	MemoryUsage              = "MemoryUsage"
 	RunningSince             = "RunningSince"
+	CapsAllocated            = "CapsAllocated"
+	CapsLimit                = "CapsLimit"
 	GoVersion                = "GoVersion"
 	HandlerSubstractUsage    = "*substract_usage"
 	XML                      = "xml"
@@ -499,6 +505,10 @@ const (
 	MetaReplicator           = "*replicator"
 	MetaRerate               = "*rerate"
 	MetaRefund               = "*refund"
+	MetaRunning              = "*running"
+	MetaCompleted            = "*completed"
+	MetaCancelled            = "*cancelled"
+	MetaFailed               = "*failed"
 	MetaStats                = "*stats"
 	MetaResponder            = "*responder"
 	MetaCore                 = "*core"
@@ -536,6 +546,9 @@ const (
 	ActionsPoster            = "act"
 	CDRPoster                = "cdr"
 	MetaFileCSV              = "*file_csv"
+	MetaFileNDJSON           = "*file_ndjson"
+	MetaS3CSV                = "*s3_csv"
+	MetaS3NDJSON             = "*s3_ndjson"
 	MetaVirt                 = "*virt"
 	MetaElastic              = "*elastic"
 	MetaFileFWV              = "*file_fwv"
@@ -575,6 +588,7 @@ const (
 	Strategy                 = "Strategy"
 	StrategyParameters       = "StrategyParameters"
 	ConnID                   = "ConnID"
+	ConnIDs                  = "ConnIDs"
 	ConnFilterIDs            = "ConnFilterIDs"
 	ConnWeight               = "ConnWeight"
 	ConnBlocker              = "ConnBlocker"
@@ -597,6 +611,7 @@ const (
 	EventSource           = "EventSource"
 	AccountID             = "AccountID"
 	AccountIDs            = "AccountIDs"
+	ReservationID         = "ReservationID"
 	ResourceID            = "ResourceID"
 	TotalUsage            = "TotalUsage"
 	StatID                = "StatID"
@@ -645,6 +660,7 @@ const (
 	MetaLC                   = "*lc"
 	MetaHC                   = "*hc"
 	MetaQOS                  = "*qos"
+	MetaLCQOS                = "*lc_qos"
 	MetaReas                 = "*reas"
 	MetaReds                 = "*reds"
 	Weight                   = "Weight"
@@ -677,6 +693,8 @@ const (
 	GroupIntervalStart       = "GroupIntervalStart"
 	RateIncrement            = "RateIncrement"
 	RateUnit                 = "RateUnit"
+	VolumeUsage              = "VolumeUsage"
+	VolumeTiers              = "VolumeTiers"
 	BalanceUUID              = "BalanceUUID"
 	RatingID                 = "RatingID"
 	ExtraChargeID            = "ExtraChargeID"
@@ -724,6 +742,10 @@ const (
 	MinItems                 = "MinItems"
 	MetricIDs                = "MetricIDs"
 	MetricFilterIDs          = "MetricFilterIDs"
+	BucketWidth              = "BucketWidth"
+	Window                   = "Window"
+	WindowType               = "WindowType"
+	RateWindow               = "RateWindow"
 	FieldName                = "FieldName"
 	Path                     = "Path"
 	MetaRound                = "*round"
@@ -883,6 +905,7 @@ const (
 	MetaLoaders           = "*loaders"
 	TmpSuffix             = ".tmp"
 	MetaDiamreq           = "*diamreq"
+	MetaRadDAReq          = "*radDAReq"
 	MetaCost              = "*cost"
 	MetaGroup             = "*group"
 	InternalRPCSet        = "InternalRPCSet"
@@ -952,6 +975,13 @@ const (
 	MetaAbstract          = "*abstract"
 	MetaBalanceLimit      = "*balanceLimit"
 	MetaBalanceUnlimited  = "*balanceUnlimited"
+	MetaInitialUnits      = "*initialUnits"
+	MetaExpiryTime        = "*expiryTime"
+	MetaRolloverMaxUnits  = "*rolloverMaxUnits"
+	MetaRolloverMaxPrcnt  = "*rolloverMaxPercent"
+	MetaRolloverCycle     = "*rolloverCycle"
+	MetaRolloverOf        = "*rolloverOf"
+	MetaRollover          = "*rollover"
 	MetaTemplateID        = "*templateID"
 	MetaCdrLog            = "*cdrLog"
 	MetaCDR               = "*cdr"
@@ -968,6 +998,9 @@ const (
 	Diktats               = "Diktats"
 	BalanceIDs            = "BalanceIDs"
 	MetaCostIncrement     = "*costIncrement"
+	SQMetrics             = "SQMetrics"
+	Hits                  = "Hits"
+	Snooze                = "Snooze"
 )
 
 // Migrator Action
@@ -1006,17 +1039,28 @@ const (
 
 // MetaMetrics
 const (
-	MetaASR      = "*asr"
-	MetaACD      = "*acd"
-	MetaTCD      = "*tcd"
-	MetaACC      = "*acc"
-	MetaTCC      = "*tcc"
-	MetaPDD      = "*pdd"
-	MetaDDC      = "*ddc"
-	MetaSum      = "*sum"
-	MetaAverage  = "*average"
-	MetaDistinct = "*distinct"
-	MetaRAR      = "*rar"
+	MetaASR       = "*asr"
+	MetaACD       = "*acd"
+	MetaTCD       = "*tcd"
+	MetaACC       = "*acc"
+	MetaTCC       = "*tcc"
+	MetaPDD       = "*pdd"
+	MetaDDC       = "*ddc"
+	MetaSum       = "*sum"
+	MetaAverage   = "*average"
+	MetaDistinct  = "*distinct"
+	MetaP50       = "*p50"
+	MetaP95       = "*p95"
+	MetaP99       = "*p99"
+	MetaHistogram = "*histogram"
+	MetaRAR       = "*rar"
+)
+
+// StatQueue windows
+const (
+	MetaBucket   = "*bucket"
+	MetaSliding  = "*sliding"
+	MetaTumbling = "*tumbling"
 )
 
 // Services
@@ -1104,9 +1148,13 @@ const (
 	BalanceUnits                = "BalanceUnits"
 	ExtraParameters             = "ExtraParameters"
 
-	MetaAddBalance = "*add_balance"
-	MetaSetBalance = "*set_balance"
-	MetaRemBalance = "*rem_balance"
+	MetaAddBalance       = "*add_balance"
+	MetaSetBalance       = "*set_balance"
+	MetaRemBalance       = "*rem_balance"
+	MetaSetAccountField  = "*set_account_field"
+	MetaReleaseResources = "*release_resources"
+	MetaRolloverBalances = "*rollover_balances"
+	MetaProrateBalances  = "*prorate_balances"
 )
 
 // Migrator Metas
@@ -1178,6 +1226,11 @@ const (
 	MetaFirst          = "*first"
 	MetaRandom         = "*random"
 	MetaRoundRobin     = "*round_robin"
+	MetaLeastLoaded    = "*least_loaded"
+	MetaHashRing       = "*hash_ring"
+	MetaHashField      = "*hash_field"
+	MetaVirtualNodes   = "*virtual_nodes"
+	DefaultVNodes      = 100
 	MetaRatio          = "*ratio"
 	MetaDefaultRatio   = "*default_ratio"
 	ThresholdSv1       = "ThresholdSv1"
@@ -1573,9 +1626,11 @@ const (
 )
 
 const (
-	RateSv1             = "RateSv1"
-	RateSv1CostForEvent = "RateSv1.CostForEvent"
-	RateSv1Ping         = "RateSv1.Ping"
+	RateSv1                    = "RateSv1"
+	RateSv1CostForEvent        = "RateSv1.CostForEvent"
+	RateSv1CostForEvents       = "RateSv1.CostForEvents"
+	RateSv1CompareRateProfiles = "RateSv1.CompareRateProfiles"
+	RateSv1Ping                = "RateSv1.Ping"
 )
 
 const (
@@ -1588,6 +1643,11 @@ const (
 	AccountSv1DebitConcretes          = "AccountSv1.DebitConcretes"
 	AccountSv1ActionSetBalance        = "AccountSv1.ActionSetBalance"
 	AccountSv1ActionRemoveBalance     = "AccountSv1.ActionRemoveBalance"
+	AccountSv1ActionResetAccount      = "AccountSv1.ActionResetAccount"
+	AccountSv1ActionRolloverBalances  = "AccountSv1.ActionRolloverBalances"
+	AccountSv1ReserveAbstracts        = "AccountSv1.ReserveAbstracts"
+	AccountSv1CommitReservation       = "AccountSv1.CommitReservation"
+	AccountSv1ReleaseReservation      = "AccountSv1.ReleaseReservation"
 )
 
 const (
@@ -1654,6 +1714,7 @@ const (
 	StatSv1GetQueueIDs             = "StatSv1.GetQueueIDs"
 	StatSv1GetQueueStringMetrics   = "StatSv1.GetQueueStringMetrics"
 	StatSv1GetQueueFloatMetrics    = "StatSv1.GetQueueFloatMetrics"
+	StatSv1GetQueueBucketMetrics   = "StatSv1.GetQueueBucketMetrics"
 	StatSv1Ping                    = "StatSv1.Ping"
 	StatSv1GetStatQueuesForEvent   = "StatSv1.GetStatQueuesForEvent"
 	StatSv1GetStatQueue            = "StatSv1.GetStatQueue"
@@ -1764,14 +1825,16 @@ const (
 	AnalyzerSv1            = "AnalyzerSv1"
 	AnalyzerSv1Ping        = "AnalyzerSv1.Ping"
 	AnalyzerSv1StringQuery = "AnalyzerSv1.StringQuery"
+	AnalyzerSv1Replay      = "AnalyzerSv1.Replay"
 )
 
 // LoaderS APIs
 const (
-	LoaderSv1       = "LoaderSv1"
-	LoaderSv1Load   = "LoaderSv1.Load"
-	LoaderSv1Remove = "LoaderSv1.Remove"
-	LoaderSv1Ping   = "LoaderSv1.Ping"
+	LoaderSv1         = "LoaderSv1"
+	LoaderSv1Load     = "LoaderSv1.Load"
+	LoaderSv1Remove   = "LoaderSv1.Remove"
+	LoaderSv1Rollback = "LoaderSv1.Rollback"
+	LoaderSv1Ping     = "LoaderSv1.Ping"
 )
 
 // CacheS APIs
@@ -1808,6 +1871,9 @@ const (
 	CDRsV1                   = "CDRsV1"
 	CDRsV1GetCDRsCount       = "CDRsV1.GetCDRsCount"
 	CDRsV1RateCDRs           = "CDRsV1.RateCDRs"
+	CDRsV1StartRerate        = "CDRsV1.StartRerate"
+	CDRsV1RerateStatus       = "CDRsV1.RerateStatus"
+	CDRsV1CancelRerate       = "CDRsV1.CancelRerate"
 	CDRsV1GetCDRs            = "CDRsV1.GetCDRs"
 	CDRsV1ProcessCDR         = "CDRsV1.ProcessCDR"
 	CDRsV1ProcessExternalCDR = "CDRsV1.ProcessExternalCDR"
@@ -1958,6 +2024,7 @@ const (
 	CacheChargerFilterIndexes         = "*charger_filter_indexes"
 	CacheDispatcherFilterIndexes      = "*dispatcher_filter_indexes"
 	CacheDiameterMessages             = "*diameter_messages"
+	CacheRadiusPackets                = "*radius_packets"
 	CacheRPCResponses                 = "*rpc_responses"
 	CacheClosedSessions               = "*closed_sessions"
 	CacheRateProfilesFilterIndexes    = "*rate_profile_filter_indexes"
@@ -1975,6 +2042,8 @@ const (
 	CacheEventCharges                 = "*event_charges"
 	CacheReverseFilterIndexes         = "*reverse_filter_indexes"
 	CacheAccounts                     = "*accounts"
+	CacheSessionsBackup               = "*sessions_backup"
+	CacheRerateJobs                   = "*rerate_jobs"
 	CacheVersions                     = "*versions"
 	CacheCapsEvents                   = "*caps_events"
 	CacheReplicationHosts             = "*replication_hosts"
@@ -2158,6 +2227,7 @@ const (
 	HTTPWSURLCfg             = "ws_url"
 	HTTPFreeswitchCDRsURLCfg = "freeswitch_cdrs_url"
 	HTTPCDRsURLCfg           = "http_cdrs"
+	HTTPPrometheusURLCfg     = "prometheus_url"
 	HTTPUseBasicAuthCfg      = "use_basic_auth"
 	HTTPAuthUsersCfg         = "auth_users"
 	HTTPClientOptsCfg        = "client_opts"
@@ -2184,6 +2254,7 @@ const (
 	StatSConnsCfg     = "stats_conns"
 	ResourceSConnsCfg = "resources_conns"
 	ApierSConnsCfg    = "apiers_conns"
+	ActionSConnsCfg   = "actions_conns"
 )
 
 // RalsCfg
@@ -2215,6 +2286,8 @@ const (
 	OnlineCDRExportsCfg    = "online_cdr_exports"
 	SessionCostRetires     = "session_cost_retries"
 	RateSConnsCfg          = "rates_conns"
+	DedupFieldsCfg         = "dedup_fields"
+	DedupTTLCfg            = "dedup_ttl"
 )
 
 // SessionSCfg
@@ -2242,6 +2315,7 @@ const (
 	AlterableFieldsCfg     = "alterable_fields"
 	MinDurLowBalanceCfg    = "min_dur_low_balance"
 	DefaultUsageCfg        = "default_usage"
+	BackupIntervalCfg      = "backup_interval"
 	STIRCfg                = "stir"
 
 	AllowedAtestCfg       = "allowed_attest"
@@ -2308,6 +2382,9 @@ const (
 	ListenAcctCfg         = "listen_acct"
 	ClientSecretsCfg      = "client_secrets"
 	ClientDictionariesCfg = "client_dictionaries"
+	ClientDaAddressesCfg  = "client_da_addresses"
+	CoATemplateCfg        = "coa_template"
+	DMRTemplateCfg        = "dmr_template"
 
 	// AttributeSCfg
 	IndexedSelectsCfg = "indexed_selects"
@@ -2320,6 +2397,11 @@ const (
 	// StatSCfg
 	StoreUncompressedLimitCfg = "store_uncompressed_limit"
 
+	// DispatcherSCfg
+	ProbeIntervalCfg    = "probe_interval"
+	BreakerThresholdCfg = "breaker_threshold"
+	BreakerTimeoutCfg   = "breaker_timeout"
+
 	// Cache
 	PartitionsCfg = "partitions"
 	PrecacheCfg   = "precache"
@@ -2333,11 +2415,12 @@ const (
 	AttributeIDsCfg      = "attribute_ids"
 
 	//LoaderSCfg
-	DryRunCfg       = "dry_run"
-	LockFileNameCfg = "lock_filename"
-	TpInDirCfg      = "tp_in_dir"
-	TpOutDirCfg     = "tp_out_dir"
-	DataCfg         = "data"
+	DryRunCfg        = "dry_run"
+	LockFileNameCfg  = "lock_filename"
+	TpInDirCfg       = "tp_in_dir"
+	TpOutDirCfg      = "tp_out_dir"
+	TransactionalCfg = "transactional"
+	DataCfg          = "data"
 
 	DefaultRatioCfg           = "default_ratio"
 	ReadersCfg                = "readers"
@@ -2371,8 +2454,9 @@ const (
 	ShutdownTimeoutCfg   = "shutdown_timeout"
 
 	// AccountSCfg
-	MaxIterations = "max_iterations"
-	MaxUsage      = "max_usage"
+	MaxIterations     = "max_iterations"
+	MaxUsage          = "max_usage"
+	ReservationTTLCfg = "reservation_ttl"
 )
 
 // FC Template
@@ -2582,6 +2666,11 @@ const (
 	OptsRouteID = "*routeID"
 	// EEs
 	OptsEEsVerbose = "*eesVerbose"
+	// AccountS
+	OptsAccountsReservationID  = "*reservationID"
+	OptsAccountsReservationTTL = "*reservationTTL"
+	// ActionS
+	OptsTrgID = "*trgID"
 	// EEs Elasticsearch options
 	ElsIndex               = "index"
 	ElsIfPrimaryTerm       = "if_primary_term"
@@ -2652,6 +2741,7 @@ const (
 	DispatcherHostIDs             = "DispatcherHostIDs"
 	DispatcherRoutesIDs           = "DispatcherRoutesIDs"
 	RateProfileIDs                = "RateProfileIDs"
+	CGREventsField                = "CGREvents"
 	ActionProfileIDs              = "ActionProfileIDs"
 	TimingIDs                     = "TimingIDs"
 	AttributeFilterIndexIDs       = "AttributeFilterIndexIDs"
@@ -2670,16 +2760,24 @@ const (
 
 // Poster and Event reader constants
 const (
-	SQSPoster     = "SQSPoster"
-	S3Poster      = "S3Poster"
-	AWSRegion     = "awsRegion"
-	AWSKey        = "awsKey"
-	AWSSecret     = "awsSecret"
-	AWSToken      = "awsToken"
-	AWSFolderPath = "folderPath"
-	KafkaTopic    = "topic"
-	KafkaGroupID  = "groupID"
-	KafkaMaxWait  = "maxWait"
+	SQSPoster        = "SQSPoster"
+	S3Poster         = "S3Poster"
+	AWSRegion        = "awsRegion"
+	AWSKey           = "awsKey"
+	AWSSecret        = "awsSecret"
+	AWSToken         = "awsToken"
+	AWSFolderPath    = "folderPath"
+	S3PathStyle      = "s3ForcePathStyle"
+	KafkaTopic       = "topic"
+	KafkaGroupID     = "groupID"
+	KafkaMaxWait     = "maxWait"
+	NatsSubject      = "natsSubject"
+	NatsJetStream    = "natsJetStream"
+	NatsConsumerName = "natsConsumerName"
+
+	DeliveryMode         = "deliveryMode"
+	DeliveryRetries      = "deliveryRetries"
+	DeadLetterExporterID = "deadLetterExporterID"
 
 	// General constants for posters
 	DefaultQueueID      = "cgrates_cdrs"
@@ -2697,12 +2795,23 @@ const (
 	KafkaDefaultGroupID = "cgrates"
 	KafkaDefaultMaxWait = time.Millisecond
 
+	NatsDefaultConsumerName = "cgrates"
+
+	MetaAtMostOnce         = "*at_most_once"
+	MetaAtLeastOnce        = "*at_least_once"
+	DefaultDeliveryRetries = 3
+	Payload                = "Payload"
+	ReaderID               = "ReaderID"
+
 	SQLDBName         = "dbName"
 	SQLTableName      = "tableName"
 	SQLSSLMode        = "sslmode"
 	SQLDefaultSSLMode = "disable"
 	SQLDefaultDBName  = "cgrates"
 
+	SQLKeyColumn        = "sqlKeyColumn"
+	SQLDefaultKeyColumn = "id"
+
 	ProcessedOpt = "Processed"
 )
 
@@ -2715,6 +2824,8 @@ const (
 
 	RequestStartTime = "RequestStartTime"
 	RequestDuration  = "RequestDuration"
+	RequestID        = "RequestID"
+	RequestMethod    = "RequestMethod"
 	RequestParams    = "RequestParams"
 	Reply            = "Reply"
 	ReplyError       = "ReplyError"
@@ -2800,6 +2911,7 @@ const (
 	ActionSv1Ping            = "ActionSv1.Ping"
 	ActionSv1ScheduleActions = "ActionSv1.ScheduleActions"
 	ActionSv1ExecuteActions  = "ActionSv1.ExecuteActions"
+	ActionSv1DryRunActions   = "ActionSv1.DryRunActions"
 )
 
 // Time duration suffix
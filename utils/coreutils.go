@@ -932,6 +932,9 @@ func CastRPCErr(err error) error {
 		if _, has := ErrMap[err.Error()]; has {
 			return ErrMap[err.Error()]
 		}
+		if rErr, has := ParseErrRetryAfter(err); has {
+			return rErr
+		}
 	}
 	return err
 }
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 var (
@@ -66,6 +67,7 @@ var (
 	RalsErrorPrfx                    = "RALS_ERROR"
 	DispatcherErrorPrefix            = "DISPATCHER_ERROR"
 	RateSErrPrfx                     = "RATES_ERROR"
+	RetryAfterPrfx                   = "RETRY_AFTER"
 	ErrUnsupportedFormat             = errors.New("UNSUPPORTED_FORMAT")
 	ErrNoDatabaseConn                = errors.New("NO_DATA_BASE_CONNECTION")
 	ErrMaxIncrementsExceeded         = errors.New("MAX_INCREMENTS_EXCEEDED")
@@ -174,6 +176,9 @@ func NewErrRALs(err error) error {
 }
 
 func NewErrResourceS(err error) error {
+	if rErr, has := ParseErrRetryAfter(err); has { // keep the retry after available to the callers
+		return NewErrRetryAfter(fmt.Errorf("RESOURCES_ERROR:%s", rErr.Err), rErr.RetryAfter)
+	}
 	return fmt.Errorf("RESOURCES_ERROR:%s", err)
 }
 
@@ -209,6 +214,62 @@ func NewErrRateS(err error) error {
 	return fmt.Errorf("%s:%s", RateSErrPrfx, err.Error())
 }
 
+// NewErrRetryAfter adds to err the time after which the request can be retried
+func NewErrRetryAfter(err error, retryAfter time.Duration) error {
+	return &ErrRetryAfter{Err: err, RetryAfter: retryAfter}
+}
+
+// ErrRetryAfter is returned when the request can be retried after RetryAfter
+// Err is still matched by errors.Is so the callers can compare against it
+type ErrRetryAfter struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *ErrRetryAfter) Error() string {
+	return fmt.Sprintf("%s:%s:%s", e.Err.Error(), RetryAfterPrfx, e.RetryAfter)
+}
+
+func (e *ErrRetryAfter) Unwrap() error {
+	return e.Err
+}
+
+// Is matches the target also when Err was prefixed by the subsystem, e.g. RESOURCES_ERROR:RESOURCE_UNAUTHORIZED,
+// or rebuilt out of its string representation received over RPC
+func (e *ErrRetryAfter) Is(target error) bool {
+	if target == nil || e.Err == nil {
+		return false
+	}
+	errStr, tgtStr := e.Err.Error(), target.Error()
+	return errStr == tgtStr ||
+		strings.HasSuffix(errStr, InInFieldSep+tgtStr)
+}
+
+// ParseErrRetryAfter returns the *ErrRetryAfter out of err
+// the string representation received over RPC is parsed back
+func ParseErrRetryAfter(err error) (rErr *ErrRetryAfter, has bool) {
+	if err == nil {
+		return
+	}
+	if errors.As(err, &rErr) {
+		return rErr, true
+	}
+	errStr := err.Error()
+	idx := strings.LastIndex(errStr, InInFieldSep+RetryAfterPrfx+InInFieldSep)
+	if idx == -1 {
+		return
+	}
+	rA, errDur := time.ParseDuration(errStr[idx+len(RetryAfterPrfx)+2:])
+	if errDur != nil {
+		return
+	}
+	rErr = &ErrRetryAfter{Err: errors.New(errStr[:idx]), RetryAfter: rA}
+	if knownErr, isKnown := ErrMap[errStr[:idx]]; isKnown {
+		rErr.Err = knownErr
+	}
+	return rErr, true
+}
+
 // Centralized returns for APIs
 func APIErrorHandler(errIn error) (err error) {
 	cgrErr, ok := errIn.(*CGRError)
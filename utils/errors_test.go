@@ -21,6 +21,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewCGRError(t *testing.T) {
@@ -92,6 +93,49 @@ func TestNewErrServiceNotOperational(t *testing.T) {
 	}
 }
 
+func TestNewErrRetryAfter(t *testing.T) {
+	exp := "RESOURCE_UNAVAILABLE:RETRY_AFTER:1.5s"
+	rcv := NewErrRetryAfter(ErrResourceUnavailable, 1500*time.Millisecond)
+	if rcv.Error() != exp {
+		t.Errorf("Expecting: %s, received: %+v", exp, rcv)
+	}
+	if !errors.Is(rcv, ErrResourceUnavailable) {
+		t.Errorf("Expecting %v to match %v", rcv, ErrResourceUnavailable)
+	}
+}
+
+func TestParseErrRetryAfter(t *testing.T) {
+	if _, has := ParseErrRetryAfter(nil); has {
+		t.Error("Expecting no retry after for nil error")
+	}
+	if _, has := ParseErrRetryAfter(ErrResourceUnauthorized); has {
+		t.Error("Expecting no retry after")
+	}
+	// received over RPC as string
+	rErr, has := ParseErrRetryAfter(errors.New("RESOURCE_UNAUTHORIZED:RETRY_AFTER:2s"))
+	if !has {
+		t.Fatal("Expecting retry after")
+	}
+	if rErr.RetryAfter != 2*time.Second {
+		t.Errorf("Expecting: %v, received: %v", 2*time.Second, rErr.RetryAfter)
+	}
+	if !errors.Is(rErr, ErrResourceUnauthorized) {
+		t.Errorf("Expecting %v to match %v", rErr, ErrResourceUnauthorized)
+	}
+	// wrapped by the subsystem prefix
+	if rErr, has = ParseErrRetryAfter(errors.New("RESOURCES_ERROR:RESOURCE_UNAUTHORIZED:RETRY_AFTER:1s")); !has {
+		t.Fatal("Expecting retry after")
+	} else if rErr.Err.Error() != "RESOURCES_ERROR:RESOURCE_UNAUTHORIZED" || rErr.RetryAfter != time.Second {
+		t.Errorf("Unexpected error: %+v", rErr)
+	}
+	if _, has = ParseErrRetryAfter(errors.New("RESOURCE_UNAUTHORIZED:RETRY_AFTER:never")); has {
+		t.Error("Expecting no retry after for invalid duration")
+	}
+	if rcv := CastRPCErr(errors.New("RESOURCE_UNAUTHORIZED:RETRY_AFTER:2s")); !errors.Is(rcv, ErrResourceUnauthorized) {
+		t.Errorf("Expecting %v to match %v", rcv, ErrResourceUnauthorized)
+	}
+}
+
 func TestNewErrRates(t *testing.T) {
 	err := errors.New("ErrorRates")
 	if rcv := NewErrRateS(err); rcv.Error() != "RATES_ERROR:ErrorRates" {
@@ -117,6 +161,13 @@ func TestNewErrResourceS(t *testing.T) {
 	if rcv := NewErrResourceS(cgrError); rcv.Error() != "RESOURCES_ERROR:shortError" {
 		t.Errorf("Expecting: RESOURCES_ERROR:shortError, received: %+v", rcv)
 	}
+	rcv := NewErrResourceS(NewErrRetryAfter(ErrResourceUnauthorized, time.Second))
+	if exp := "RESOURCES_ERROR:RESOURCE_UNAUTHORIZED:RETRY_AFTER:1s"; rcv.Error() != exp {
+		t.Errorf("Expecting: %s, received: %+v", exp, rcv)
+	}
+	if rErr, has := ParseErrRetryAfter(rcv); !has || rErr.RetryAfter != time.Second {
+		t.Errorf("Expecting retry after of 1s, received: %+v", rcv)
+	}
 }
 
 func TestNewErrSupplierS(t *testing.T) {
@@ -244,3 +295,17 @@ func TestNewSTIRError(t *testing.T) {
 		t.Errorf("Expecting: %q, received: %q", expected, rcv.Error())
 	}
 }
+
+func TestErrRetryAfterIs(t *testing.T) {
+	rErr := NewErrResourceS(NewErrRetryAfter(ErrResourceUnauthorized, time.Second))
+	if !errors.Is(rErr, ErrResourceUnauthorized) {
+		t.Errorf("Expecting %v to match %v", rErr, ErrResourceUnauthorized)
+	}
+	if errors.Is(rErr, ErrResourceUnavailable) {
+		t.Errorf("Not expecting %v to match %v", rErr, ErrResourceUnavailable)
+	}
+	rcv := CastRPCErr(errors.New("RESOURCES_ERROR:RESOURCE_UNAUTHORIZED:RETRY_AFTER:1s"))
+	if !errors.Is(rcv, ErrResourceUnauthorized) {
+		t.Errorf("Expecting %v to match %v", rcv, ErrResourceUnauthorized)
+	}
+}
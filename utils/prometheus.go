@@ -0,0 +1,149 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Prometheus metric types
+const (
+	PrometheusCounter = "counter"
+	PrometheusGauge   = "gauge"
+	PrometheusSummary = "summary"
+
+	PrometheusStatIDs = "stat_ids" // scrape parameter selecting the StatQueues to export
+)
+
+// NewPrometheusMetrics returns an empty set of metrics
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{families: make(map[string]*prometheusFamily)}
+}
+
+// PrometheusMetrics gathers metric samples and writes them in the Prometheus text format
+type PrometheusMetrics struct {
+	families map[string]*prometheusFamily
+}
+
+type prometheusFamily struct {
+	help    string
+	typ     string
+	samples []*prometheusSample
+}
+
+type prometheusSample struct {
+	suffix string
+	labels map[string]string
+	value  float64
+}
+
+// Add adds a sample to the metric family named name, creating the family if missing
+func (pm *PrometheusMetrics) Add(name, typ, help string, labels map[string]string, value float64) {
+	pm.add(name, typ, help, EmptyString, labels, value)
+}
+
+// AddSummary adds the sum and count samples of a summary family
+func (pm *PrometheusMetrics) AddSummary(name, help string, labels map[string]string, sum float64, count uint64) {
+	pm.add(name, PrometheusSummary, help, "_sum", labels, sum)
+	pm.add(name, PrometheusSummary, help, "_count", labels, float64(count))
+}
+
+func (pm *PrometheusMetrics) add(name, typ, help, suffix string, labels map[string]string, value float64) {
+	fam, has := pm.families[name]
+	if !has {
+		fam = &prometheusFamily{help: help, typ: typ}
+		pm.families[name] = fam
+	}
+	fam.samples = append(fam.samples, &prometheusSample{
+		suffix: suffix,
+		labels: labels,
+		value:  value,
+	})
+}
+
+// WriteTo writes the metrics in the Prometheus text format, ordered by name
+func (pm *PrometheusMetrics) WriteTo(w io.Writer) (n int64, err error) {
+	names := make([]string, 0, len(pm.families))
+	for name := range pm.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, name := range names {
+		fam := pm.families[name]
+		if fam.help != EmptyString {
+			fmt.Fprintf(&sb, "# HELP %s %s\n", name, prometheusEscape(fam.help, false))
+		}
+		fmt.Fprintf(&sb, "# TYPE %s %s\n", name, fam.typ)
+		for _, smpl := range fam.samples {
+			sb.WriteString(name)
+			sb.WriteString(smpl.suffix)
+			sb.WriteString(prometheusLabels(smpl.labels))
+			sb.WriteByte(' ')
+			sb.WriteString(prometheusValue(smpl.value))
+			sb.WriteByte('\n')
+		}
+	}
+	var nW int
+	nW, err = io.WriteString(w, sb.String())
+	return int64(nW), err
+}
+
+// prometheusLabels formats the labels ordered by name
+func prometheusLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return EmptyString
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lbls := make([]string, len(keys))
+	for i, k := range keys {
+		lbls[i] = k + `="` + prometheusEscape(labels[k], true) + `"`
+	}
+	return "{" + strings.Join(lbls, ",") + "}"
+}
+
+func prometheusEscape(s string, quote bool) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	if quote {
+		s = strings.ReplaceAll(s, `"`, `\"`)
+	}
+	return s
+}
+
+func prometheusValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,56 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestPrometheusMetricsWriteTo(t *testing.T) {
+	pm := NewPrometheusMetrics()
+	pm.Add("cgrates_sessions_active", PrometheusGauge, "Number of active sessions.",
+		map[string]string{"tenant": "cgrates.org"}, 2)
+	pm.Add("cgrates_sessions_active", PrometheusGauge, "Number of active sessions.",
+		map[string]string{"tenant": `it"sys\com`}, 1)
+	pm.AddSummary("cgrates_rpc_request_duration_seconds", "Time spent\nserving requests.",
+		map[string]string{"method": "CoreSv1.Status", "codec": "*json"}, 0.25, 5)
+	pm.Add("cgrates_goroutines", PrometheusGauge, EmptyString, nil, math.Inf(1))
+	exp := `# TYPE cgrates_goroutines gauge
+cgrates_goroutines +Inf
+# HELP cgrates_rpc_request_duration_seconds Time spent\nserving requests.
+# TYPE cgrates_rpc_request_duration_seconds summary
+cgrates_rpc_request_duration_seconds_sum{codec="*json",method="CoreSv1.Status"} 0.25
+cgrates_rpc_request_duration_seconds_count{codec="*json",method="CoreSv1.Status"} 5
+# HELP cgrates_sessions_active Number of active sessions.
+# TYPE cgrates_sessions_active gauge
+cgrates_sessions_active{tenant="cgrates.org"} 2
+cgrates_sessions_active{tenant="it\"sys\\com"} 1
+`
+	var buf bytes.Buffer
+	if n, err := pm.WriteTo(&buf); err != nil {
+		t.Error(err)
+	} else if n != int64(buf.Len()) {
+		t.Errorf("Expected %d bytes written, received %d", buf.Len(), n)
+	}
+	if buf.String() != exp {
+		t.Errorf("Expected \n%s\n, received \n%s", exp, buf.String())
+	}
+}